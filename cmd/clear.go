@@ -35,19 +35,29 @@ var clearCmd = &cobra.Command{
 	},
 }
 
-// newDisplayService creates a local or remote DisplayService based on the device string.
-func newDisplayService(dev string, init bool) (display.Service, error) {
+// newDisplayService creates a local or remote DisplayService based on the device
+// string, configuring a local display with opts.
+func newDisplayService(dev string, init bool, opts ...display.LocalOption) (display.Service, error) {
 	if display.IsRemote(dev) {
-		return display.NewRemoteDisplay(dev)
+		addr, err := resolveDevice(dev)
+		if err != nil {
+			return nil, err
+		}
+		return display.NewRemoteDisplay(addr,
+			display.WithMaxMessageSize(maxMessageSize<<20),
+			display.WithToken(authToken),
+		)
 	}
 
-	local, err := display.NewLocalDisplay(dev)
+	local, err := display.NewLocalDisplay(dev, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	if init {
-		local.HardwareInit()
+		if err := local.HardwareInit(); err != nil {
+			return nil, err
+		}
 	}
 
 	return local, nil
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/justmiles/epd/lib/discovery"
+	"github.com/justmiles/epd/lib/display"
+	"github.com/spf13/cobra"
+)
+
+var discoverTimeout time.Duration
+
+func init() {
+	rootCmd.AddCommand(discoverCmd)
+	discoverCmd.PersistentFlags().DurationVar(&discoverTimeout, "timeout", 2*time.Second, "how long to wait for daemons to answer")
+}
+
+var discoverCmd = &cobra.Command{
+	Use:   "discover",
+	Short: "List EPD daemons advertised on the local network",
+	Long: `List EPD daemons advertised on the local network over mDNS. Pass a
+daemon's name, as name.local, to --device to use it without knowing its
+address.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		conn, err := discovery.Multicast()
+		if err != nil {
+			errorOut(err.Error())
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), discoverTimeout)
+		defer cancel()
+
+		services, err := discovery.Browse(ctx, conn)
+		if err != nil {
+			errorOut(err.Error())
+		}
+		if len(services) == 0 {
+			errorOut("No EPD daemons found")
+		}
+
+		sort.Slice(services, func(i, j int) bool { return services[i].Instance < services[j].Instance })
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tADDRESS\tMODEL\tRESOLUTION\tVERSION")
+		for _, s := range services {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%dx%d\t%s\n", s.Instance, s.Addr(), s.Model, s.Width, s.Height, s.Version)
+		}
+		w.Flush()
+	},
+}
+
+// resolveDevice returns the gRPC address of a remote --device, looking up
+// daemon names advertised over mDNS, as in lobby.local. A display name, as in
+// lobby.local/left, is kept.
+func resolveDevice(dev string) (string, error) {
+	if strings.HasPrefix(dev, display.UnixScheme) {
+		return dev, nil
+	}
+	addr, name := display.SplitDisplay(dev)
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return dev, nil
+	}
+
+	instance, ok := display.InstanceName(addr)
+	if !ok {
+		return "", fmt.Errorf("device %s is not a host:port, unix: socket or name.local", dev)
+	}
+
+	conn, err := discovery.Multicast()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), discoverTimeout)
+	defer cancel()
+
+	svc, err := discovery.Lookup(ctx, conn, instance)
+	if err != nil {
+		return "", err
+	}
+	if name != "" {
+		return svc.Addr() + "/" + name, nil
+	}
+	return svc.Addr(), nil
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(displaysCmd)
+}
+
+var displaysCmd = &cobra.Command{
+	Use:   "displays",
+	Short: "List the displays a remote EPD daemon serves",
+	Long: `List the displays a remote EPD daemon serves. Address one with
+--device host:port/name; without a name, commands go to the first, default,
+display.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		remote := remoteDisplay("displays")
+		defer remote.Close()
+
+		displays, err := remote.ListDisplays()
+		if err != nil {
+			errorOut(err.Error())
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tDEVICE\tRESOLUTION\tSTATE\tLAST REFRESH")
+		for _, d := range displays {
+			name := d.Display
+			if name == "" {
+				name = "-"
+			}
+			state := "awake"
+			if d.Sleeping {
+				state = "sleeping"
+			}
+			if d.LastError != "" {
+				state = "error: " + d.LastError
+			}
+			lastRefresh := "never"
+			if d.LastRefresh != nil {
+				lastRefresh = formatTime(d.LastRefresh.AsTime())
+			}
+			fmt.Fprintf(w, "%s\t%s\t%dx%d\t%s\t%s\n", name, d.Device, d.Width, d.Height, state, lastRefresh)
+		}
+		w.Flush()
+	},
+}
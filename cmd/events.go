@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/justmiles/epd/lib/display"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+}
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Tail display activity from a remote EPD daemon",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if !display.IsRemote(device) {
+			errorOut("events requires a remote --device host:port")
+		}
+
+		svc, err := newDisplayService(device, false)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer svc.Close()
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		remote := svc.(*display.RemoteDisplay)
+		if err := remote.WatchEvents(ctx, printEvent); err != nil {
+			errorOut(err.Error())
+		}
+	},
+}
+
+// printEvent writes a single event as one line of text.
+func printEvent(e *pb.Event) {
+	fields := []string{
+		e.Time.AsTime().Local().Format("2006-01-02 15:04:05"),
+		e.Type.String(),
+	}
+	if e.Operation != "" {
+		fields = append(fields, e.Operation)
+	}
+	if e.Type == pb.Event_QUEUE_CHANGED {
+		fields = append(fields, fmt.Sprintf("depth=%d", e.QueueDepth))
+	}
+	if e.StartedAt != nil && e.Type != pb.Event_REFRESH_STARTED {
+		fields = append(fields, fmt.Sprintf("took=%s", e.Time.AsTime().Sub(e.StartedAt.AsTime()).Round(time.Millisecond)))
+	}
+	if e.ClientId != "" || e.ClientAddress != "" {
+		fields = append(fields, fmt.Sprintf("client=%s", strings.TrimSpace(e.ClientId+" "+e.ClientAddress)))
+	}
+	if e.Error != "" {
+		fields = append(fields, fmt.Sprintf("error=%q", e.Error))
+	}
+	fmt.Println(strings.Join(fields, " "))
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/justmiles/epd/lib/display"
+	"github.com/spf13/cobra"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Exit codes reported by the health command.
+const (
+	healthExitServing     = 0
+	healthExitNotServing  = 1
+	healthExitUnreachable = 2
+	healthExitUsage       = 3
+)
+
+func init() {
+	rootCmd.AddCommand(healthCmd)
+}
+
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Check the health of a remote EPD daemon",
+	Long: `Check a remote EPD daemon with the standard gRPC health service, for use
+in systemd and Kubernetes probes. Exits 0 when the daemon is serving, 1 when
+it reports NOT_SERVING (for example because the panel timed out busy), 2
+when it cannot be reached and 3 when it is used wrongly, such as with a local
+--device.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if !display.IsRemote(device) {
+			fmt.Println("health requires a remote --device host:port")
+			os.Exit(healthExitUsage)
+		}
+
+		svc, err := newDisplayService(device, false)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(healthExitUnreachable)
+		}
+		defer svc.Close()
+
+		st, err := svc.(*display.RemoteDisplay).Health()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(healthExitUnreachable)
+		}
+
+		fmt.Println(st)
+		if st != healthpb.HealthCheckResponse_SERVING {
+			os.Exit(healthExitNotServing)
+		}
+	},
+}
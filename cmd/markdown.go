@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/justmiles/epd/lib/display"
+	mdpng "github.com/justmiles/epd/lib/md-png"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	log.SetFlags(0)
+	rootCmd.AddCommand(displayMarkdownCmd)
+}
+
+var displayMarkdownCmd = &cobra.Command{
+	Use:   "display-markdown",
+	Short: "Display markdown on your EPD",
+	Long: `Display markdown, passed as text or a path to a markdown file, on your EPD.
+Remote daemons render the markdown themselves.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(args) == 0 {
+			errorOut("Please pass markdown or a markdown file to display")
+		}
+
+		markdown := resolveTextOrFile(strings.Join(args, " "))
+
+		svc, err := newDisplayService(device, initialize)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer svc.Close()
+
+		if display.IsRemote(device) {
+			if err := svc.(*display.RemoteDisplay).DisplayMarkdown(markdown); err != nil {
+				errorOut(err.Error())
+			}
+		} else {
+			local := svc.(*display.LocalDisplay)
+
+			var buf bytes.Buffer
+			err := mdpng.Convert([]byte(markdown), &buf,
+				mdpng.WithWidth(local.Width()),
+				mdpng.WithHeight(local.Height()),
+				mdpng.WithPadding(20),
+				mdpng.WithFontSize(20),
+			)
+			if err != nil {
+				errorOut(err.Error())
+			}
+			if err := local.DisplayImage(buf.Bytes()); err != nil {
+				errorOut(err.Error())
+			}
+		}
+
+		if sleep {
+			svc.Sleep()
+		}
+	},
+}
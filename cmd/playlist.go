@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var (
+	playlistImage        string
+	playlistMarkdownFile string
+	playlistDashboard    bool
+	playlistDwell        time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(playlistCmd)
+	playlistCmd.AddCommand(playlistAddCmd, playlistListCmd, playlistRemoveCmd, playlistSkipCmd,
+		playlistPauseCmd, playlistResumeCmd, playlistShuffleCmd)
+
+	playlistAddCmd.Flags().StringVar(&playlistImage, "image", "", "show the image at this URL or path on the daemon, fetched every time it is shown")
+	playlistAddCmd.Flags().StringVar(&playlistMarkdownFile, "markdown-file", "", "show this markdown file, a path on the daemon read every time it is shown")
+	playlistAddCmd.Flags().BoolVar(&playlistDashboard, "dashboard", false, "render the daemon's dashboard")
+	playlistAddCmd.Flags().StringVar(&headerText, "header-text", "", "dashboard header text, or a path on the daemon to a file holding it")
+	playlistAddCmd.Flags().StringVar(&bodyText, "body-text", "", "dashboard body markdown, or a path on the daemon to a file holding it")
+	playlistAddCmd.Flags().DurationVar(&playlistDwell, "dwell", time.Minute, "how long to show the item")
+}
+
+var playlistCmd = &cobra.Command{
+	Use:   "playlist",
+	Short: "Manage the slideshow a remote EPD daemon cycles through",
+}
+
+var playlistAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add an image, markdown file or dashboard to the playlist",
+	Run: func(cmd *cobra.Command, args []string) {
+		content := 0
+		for _, name := range []string{"image", "markdown-file", "dashboard"} {
+			if cmd.Flags().Changed(name) {
+				content++
+			}
+		}
+		if content != 1 {
+			errorOut("Please pass exactly one of --image, --markdown-file or --dashboard")
+		}
+
+		item := &pb.PlaylistItem{Dwell: durationpb.New(playlistDwell)}
+		switch {
+		case playlistDashboard:
+			item.Content = &pb.PlaylistItem_Dashboard{Dashboard: &pb.DashboardContent{
+				HeaderText: headerText,
+				BodyText:   bodyText,
+			}}
+		case playlistMarkdownFile != "":
+			item.Content = &pb.PlaylistItem_MarkdownFile{MarkdownFile: playlistMarkdownFile}
+		default:
+			item.Content = &pb.PlaylistItem_Image{Image: playlistImage}
+		}
+
+		remote := remoteDisplay("playlist")
+		defer remote.Close()
+
+		added, err := remote.AddPlaylistItem(item)
+		if err != nil {
+			errorOut(err.Error())
+		}
+		fmt.Printf("Added playlist item %s\n", added.Id)
+	},
+}
+
+var playlistListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the playlist and what is currently shown",
+	Run: func(cmd *cobra.Command, args []string) {
+		remote := remoteDisplay("playlist")
+		defer remote.Close()
+
+		playlist, err := remote.Playlist()
+		if err != nil {
+			errorOut(err.Error())
+		}
+		printPlaylist(playlist)
+	},
+}
+
+var playlistRemoveCmd = &cobra.Command{
+	Use:   "remove ID",
+	Short: "Remove an item from the playlist",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		remote := remoteDisplay("playlist")
+		defer remote.Close()
+
+		if err := remote.RemovePlaylistItem(args[0]); err != nil {
+			errorOut(err.Error())
+		}
+		fmt.Printf("Removed playlist item %s\n", args[0])
+	},
+}
+
+var playlistSkipCmd = &cobra.Command{
+	Use:   "skip",
+	Short: "Show the next playlist item now",
+	Run: func(cmd *cobra.Command, args []string) {
+		remote := remoteDisplay("playlist")
+		defer remote.Close()
+
+		playlist, err := remote.SkipPlaylistItem()
+		if err != nil {
+			errorOut(err.Error())
+		}
+		fmt.Printf("Showing playlist item %s\n", playlist.CurrentId)
+	},
+}
+
+var playlistPauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Stay on the current playlist item",
+	Run: func(cmd *cobra.Command, args []string) {
+		remote := remoteDisplay("playlist")
+		defer remote.Close()
+
+		if _, err := remote.PausePlaylist(true); err != nil {
+			errorOut(err.Error())
+		}
+		fmt.Println("Playlist paused")
+	},
+}
+
+var playlistResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume cycling through the playlist",
+	Run: func(cmd *cobra.Command, args []string) {
+		remote := remoteDisplay("playlist")
+		defer remote.Close()
+
+		if _, err := remote.PausePlaylist(false); err != nil {
+			errorOut(err.Error())
+		}
+		fmt.Println("Playlist resumed")
+	},
+}
+
+var playlistShuffleCmd = &cobra.Command{
+	Use:       "shuffle on|off",
+	Short:     "Play the playlist shuffled or in order",
+	Args:      cobra.ExactValidArgs(1),
+	ValidArgs: []string{"on", "off"},
+	Run: func(cmd *cobra.Command, args []string) {
+		remote := remoteDisplay("playlist")
+		defer remote.Close()
+
+		if _, err := remote.ShufflePlaylist(args[0] == "on"); err != nil {
+			errorOut(err.Error())
+		}
+		fmt.Printf("Playlist shuffle %s\n", args[0])
+	},
+}
+
+// printPlaylist writes the playlist as a table, marking the current item.
+func printPlaylist(playlist *pb.Playlist) {
+	mode := "in order"
+	if playlist.Shuffle {
+		mode = "shuffled"
+	}
+	if playlist.Paused {
+		mode += ", paused"
+	} else if playlist.NextChange != nil {
+		mode += ", next change " + formatTime(playlist.NextChange.AsTime())
+	}
+	fmt.Printf("Playing %s\n\n", mode)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tID\tDWELL\tCONTENT")
+	for _, item := range playlist.Items {
+		marker := ""
+		if item.Id == playlist.CurrentId {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", marker, item.Id, item.Dwell.AsDuration(), describePlaylistItem(item))
+	}
+	w.Flush()
+}
+
+// describePlaylistItem summarizes a playlist item's content in a few words.
+func describePlaylistItem(item *pb.PlaylistItem) string {
+	switch c := item.Content.(type) {
+	case *pb.PlaylistItem_Image:
+		return "image " + c.Image
+	case *pb.PlaylistItem_MarkdownFile:
+		return "markdown " + c.MarkdownFile
+	case *pb.PlaylistItem_Dashboard:
+		return "dashboard"
+	}
+	return ""
+}
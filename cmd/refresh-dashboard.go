@@ -1,33 +1,119 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	dashboard "github.com/justmiles/epd/lib/dashboard"
 	"github.com/justmiles/epd/lib/display"
+	"github.com/justmiles/epd/lib/locale"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
 	weatherAPIOptions dashboard.WeatherAPIOptions
+	weatherFile       string
+	weatherCache      string
+	weatherCacheTTL   time.Duration
 	location          string
+	localeTag         string
+	layoutFile        string
+	templates         bool
+	templateHost      bool
+	templateShell     bool
 	headerText        string
 	bodyText          string
+	renderOnDaemon    bool
 )
 
 func init() {
 	rootCmd.AddCommand(refreshDashboardCmd)
-	refreshDashboardCmd.PersistentFlags().StringVar(&weatherAPIOptions.WeatherAPIKey, "weather-api-key", envDefault("EPD_WEATHER_API_KEY", ""), "your openweathermap.org API key (env: EPD_WEATHER_API_KEY)")
-	refreshDashboardCmd.PersistentFlags().StringVar(&weatherAPIOptions.WeatherLanguage, "weather-language", envDefault("EPD_WEATHER_LANGUAGE", "EN"), "language for weather (env: EPD_WEATHER_LANGUAGE)")
-	refreshDashboardCmd.PersistentFlags().StringVar(&weatherAPIOptions.WeatherTempUnit, "weather-temp-unit", envDefault("EPD_WEATHER_TEMP_UNIT", "F"), "temperature unit for weather (env: EPD_WEATHER_TEMP_UNIT)")
-	refreshDashboardCmd.PersistentFlags().StringVar(&weatherAPIOptions.WeatherCountry, "weather-country", envDefault("EPD_WEATHER_COUNTRY", "US"), "country for weather (env: EPD_WEATHER_COUNTRY)")
-	refreshDashboardCmd.PersistentFlags().StringVar(&location, "location", envDefault("EPD_LOCATION", "America/Chicago"), "location for date (env: EPD_LOCATION)")
-	refreshDashboardCmd.PersistentFlags().IntVar(&weatherAPIOptions.WeatherZipCode, "weather-zip", envDefaultInt("EPD_WEATHER_ZIP", 60601), "zip code for weather (env: EPD_WEATHER_ZIP)")
-	refreshDashboardCmd.PersistentFlags().StringVar(&headerText, "header-text", envDefault("EPD_HEADER_TEXT", ""), "custom header text for the dashboard (env: EPD_HEADER_TEXT)")
-	refreshDashboardCmd.PersistentFlags().StringVar(&bodyText, "body-text", envDefault("EPD_BODY_TEXT", ""), "custom body text for the dashboard (env: EPD_BODY_TEXT)")
+	addDashboardFlags(refreshDashboardCmd.PersistentFlags())
+	refreshDashboardCmd.PersistentFlags().StringVar(&headerText, "header-text", envDefault("EPD_HEADER_TEXT", ""), "custom header text for the dashboard, or a file holding it, as a template unless --templates=false (env: EPD_HEADER_TEXT)")
+	refreshDashboardCmd.PersistentFlags().StringVar(&bodyText, "body-text", envDefault("EPD_BODY_TEXT", ""), "custom body markdown for the dashboard, or a file holding it, as a template unless --templates=false (env: EPD_BODY_TEXT)")
 	refreshDashboardCmd.PersistentFlags().BoolVar(&previewImage, "preview", false, "preview the dashboard instead of updating the display")
+	refreshDashboardCmd.PersistentFlags().BoolVar(&renderOnDaemon, "render-on-daemon", false, "send only the header and body, with templates executed here, to a remote daemon, which renders the dashboard with its own weather settings")
+}
+
+// addDashboardFlags registers the weather, location, locale, template and
+// layout flags shared by every command that renders the dashboard.
+func addDashboardFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&weatherAPIOptions.WeatherAPIKey, "weather-api-key", envDefault("EPD_WEATHER_API_KEY", ""), "your openweathermap.org API key (env: EPD_WEATHER_API_KEY)")
+	flags.StringVar(&weatherAPIOptions.WeatherLanguage, "weather-language", envDefault("EPD_WEATHER_LANGUAGE", ""), "language for weather, defaults to the --locale language if openweathermap.org supports it, otherwise EN (env: EPD_WEATHER_LANGUAGE)")
+	flags.StringVar(&weatherAPIOptions.WeatherTempUnit, "weather-temp-unit", envDefault("EPD_WEATHER_TEMP_UNIT", "F"), "temperature unit for weather (env: EPD_WEATHER_TEMP_UNIT)")
+	flags.StringVar(&weatherAPIOptions.WeatherCountry, "weather-country", envDefault("EPD_WEATHER_COUNTRY", "US"), "country for weather (env: EPD_WEATHER_COUNTRY)")
+	flags.StringVar(&weatherFile, "weather-file", envDefault("EPD_WEATHER_FILE", ""), "JSON file with the weather to show instead of calling openweathermap.org, e.g. to try layouts offline (env: EPD_WEATHER_FILE)")
+	flags.StringVar(&weatherCache, "weather-cache", envDefault("EPD_WEATHER_CACHE", ""), "file keeping the last weather, to save API calls and to fall back on when openweathermap.org fails; defaults to epd/weather.json in the user cache directory, or weather.json in --state-dir for serve (env: EPD_WEATHER_CACHE)")
+	flags.DurationVar(&weatherCacheTTL, "weather-cache-ttl", envDefaultDuration("EPD_WEATHER_CACHE_TTL", 10*time.Minute), "how long to reuse the cached weather before asking openweathermap.org again, 0 to always ask (env: EPD_WEATHER_CACHE_TTL)")
+	flags.StringVar(&location, "location", envDefault("EPD_LOCATION", "America/Chicago"), "location for date (env: EPD_LOCATION)")
+	flags.StringVar(&localeTag, "locale", envDefault("EPD_LOCALE", "en"), "locale for the names of days and months, dates, numbers and labels, e.g. de or en-GB; one of "+strings.Join(locale.Tags(), ", ")+" (env: EPD_LOCALE)")
+	flags.IntVar(&weatherAPIOptions.WeatherZipCode, "weather-zip", envDefaultInt("EPD_WEATHER_ZIP", 60601), "zip code for weather (env: EPD_WEATHER_ZIP)")
+	flags.BoolVar(&templates, "templates", envDefaultBool("EPD_TEMPLATES", true), "execute the header and body text as Go templates, e.g. {{ date \"Jan 2\" }} (env: EPD_TEMPLATES)")
+	flags.BoolVar(&templateHost, "template-host", envDefaultBool("EPD_TEMPLATE_HOST", false), "let header and body templates read environment variables and files with {{ env \"...\" }}, {{ file \"...\" }} and {{ json \"...\" \"...\" }} (env: EPD_TEMPLATE_HOST)")
+	flags.BoolVar(&templateShell, "template-shell", envDefaultBool("EPD_TEMPLATE_SHELL", false), "let header and body templates run shell commands with {{ shell \"...\" }} (env: EPD_TEMPLATE_SHELL)")
+	flags.StringVar(&layoutFile, "layout", envDefault("EPD_LAYOUT", ""), "YAML or JSON file arranging the dashboard's widgets, defaults to the built-in layout (env: EPD_LAYOUT)")
+}
+
+// weatherOptions returns the dashboard option for the weather: --weather-file
+// if set, otherwise openweathermap.org when an API key is set or required,
+// cached in --weather-cache or in cacheDir, the user cache directory if empty.
+func weatherOptions(required bool, cacheDir string) ([]dashboard.Options, error) {
+	if weatherFile != "" {
+		return []dashboard.Options{dashboard.WithWeatherProvider(dashboard.WeatherFile(weatherFile))}, nil
+	}
+	if !required && weatherAPIOptions.WeatherAPIKey == "" {
+		return nil, nil
+	}
+
+	if weatherAPIOptions.WeatherLanguage == "" {
+		l, err := locale.Lookup(localeTag)
+		if err != nil {
+			return nil, err
+		}
+		weatherAPIOptions.WeatherLanguage = dashboard.OpenWeatherMapLanguage(l)
+	}
+
+	provider, err := dashboard.NewOpenWeatherMap(&weatherAPIOptions)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize weather api: %s", err)
+	}
+
+	path := weatherCache
+	if path == "" {
+		if cacheDir == "" {
+			userCacheDir, err := os.UserCacheDir()
+			if err != nil {
+				log.Printf("Not caching the weather: %v", err)
+				return []dashboard.Options{dashboard.WithWeatherProvider(provider)}, nil
+			}
+			cacheDir = filepath.Join(userCacheDir, "epd")
+		}
+		path = filepath.Join(cacheDir, "weather.json")
+	}
+
+	// Saved weather is only reused for the same place, units and language
+	o := weatherAPIOptions
+	key := fmt.Sprintf("openweathermap zip=%d,%s unit=%s lang=%s", o.WeatherZipCode, o.WeatherCountry, strings.ToUpper(o.WeatherTempUnit), strings.ToUpper(o.WeatherLanguage))
+	cache := dashboard.NewWeatherCache(provider, path, key, weatherCacheTTL)
+	return []dashboard.Options{dashboard.WithWeatherProvider(cache)}, nil
+}
+
+// layoutOptions returns the dashboard option for --layout, if set.
+func layoutOptions() ([]dashboard.Options, error) {
+	if layoutFile == "" {
+		return nil, nil
+	}
+	layout, err := dashboard.LoadLayout(layoutFile)
+	if err != nil {
+		return nil, err
+	}
+	return []dashboard.Options{dashboard.WithLayout(layout)}, nil
 }
 
 var refreshDashboardCmd = &cobra.Command{
@@ -35,20 +121,64 @@ var refreshDashboardCmd = &cobra.Command{
 	Short: "Update your display with a custom dashboard",
 	Run: func(cmd *cobra.Command, args []string) {
 
-		// Generate the dashboard image locally (no EPD needed for generation)
-		d, err := dashboard.NewDashboard(
-			dashboard.WithWeatherAPI(&weatherAPIOptions),
-		)
+		// If headerText or bodyText point to a file, read the file contents
+		headerText = resolveTextOrFile(headerText)
+		bodyText = resolveTextOrFile(bodyText)
+
+		if renderOnDaemon && !display.IsRemote(device) {
+			errorOut("--render-on-daemon requires a remote --device host:port")
+		}
+
+		// Generate the dashboard image locally (no EPD needed for generation).
+		// The daemon never executes templates sent to it, so they are executed
+		// here even when it renders the dashboard.
+		opts, err := layoutOptions()
+		if err != nil {
+			log.Fatal(err)
+		}
+		weatherOpts, err := weatherOptions(!renderOnDaemon, "")
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, weatherOpts...)
+		d, err := dashboard.NewDashboard(append(opts,
+			dashboard.WithLocation(location),
+			dashboard.WithLocale(localeTag),
+			dashboard.WithTemplates(templates),
+			dashboard.WithTemplateHost(templateHost),
+			dashboard.WithTemplateShell(templateShell),
+		)...)
 
 		if err != nil {
 			log.Fatalf("error creating custom dashboard: %s", err)
 		}
 
-		const outputImage = "dashboard-image.png"
+		ctx := context.Background()
+		if headerText, err = d.ExecuteTemplate(ctx, "header", headerText); err != nil {
+			log.Fatal(err)
+		}
+		if bodyText, err = d.ExecuteTemplate(ctx, "body", bodyText); err != nil {
+			log.Fatal(err)
+		}
 
-		// If headerText or bodyText point to a file, read the file contents
-		headerText = resolveTextOrFile(headerText)
-		bodyText = resolveTextOrFile(bodyText)
+		if renderOnDaemon {
+			svc, err := newDisplayService(device, false)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			defer svc.Close()
+
+			if err := svc.(*display.RemoteDisplay).RenderDashboard(headerText, bodyText); err != nil {
+				log.Fatal(err)
+			}
+			if sleep {
+				svc.Sleep()
+			}
+			return
+		}
+
+		const outputImage = "dashboard-image.png"
 
 		err = d.Generate(outputImage, headerText, bodyText)
 		if err != nil {
@@ -5,21 +5,25 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 var (
 	debug, initialize, sleep bool
-	device                   string
+	device, authToken        string
+	maxMessageSize           int
 )
 
 func init() {
 	log.SetFlags(0)
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug logging")
-	rootCmd.PersistentFlags().StringVarP(&device, "device", "d", envDefault("EPD_DEVICE", "epd7in5v2"), "your supported EPD device type or remote host:port (env: EPD_DEVICE)")
+	rootCmd.PersistentFlags().StringVarP(&device, "device", "d", envDefault("EPD_DEVICE", "epd7in5v2"), "your supported EPD device type, or a remote daemon's host:port, unix:// socket or discovered name.local (env: EPD_DEVICE)")
 	rootCmd.PersistentFlags().BoolVarP(&initialize, "initialize", "i", false, "initialize (wake) the device before updating it. Required if in sleep mode")
 	rootCmd.PersistentFlags().BoolVarP(&sleep, "sleep", "s", false, "set the device to sleep mode after updating display")
+	rootCmd.PersistentFlags().StringVar(&authToken, "token", envDefault("EPD_TOKEN", ""), "shared token required by the daemon and sent by remote clients (env: EPD_TOKEN)")
+	rootCmd.PersistentFlags().IntVar(&maxMessageSize, "max-message-size", envDefaultInt("EPD_MAX_MESSAGE_SIZE", 4), "maximum gRPC message size in MiB, for both the daemon and remote clients (env: EPD_MAX_MESSAGE_SIZE)")
 }
 
 // envDefault returns the value of the environment variable if set, otherwise the fallback.
@@ -40,6 +44,36 @@ func envDefaultInt(envVar string, fallback int) int {
 	return fallback
 }
 
+// envDefaultFloat returns the float value of the environment variable if set, otherwise the fallback.
+func envDefaultFloat(envVar string, fallback float64) float64 {
+	if v := os.Getenv(envVar); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
+
+// envDefaultBool returns the boolean value of the environment variable if set, otherwise the fallback.
+func envDefaultBool(envVar string, fallback bool) bool {
+	if v := os.Getenv(envVar); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+// envDefaultDuration returns the duration value of the environment variable if set, otherwise the fallback.
+func envDefaultDuration(envVar string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(envVar); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
 // resolveTextOrFile checks if the input string is a path to an existing file.
 // If so, it reads and returns the file contents. Otherwise, it returns the string as-is.
 func resolveTextOrFile(s string) string {
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/justmiles/epd/lib/display"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scheduleCron         string
+	scheduleText         string
+	scheduleMarkdownFile string
+	scheduleImageURL     string
+	scheduleDashboard    bool
+)
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+	scheduleCmd.AddCommand(scheduleAddCmd, scheduleListCmd, scheduleRemoveCmd, scheduleQuietHoursCmd)
+
+	scheduleAddCmd.Flags().StringVar(&scheduleCron, "cron", "", "five-field cron expression or descriptor, e.g. \"*/15 7-22 * * *\" or \"@hourly\"")
+	scheduleAddCmd.Flags().StringVar(&scheduleText, "text", "", "display this text")
+	scheduleAddCmd.Flags().StringVar(&scheduleMarkdownFile, "markdown-file", "", "display this markdown file, a path on the daemon read on every run")
+	scheduleAddCmd.Flags().StringVar(&scheduleImageURL, "image-url", "", "display the image at this URL or path on the daemon, fetched on every run")
+	scheduleAddCmd.Flags().BoolVar(&scheduleDashboard, "dashboard", false, "render the daemon's dashboard")
+	scheduleAddCmd.Flags().StringVar(&headerText, "header-text", "", "dashboard header text, or a path on the daemon to a file holding it")
+	scheduleAddCmd.Flags().StringVar(&bodyText, "body-text", "", "dashboard body markdown, or a path on the daemon to a file holding it")
+	scheduleAddCmd.MarkFlagRequired("cron")
+}
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage content a remote EPD daemon displays on a schedule",
+}
+
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Schedule content on a remote EPD daemon",
+	Run: func(cmd *cobra.Command, args []string) {
+		content := 0
+		for _, name := range []string{"text", "markdown-file", "image-url", "dashboard"} {
+			if cmd.Flags().Changed(name) {
+				content++
+			}
+		}
+		if content != 1 {
+			errorOut("Please pass exactly one of --text, --markdown-file, --image-url or --dashboard")
+		}
+
+		schedule := &pb.Schedule{Cron: scheduleCron}
+		switch {
+		case scheduleDashboard:
+			schedule.Content = &pb.Schedule_Dashboard{Dashboard: &pb.DashboardContent{
+				HeaderText: headerText,
+				BodyText:   bodyText,
+			}}
+		case scheduleMarkdownFile != "":
+			schedule.Content = &pb.Schedule_MarkdownFile{MarkdownFile: scheduleMarkdownFile}
+		case scheduleImageURL != "":
+			schedule.Content = &pb.Schedule_ImageUrl{ImageUrl: scheduleImageURL}
+		default:
+			schedule.Content = &pb.Schedule_Text{Text: scheduleText}
+		}
+
+		remote := remoteDisplay("schedule")
+		defer remote.Close()
+
+		added, err := remote.AddSchedule(schedule)
+		if err != nil {
+			errorOut(err.Error())
+		}
+		fmt.Printf("Added schedule %s, next run %s\n", added.Id, formatTime(added.NextRun.AsTime()))
+	},
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled content on a remote EPD daemon",
+	Run: func(cmd *cobra.Command, args []string) {
+		remote := remoteDisplay("schedule")
+		defer remote.Close()
+
+		resp, err := remote.ListSchedules()
+		if err != nil {
+			errorOut(err.Error())
+		}
+
+		if q := resp.QuietHours; q.GetStart() != "" {
+			fmt.Printf("Quiet hours: %s-%s\n\n", q.Start, q.End)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tCRON\tNEXT RUN\tCONTENT")
+		for _, s := range resp.Schedules {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Id, s.Cron, formatTime(s.NextRun.AsTime()), describeSchedule(s))
+		}
+		w.Flush()
+	},
+}
+
+var scheduleRemoveCmd = &cobra.Command{
+	Use:   "remove ID",
+	Short: "Remove scheduled content from a remote EPD daemon",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		remote := remoteDisplay("schedule")
+		defer remote.Close()
+
+		if err := remote.RemoveSchedule(args[0]); err != nil {
+			errorOut(err.Error())
+		}
+		fmt.Printf("Removed schedule %s\n", args[0])
+	},
+}
+
+var scheduleQuietHoursCmd = &cobra.Command{
+	Use:   "quiet-hours [START END]",
+	Short: "Set the daily window in which scheduled content doesn't run",
+	Long: `Set the daily window, as HH:MM times in the daemon's local time, in which
+scheduled content doesn't run. The window may wrap past midnight, e.g.
+"epd schedule quiet-hours 22:00 07:00". Pass no times to disable quiet hours.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 && len(args) != 2 {
+			return fmt.Errorf("expected a start and end time, or none to disable quiet hours")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		var start, end string
+		if len(args) == 2 {
+			start, end = args[0], args[1]
+		}
+
+		remote := remoteDisplay("schedule")
+		defer remote.Close()
+
+		if err := remote.SetQuietHours(start, end); err != nil {
+			errorOut(err.Error())
+		}
+		if start == "" {
+			fmt.Println("Quiet hours disabled")
+		} else {
+			fmt.Printf("Quiet hours set to %s-%s\n", start, end)
+		}
+	},
+}
+
+// remoteDisplay connects to the remote daemon named by --device, exiting if
+// the device isn't remote or can't be reached.
+func remoteDisplay(command string) *display.RemoteDisplay {
+	if !display.IsRemote(device) {
+		errorOut(command + " requires a remote --device host:port")
+	}
+
+	svc, err := newDisplayService(device, false)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	return svc.(*display.RemoteDisplay)
+}
+
+// describeSchedule summarizes a schedule entry's content in a few words.
+func describeSchedule(s *pb.Schedule) string {
+	switch c := s.Content.(type) {
+	case *pb.Schedule_Dashboard:
+		return "dashboard"
+	case *pb.Schedule_MarkdownFile:
+		return "markdown " + c.MarkdownFile
+	case *pb.Schedule_ImageUrl:
+		return "image " + c.ImageUrl
+	case *pb.Schedule_Text:
+		return fmt.Sprintf("text %q", c.Text)
+	}
+	return ""
+}
+
+// formatTime formats t in local time, as the other commands print times.
+func formatTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/justmiles/epd/lib/display"
+	"github.com/spf13/cobra"
+)
+
+var (
+	screenshotOutput   string
+	screenshotStateDir string
+)
+
+func init() {
+	log.SetFlags(0)
+	rootCmd.AddCommand(screenshotCmd)
+
+	screenshotCmd.PersistentFlags().StringVarP(&screenshotOutput, "output", "o", "screenshot.png", "file to write the PNG screenshot to")
+	screenshotCmd.PersistentFlags().StringVar(&screenshotStateDir, "state-dir", envDefault("EPD_STATE_DIR", "/var/lib/epd"), "for a local --device, the --state-dir of the epd serve daemon driving it, with the display's name appended for one of several (env: EPD_STATE_DIR)")
+}
+
+var screenshotCmd = &cobra.Command{
+	Use:   "screenshot",
+	Short: "Save the image currently shown on your EPD",
+	Run: func(cmd *cobra.Command, args []string) {
+
+		var (
+			pngData []byte
+			updated time.Time
+			err     error
+		)
+		if display.IsRemote(device) {
+			pngData, updated, err = remoteFrame()
+		} else {
+			pngData, updated, err = localFrame()
+		}
+		if err != nil {
+			errorOut(err.Error())
+		}
+
+		if err := os.WriteFile(screenshotOutput, pngData, 0644); err != nil {
+			errorOut(err.Error())
+		}
+
+		log.Printf("Saved frame displayed at %s to %s", updated.Local().Format("2006-01-02 15:04:05"), screenshotOutput)
+	},
+}
+
+// remoteFrame asks the daemon at --device for its current frame.
+func remoteFrame() ([]byte, time.Time, error) {
+	svc, err := newDisplayService(device, false)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer svc.Close()
+	return svc.CurrentFrame()
+}
+
+// localFrame reads the current frame of a local panel from the file epd serve
+// persists it to, since only the daemon knows what the panel shows.
+func localFrame() ([]byte, time.Time, error) {
+	if !slices.Contains(display.SupportedDevices, device) {
+		return nil, time.Time{}, fmt.Errorf("device %s is not supported", device)
+	}
+	path := filepath.Join(screenshotStateDir, "frame.png")
+	pngData, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, time.Time{}, fmt.Errorf("no frame in %s; is epd serve driving the display with --state-dir %s?", path, screenshotStateDir)
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return pngData, info.ModTime(), nil
+}
@@ -1,24 +1,67 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
+	"github.com/justmiles/epd/lib/dashboard"
+	"github.com/justmiles/epd/lib/discovery"
+	"github.com/justmiles/epd/lib/display"
 	"github.com/justmiles/epd/lib/server"
 	pb "github.com/justmiles/epd/proto/epdpb"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
-var servePort int
+var (
+	servePort        int
+	serveHTTPPort    int
+	serveStateDir    string
+	serveIdle        time.Duration
+	serveTemplateDir string
+	serveMQTT        server.MQTTConfig
+
+	serveSocket      string
+	serveSocketMode  string
+	serveSocketGroup string
+
+	serveMDNS     bool
+	serveMDNSName string
+
+	serveDisplays []string
+)
 
 func init() {
 	rootCmd.AddCommand(serveCmd)
-	serveCmd.PersistentFlags().IntVar(&servePort, "port", 50051, "gRPC server port")
+	serveCmd.PersistentFlags().IntVar(&servePort, "port", 50051, "gRPC server port, 0 to listen only on --socket")
+	serveCmd.PersistentFlags().StringVar(&serveSocket, "socket", envDefault("EPD_SOCKET", ""), "also serve gRPC on this Unix socket, e.g. /run/epd.sock (env: EPD_SOCKET)")
+	serveCmd.PersistentFlags().StringVar(&serveSocketMode, "socket-mode", envDefault("EPD_SOCKET_MODE", "0660"), "file permissions of the Unix socket, in octal (env: EPD_SOCKET_MODE)")
+	serveCmd.PersistentFlags().StringVar(&serveSocketGroup, "socket-group", envDefault("EPD_SOCKET_GROUP", ""), "group that owns the Unix socket (env: EPD_SOCKET_GROUP)")
+	serveCmd.PersistentFlags().IntVar(&serveHTTPPort, "http-port", envDefaultInt("EPD_HTTP_PORT", 0), "HTTP/JSON API port, 0 to disable (env: EPD_HTTP_PORT)")
+	serveCmd.PersistentFlags().StringArrayVar(&serveDisplays, "display", nil, "serve a named display, as name=device[,reset=PIN,dc=PIN,cs=PIN,busy=PIN,idle-timeout=DURATION]; repeat for each panel, the first is the default")
+	serveCmd.PersistentFlags().StringVar(&serveStateDir, "state-dir", envDefault("EPD_STATE_DIR", "/var/lib/epd"), "directory for daemon state such as the current frame (env: EPD_STATE_DIR)")
+	serveCmd.PersistentFlags().StringVar(&serveTemplateDir, "template-dir", envDefault("EPD_TEMPLATE_DIR", ""), "directory of dashboard header and body templates that schedules and playlists may name (env: EPD_TEMPLATE_DIR)")
+	serveCmd.PersistentFlags().BoolVar(&serveMDNS, "mdns", envDefaultBool("EPD_MDNS", true), "advertise the daemon on the local network with mDNS (env: EPD_MDNS)")
+	serveCmd.PersistentFlags().StringVar(&serveMDNSName, "mdns-name", envDefault("EPD_MDNS_NAME", ""), "name to advertise the daemon as, defaults to the host name (env: EPD_MDNS_NAME)")
+	addDashboardFlags(serveCmd.PersistentFlags())
+	addTextFlags(serveCmd.PersistentFlags())
+	serveCmd.PersistentFlags().DurationVar(&serveIdle, "idle-timeout", envDefaultDuration("EPD_IDLE_TIMEOUT", 5*time.Minute), "put the panel to sleep after this long without a refresh, 0 to keep it awake (env: EPD_IDLE_TIMEOUT)")
+	serveCmd.PersistentFlags().StringVar(&serveMQTT.Broker, "mqtt-broker", envDefault("EPD_MQTT_BROKER", ""), "MQTT broker URL to subscribe to, e.g. tcp://broker:1883 (env: EPD_MQTT_BROKER)")
+	serveCmd.PersistentFlags().StringVar(&serveMQTT.Topic, "mqtt-topic", envDefault("EPD_MQTT_TOPIC", "epd"), "MQTT topic prefix (env: EPD_MQTT_TOPIC)")
+	serveCmd.PersistentFlags().StringVar(&serveMQTT.ClientID, "mqtt-client-id", envDefault("EPD_MQTT_CLIENT_ID", "epd"), "MQTT client ID (env: EPD_MQTT_CLIENT_ID)")
+	serveCmd.PersistentFlags().StringVar(&serveMQTT.Username, "mqtt-username", envDefault("EPD_MQTT_USERNAME", ""), "MQTT username (env: EPD_MQTT_USERNAME)")
+	serveCmd.PersistentFlags().StringVar(&serveMQTT.Password, "mqtt-password", envDefault("EPD_MQTT_PASSWORD", ""), "MQTT password (env: EPD_MQTT_PASSWORD)")
 }
 
 var serveCmd = &cobra.Command{
@@ -26,22 +69,118 @@ var serveCmd = &cobra.Command{
 	Short: "Run as a daemon, exposing the EPD over gRPC",
 	Long: `Run the EPD as a daemon process that listens for gRPC requests.
 Other machines can then use the display-image and display-text commands
-with --device host:port to push content to this display remotely.`,
+with --device host:port to push content to this display remotely. Local
+services can use --device unix:///run/epd.sock when --socket is set.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
-		// Use the root --device flag for the local hardware device type
-		epdServer, err := server.NewEPDServer(device)
+		// The daemon renders dashboards itself, with weather only when a key or
+		// file is set
+		dashboardOpts := []dashboard.Options{
+			dashboard.WithLocation(location),
+			dashboard.WithLocale(localeTag),
+			dashboard.WithTemplates(templates),
+			dashboard.WithTemplateHost(templateHost),
+			dashboard.WithTemplateShell(templateShell),
+		}
+		weatherOpts, err := weatherOptions(false, serveStateDir)
 		if err != nil {
-			log.Fatalf("Failed to initialize EPD server: %v", err)
+			log.Fatal(err)
+		}
+		dashboardOpts = append(dashboardOpts, weatherOpts...)
+		layoutOpts, err := layoutOptions()
+		if err != nil {
+			log.Fatal(err)
+		}
+		dashboardOpts = append(dashboardOpts, layoutOpts...)
+		dash, err := dashboard.NewDashboard(dashboardOpts...)
+		if err != nil {
+			log.Fatalf("Failed to configure dashboard: %v", err)
 		}
 
-		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", servePort))
+		textOpts, err := textOptions()
 		if err != nil {
-			log.Fatalf("Failed to listen on port %d: %v", servePort, err)
+			log.Fatal(err)
 		}
 
-		grpcServer := grpc.NewServer()
-		pb.RegisterEPDServiceServer(grpcServer, epdServer)
+		epdServers, err := newEPDServers(dash, textOpts)
+		if err != nil {
+			log.Fatalf("Failed to initialize EPD server: %v", err)
+		}
+		mux, err := server.NewMux(epdServers...)
+		if err != nil {
+			log.Fatalf("Failed to initialize EPD server: %v", err)
+		}
+
+		var listeners []net.Listener
+		if servePort != 0 {
+			lis, err := net.Listen("tcp", fmt.Sprintf(":%d", servePort))
+			if err != nil {
+				log.Fatalf("Failed to listen on port %d: %v", servePort, err)
+			}
+			listeners = append(listeners, lis)
+		}
+		if serveSocket != "" {
+			mode, err := strconv.ParseUint(serveSocketMode, 8, 32)
+			if err != nil {
+				log.Fatalf("Invalid --socket-mode %q: %v", serveSocketMode, err)
+			}
+			lis, err := server.ListenUnix(serveSocket, os.FileMode(mode), serveSocketGroup)
+			if err != nil {
+				log.Fatalf("Failed to listen on socket: %v", err)
+			}
+			listeners = append(listeners, lis)
+		}
+		if len(listeners) == 0 {
+			log.Fatal("Nothing to listen on: set --port or --socket")
+		}
+
+		grpcServer := grpc.NewServer(
+			grpc.MaxRecvMsgSize(maxMessageSize<<20),
+			grpc.MaxSendMsgSize(maxMessageSize<<20),
+			grpc.ChainUnaryInterceptor(mux.UnaryInterceptor()),
+			grpc.ChainStreamInterceptor(mux.StreamInterceptor()),
+		)
+		pb.RegisterEPDServiceServer(grpcServer, mux)
+		healthpb.RegisterHealthServer(grpcServer, mux.HealthServer())
+		reflection.Register(grpcServer)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		if serveHTTPPort != 0 {
+			go func() {
+				log.Printf("EPD HTTP API listening on :%d", serveHTTPPort)
+				if err := mux.ListenAndServeHTTP(ctx, fmt.Sprintf(":%d", serveHTTPPort)); err != nil {
+					log.Fatal(err)
+				}
+			}()
+		}
+
+		if serveMDNS && servePort != 0 {
+			go func() {
+				if err := advertise(ctx, epdServers[0]); err != nil {
+					log.Printf("Warning: not advertising over mDNS: %v", err)
+				}
+			}()
+		}
+
+		if serveMQTT.Broker != "" {
+			for _, epdServer := range epdServers {
+				// With several displays, each gets its own topic and client
+				cfg := serveMQTT
+				if name := epdServer.Name(); name != "" {
+					cfg.Topic = strings.TrimSuffix(cfg.Topic, "/") + "/" + name
+					cfg.ClientID += "-" + name
+				}
+				go func() {
+					log.Printf("EPD subscribing to MQTT topic %s/#", cfg.Topic)
+					// MQTT is optional, so its failures never stop the daemon
+					if err := epdServer.RunMQTT(ctx, cfg); err != nil {
+						log.Printf("MQTT error: %v", err)
+					}
+				}()
+			}
+		}
 
 		// Graceful shutdown
 		sigCh := make(chan os.Signal, 1)
@@ -49,13 +188,157 @@ with --device host:port to push content to this display remotely.`,
 		go func() {
 			<-sigCh
 			log.Println("Received shutdown signal")
-			epdServer.Shutdown()
+			cancel()
+			mux.Shutdown()
 			grpcServer.GracefulStop()
 		}()
 
-		log.Printf("EPD daemon listening on :%d (device: %s)", servePort, device)
-		if err := grpcServer.Serve(lis); err != nil {
-			log.Fatalf("gRPC server error: %v", err)
+		errCh := make(chan error, len(listeners))
+		for _, lis := range listeners {
+			log.Printf("EPD daemon listening on %s (%s)", lis.Addr(), describeDisplays(epdServers))
+			go func(lis net.Listener) {
+				errCh <- grpcServer.Serve(lis)
+			}(lis)
+		}
+		for range listeners {
+			if err := <-errCh; err != nil {
+				log.Fatalf("gRPC server error: %v", err)
+			}
 		}
 	},
 }
+
+// newEPDServers creates the displays to serve: one per --display, each with
+// its state in a subdirectory of --state-dir named after it, or the root
+// --device alone.
+func newEPDServers(dash *dashboard.Dashboard, textOpts display.TextOptions) ([]*server.EPDServer, error) {
+	loc, err := time.LoadLocation(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid location: %w", err)
+	}
+	opts := []server.Option{
+		server.WithAuthToken(authToken),
+		server.WithDashboard(dash),
+		server.WithTextOptions(textOpts),
+		server.WithLocation(loc),
+		server.WithTemplateDir(serveTemplateDir),
+	}
+
+	if len(serveDisplays) == 0 {
+		// Use the root --device flag for the local hardware device type
+		s, err := server.NewEPDServer(device, append(opts,
+			server.WithStateDir(serveStateDir),
+			server.WithIdleTimeout(serveIdle),
+		)...)
+		if err != nil {
+			return nil, err
+		}
+		return []*server.EPDServer{s}, nil
+	}
+
+	var servers []*server.EPDServer
+	for _, spec := range serveDisplays {
+		d, err := parseDisplaySpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		s, err := server.NewEPDServer(d.device, append(opts,
+			server.WithName(d.name),
+			server.WithPins(d.pins),
+			server.WithStateDir(filepath.Join(serveStateDir, d.name)),
+			server.WithIdleTimeout(d.idleTimeout),
+		)...)
+		if err != nil {
+			return nil, fmt.Errorf("display %s: %w", d.name, err)
+		}
+		servers = append(servers, s)
+	}
+	return servers, nil
+}
+
+// displaySpec is a display parsed from a --display flag.
+type displaySpec struct {
+	name        string
+	device      string
+	pins        display.Pins
+	idleTimeout time.Duration
+}
+
+// parseDisplaySpec parses a --display flag of the form
+// name=device[,reset=PIN,dc=PIN,cs=PIN,busy=PIN,idle-timeout=DURATION]. Pins
+// default to the HAT's and the idle timeout to --idle-timeout.
+func parseDisplaySpec(spec string) (displaySpec, error) {
+	fields := strings.Split(spec, ",")
+	name, dev, ok := strings.Cut(fields[0], "=")
+	if !ok || name == "" || dev == "" {
+		return displaySpec{}, fmt.Errorf("invalid --display %q: expected name=device", spec)
+	}
+
+	d := displaySpec{name: name, device: dev, pins: display.DefaultPins, idleTimeout: serveIdle}
+	for _, field := range fields[1:] {
+		key, value, _ := strings.Cut(field, "=")
+		if key == "idle-timeout" {
+			timeout, err := time.ParseDuration(value)
+			if err != nil {
+				return displaySpec{}, fmt.Errorf("invalid --display %q: %w", spec, err)
+			}
+			d.idleTimeout = timeout
+			continue
+		}
+
+		pin, err := strconv.ParseUint(value, 10, 8)
+		if err != nil {
+			return displaySpec{}, fmt.Errorf("invalid --display %q: bad pin %s", spec, field)
+		}
+		switch key {
+		case "reset":
+			d.pins.Reset = uint8(pin)
+		case "dc":
+			d.pins.DC = uint8(pin)
+		case "cs":
+			d.pins.CS = uint8(pin)
+		case "busy":
+			d.pins.Busy = uint8(pin)
+		default:
+			return displaySpec{}, fmt.Errorf("invalid --display %q: unknown option %s", spec, key)
+		}
+	}
+	return d, nil
+}
+
+// describeDisplays lists the served displays for the startup log.
+func describeDisplays(servers []*server.EPDServer) string {
+	if len(servers) == 1 && servers[0].Name() == "" {
+		return "device: " + device
+	}
+	names := make([]string, len(servers))
+	for i, s := range servers {
+		names[i] = s.Name()
+	}
+	return "displays: " + strings.Join(names, ", ")
+}
+
+// advertise announces the daemon over mDNS, with its panel and version, until
+// ctx is cancelled.
+func advertise(ctx context.Context, epdServer *server.EPDServer) error {
+	svc, err := discovery.LocalService(serveMDNSName, servePort)
+	if err != nil {
+		return err
+	}
+
+	status, err := epdServer.GetStatus(ctx, &pb.GetStatusRequest{})
+	if err != nil {
+		return err
+	}
+	svc.Model = status.Device
+	svc.Width, svc.Height = int(status.Width), int(status.Height)
+	svc.Version = rootCmd.Version
+
+	conn, err := discovery.Multicast()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("EPD advertising %s as %q over mDNS", discovery.ServiceType, svc.Instance)
+	return discovery.Advertise(ctx, conn, svc)
+}
@@ -1,17 +1,56 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"image/png"
 	"log"
 	"os"
 	"strings"
 
+	"github.com/justmiles/epd/lib/display"
+	"github.com/justmiles/epd/lib/fonts"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	textFont       string
+	textFontWeight string
+	textFontStyle  string
+	textFontSize   float64
 )
 
 func init() {
 	log.SetFlags(0)
 	rootCmd.AddCommand(displayTextCmd)
+	addTextFlags(displayTextCmd.PersistentFlags())
+}
+
+// addTextFlags adds the flags setting the font text is displayed in.
+func addTextFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&textFont, "font", envDefault("EPD_FONT", ""), "font to display text in: "+strings.Join(fonts.Families(), ", ")+", or the path of a .ttf or .otf file; defaults to go (env: EPD_FONT)")
+	flags.StringVar(&textFontWeight, "font-weight", envDefault("EPD_FONT_WEIGHT", ""), "weight of a built-in --font: regular, medium or bold (env: EPD_FONT_WEIGHT)")
+	flags.StringVar(&textFontStyle, "font-style", envDefault("EPD_FONT_STYLE", ""), "style of a built-in --font: normal or italic (env: EPD_FONT_STYLE)")
+	flags.Float64Var(&textFontSize, "font-size", envDefaultFloat("EPD_FONT_SIZE", 0), "font size in points, 0 to fit the text to the display (env: EPD_FONT_SIZE)")
+}
+
+// textOptions returns the font set by the text flags.
+func textOptions() (display.TextOptions, error) {
+	opts := display.TextOptions{Size: textFontSize}
+	if textFontSize < 0 {
+		return opts, fmt.Errorf("--font-size must not be negative")
+	}
+	if textFont == "" && textFontWeight == "" && textFontStyle == "" {
+		return opts, nil
+	}
+	if !fonts.IsBuiltin(textFont) && (textFontWeight != "" || textFontStyle != "") {
+		return opts, fmt.Errorf("--font-weight and --font-style only apply to the built-in fonts, %s", strings.Join(fonts.Families(), ", "))
+	}
+
+	var err error
+	opts.Font, err = fonts.Load(textFont, textFontWeight, textFontStyle)
+	return opts, err
 }
 
 var displayTextCmd = &cobra.Command{
@@ -23,14 +62,25 @@ var displayTextCmd = &cobra.Command{
 			errorOut("Please pass text to display")
 		}
 
-		svc, err := newDisplayService(device, initialize)
+		opts, err := textOptions()
+		if err != nil {
+			errorOut(err.Error())
+		}
+
+		svc, err := newDisplayService(device, initialize, display.WithTextOptions(opts))
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
 		defer svc.Close()
 
-		if err := svc.DisplayText(strings.Join(args, " ")); err != nil {
+		text := strings.Join(args, " ")
+		if remote, ok := svc.(*display.RemoteDisplay); ok && opts != (display.TextOptions{}) {
+			err = displayTextAsImage(remote, text, opts)
+		} else {
+			err = svc.DisplayText(text)
+		}
+		if err != nil {
 			errorOut(err.Error())
 		}
 
@@ -39,3 +89,28 @@ var displayTextCmd = &cobra.Command{
 		}
 	},
 }
+
+// displayTextAsImage draws text in the font of opts here and sends it to a
+// remote display as an image, as the daemon draws text in its own font.
+func displayTextAsImage(remote *display.RemoteDisplay, text string, opts display.TextOptions) error {
+	displays, err := remote.ListDisplays()
+	if err != nil {
+		return err
+	}
+	_, name := display.SplitDisplay(device)
+	for _, d := range displays {
+		if name != "" && d.GetDisplay() != name {
+			continue
+		}
+		img, err := display.RenderText(text, int(d.GetWidth()), int(d.GetHeight()), opts)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return err
+		}
+		return remote.DisplayImage(buf.Bytes())
+	}
+	return fmt.Errorf("daemon has no display %q", name)
+}
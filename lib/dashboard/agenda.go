@@ -0,0 +1,256 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fogleman/gg"
+	"github.com/justmiles/epd/lib/fonts"
+	"github.com/justmiles/epd/lib/ical"
+	"github.com/justmiles/epd/lib/locale"
+)
+
+// calendarTimeout bounds how long fetching a calendar URL may take.
+const calendarTimeout = 30 * time.Second
+
+func init() {
+	Register("agenda", func() Widget { return &agendaWidget{Days: 2, FontSize: 20} })
+}
+
+// agendaWidget lists the events of the coming days, by default today and
+// tomorrow, from iCalendar files in black on white. Events that don't fit are
+// summed up as "+N more". Calendars that can't be read are left out and
+// marked as unavailable, as long as one can be.
+type agendaWidget struct {
+	// Calendars are the paths or URLs of the .ics files to show, e.g. a
+	// Google Calendar's secret address in iCal format.
+	Calendars []string `yaml:"calendars"`
+
+	// Days is how many days to list, starting today.
+	Days int `yaml:"days"`
+
+	FontSize   float64 `yaml:"font-size"`
+	fontConfig `yaml:",inline"`
+
+	days        []agendaDay
+	unavailable bool
+	locale      *locale.Locale
+}
+
+// agendaDay is a day of the agenda and its events.
+type agendaDay struct {
+	label  string
+	start  time.Time
+	events []ical.Event
+}
+
+func (w *agendaWidget) Configure(config Config) error {
+	if err := config.Decode(w); err != nil {
+		return err
+	}
+	switch {
+	case len(w.Calendars) == 0:
+		return errors.New("calendars must list at least one .ics file or URL")
+	case w.Days < 1:
+		return errors.New("days must be at least 1")
+	case w.FontSize <= 0:
+		return errors.New("font-size must be positive")
+	}
+	return w.load()
+}
+
+func (w *agendaWidget) Fetch(ctx context.Context, env *Env) error {
+	cal := &ical.Calendar{}
+	failed := addCalendars(ctx, cal, w.Calendars)
+	if len(failed) == len(w.Calendars) {
+		return errors.Join(failed...)
+	}
+	w.unavailable = len(failed) > 0
+
+	w.locale = env.Locale
+	today := startOfDay(env.Now)
+	for i := range w.Days {
+		start := today.AddDate(0, 0, i)
+		label := locale.Heading(env.Locale.LongDate(start))
+		switch i {
+		case 0:
+			label = env.Locale.Translate("Today")
+		case 1:
+			label = env.Locale.Translate("Tomorrow")
+		}
+
+		// Leave out what has already ended today
+		from := start
+		if i == 0 {
+			from = env.Now
+		}
+		w.days = append(w.days, agendaDay{
+			label:  label,
+			start:  start,
+			events: cal.Events(from, start.AddDate(0, 0, 1), env.Location),
+		})
+	}
+	return nil
+}
+
+// addCalendars reads the .ics files at srcs into cal, leaving out and logging
+// those that can't be read, and returns their errors.
+func addCalendars(ctx context.Context, cal *ical.Calendar, srcs []string) []error {
+	var failed []error
+	for _, src := range srcs {
+		if err := addCalendar(ctx, cal, src); err != nil {
+			log.Printf("Leaving out calendar: %v", err)
+			failed = append(failed, err)
+		}
+	}
+	return failed
+}
+
+// addCalendar reads the .ics file at src, a path or an http(s) or webcal URL,
+// into cal.
+func addCalendar(ctx context.Context, cal *ical.Calendar, src string) error {
+	if after, ok := strings.CutPrefix(src, "webcal://"); ok {
+		src = "https://" + after
+	}
+	if !isValidURL(src) {
+		f, err := os.Open(src)
+		if err != nil {
+			return fmt.Errorf("could not read calendar: %w", err)
+		}
+		defer f.Close()
+		if err := cal.Add(f); err != nil {
+			return fmt.Errorf("invalid calendar %s: %w", src, err)
+		}
+		return nil
+	}
+
+	// Name the calendar by its host, as calendar URLs often hold secrets
+	u, _ := url.Parse(src)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return fmt.Errorf("could not get calendar from %s: %w", u.Host, err)
+	}
+	client := &http.Client{Timeout: calendarTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("could not get calendar from %s: %w", u.Host, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not get calendar from %s: %s", u.Host, resp.Status)
+	}
+	if err := cal.Add(io.LimitReader(resp.Body, 16<<20)); err != nil {
+		return fmt.Errorf("invalid calendar from %s: %w", u.Host, err)
+	}
+	return nil
+}
+
+// Render draws the agenda x pixels wide and y pixels tall
+func (w *agendaWidget) Render(x, y int) (image.Image, error) {
+	var (
+		xWidth, xHeight = float64(x), float64(y)
+		pad             = w.FontSize / 2
+		lineHeight      = w.FontSize * 1.4
+	)
+
+	dc := gg.NewContext(x, y)
+	dc.SetRGB(1, 1, 1)
+	dc.Clear()
+	dc.SetRGB(0, 0, 0)
+
+	// Note calendars left out below the events
+	if w.unavailable {
+		xHeight -= drawUnavailableMarker(dc, w.regular, w.locale, xWidth-pad, xHeight)
+	}
+
+	// Times go in a column wide enough for the widest of them
+	setFont(dc, w.regular, w.FontSize)
+	var timeWidth float64
+	for _, s := range []string{
+		w.locale.Translate("All day"),
+		w.locale.Time(time.Date(2000, 1, 1, 10, 58, 0, 0, time.UTC)),
+		"–" + w.locale.Time(time.Date(2000, 1, 1, 22, 58, 0, 0, time.UTC)),
+	} {
+		width, _ := dc.MeasureString(s)
+		timeWidth = max(timeWidth, width)
+	}
+	timeWidth += pad
+
+	top := pad
+	for _, day := range w.days {
+		if top+lineHeight > xHeight {
+			break
+		}
+
+		// Heading, underlined
+		setFont(dc, w.bold, w.FontSize*1.1)
+		dc.DrawStringAnchored(truncateText(dc, day.label, xWidth-2*pad), pad, top+lineHeight/2, 0, 0.35)
+		top += lineHeight
+		dc.DrawRectangle(pad, top-2, xWidth-2*pad, 2)
+		dc.Fill()
+		top += pad / 2
+
+		setFont(dc, w.regular, w.FontSize)
+		rows := int((xHeight - top) / lineHeight)
+		events := day.events
+		if len(events) == 0 && rows > 0 {
+			dc.DrawStringAnchored(w.locale.Translate("No events"), pad, top+lineHeight/2, 0, 0.35)
+			top += lineHeight
+		}
+		for i, e := range events {
+			if i == rows-1 && len(events) > rows {
+				more := strings.ReplaceAll(w.locale.Translate("+{n} more"), "{n}", strconv.Itoa(len(events)-i))
+				dc.DrawStringAnchored(more, pad, top+lineHeight/2, 0, 0.35)
+				top += lineHeight
+				break
+			}
+			if i >= rows {
+				break
+			}
+			dc.DrawStringAnchored(eventTime(e, day.start, w.locale), pad, top+lineHeight/2, 0, 0.35)
+			summary := truncateText(dc, e.Summary, xWidth-2*pad-timeWidth)
+			dc.DrawStringAnchored(summary, pad+timeWidth, top+lineHeight/2, 0, 0.35)
+			top += lineHeight
+		}
+		top += pad
+	}
+
+	return dc.Image(), nil
+}
+
+// drawUnavailableMarker notes that calendars were left out, in black in f
+// along the bottom of a context y pixels tall, ending at x. It returns the
+// height of the band it takes.
+func drawUnavailableMarker(dc *gg.Context, f *fonts.Font, l *locale.Locale, x, y float64) float64 {
+	height := staleMarkerHeight(y)
+	setFont(dc, f, height*0.8)
+	dc.DrawStringAnchored(l.Translate("calendar unavailable"), x, y-height/2, 1, 0.35)
+	return height
+}
+
+// eventTime describes when e happens on the day starting at dayStart: its start
+// time, "–" and its end time if it started on an earlier day, or "All day".
+func eventTime(e ical.Event, dayStart time.Time, l *locale.Locale) string {
+	dayEnd := dayStart.AddDate(0, 0, 1)
+	switch {
+	case e.AllDay, !e.Start.After(dayStart) && !e.End.Before(dayEnd):
+		return l.Translate("All day")
+	case e.Start.Before(dayStart):
+		return "–" + l.Time(e.End)
+	}
+	return l.Time(e.Start)
+}
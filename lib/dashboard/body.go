@@ -0,0 +1,66 @@
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+
+	"github.com/justmiles/epd/lib/fonts"
+	mdpng "github.com/justmiles/epd/lib/md-png"
+)
+
+func init() {
+	Register("body", func() Widget { return &bodyWidget{FontSize: 20} })
+}
+
+// bodyWidget shows GitHub Flavored Markdown, by default the dashboard's body
+// text.
+type bodyWidget struct {
+	Markdown string  `yaml:"markdown"`
+	FontSize float64 `yaml:"font-size"`
+
+	// Font is a built-in family or the path of a font file for the text,
+	// which markdown makes bold or italic. By default the system's fonts.
+	Font string `yaml:"font"`
+}
+
+func (w *bodyWidget) Configure(config Config) error {
+	if err := config.Decode(w); err != nil {
+		return err
+	}
+	if w.Font != "" {
+		if _, err := fonts.Load(w.Font, "", ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *bodyWidget) Fetch(ctx context.Context, env *Env) error {
+	if w.Markdown == "" {
+		w.Markdown = env.BodyText
+	}
+	return nil
+}
+
+// Render draws the markdown x pixels wide and y pixels tall
+func (w *bodyWidget) Render(x, y int) (image.Image, error) {
+	var bodyBuf bytes.Buffer
+	err := mdpng.Convert([]byte(w.Markdown), &bodyBuf,
+		mdpng.WithWidth(x),
+		mdpng.WithHeight(y),
+		mdpng.WithFontSize(w.FontSize),
+		mdpng.WithFont(w.Font),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not render body text: %s", err)
+	}
+
+	bodyImg, err := png.Decode(&bodyBuf)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode body image: %s", err)
+	}
+	return bodyImg, nil
+}
@@ -0,0 +1,71 @@
+package dashboard
+
+import (
+	"context"
+	"image"
+
+	"github.com/fogleman/gg"
+	"github.com/justmiles/epd/lib/locale"
+)
+
+func init() {
+	Register("calendar", func() Widget { return &calendarWidget{} })
+}
+
+// calendarWidget shows the day of the week, the day of the month and the
+// month and year in white on black.
+type calendarWidget struct {
+	fontConfig `yaml:",inline"`
+
+	env *Env
+}
+
+func (w *calendarWidget) Configure(config Config) error {
+	if err := config.Decode(w); err != nil {
+		return err
+	}
+	return w.load()
+}
+
+func (w *calendarWidget) Fetch(ctx context.Context, env *Env) error {
+	w.env = env
+	return nil
+}
+
+// Render draws the calendar x pixels wide and y pixels tall
+func (w *calendarWidget) Render(x, y int) (image.Image, error) {
+	var (
+		xWidth, xHeight          = float64(x), float64(y)
+		fontSize, widgetLocation float64
+		now                      = w.env.Now
+	)
+
+	// Draw background
+	dc := gg.NewContext(x, y)
+	dc.DrawRectangle(0, 0, xWidth, xHeight)
+	dc.SetRGB(0, 0, 0)
+	dc.Fill()
+
+	// Set font color
+	dc.SetRGB(1, 1, 1)
+
+	// Draw day of the week
+	dow := locale.Heading(w.env.Locale.Weekday(now.Weekday()))
+	fontSize = setDynamicFont(dc, w.regular, xWidth-(xWidth*.1), xHeight*.2, dow)
+	widgetLocation = fontSize/2 + 10
+	dc.DrawStringAnchored(dow, xWidth/2, widgetLocation, 0.5, 0.5)
+
+	// Draw day of month
+	domText := now.Format("02")
+	fontSize = setDynamicFont(dc, w.regular, xWidth-(xWidth*.1), xHeight*.5, domText)
+	widgetLocation = (widgetLocation) + fontSize/2 + 10
+	dc.DrawStringAnchored(domText, xWidth/2, widgetLocation, 0.5, 0.5)
+
+	// Draw month, year
+	ymText := locale.Heading(w.env.Locale.MonthYear(now))
+	fontSize = setDynamicFont(dc, w.regular, xWidth-(xWidth*.1), xHeight*.3, ymText)
+	widgetLocation = (widgetLocation * 1.75) + fontSize/2
+	dc.DrawStringAnchored(ymText, xWidth/2, widgetLocation, 0.5, 0.5)
+
+	return dc.Image(), nil
+}
@@ -2,21 +2,19 @@ package dashboard
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"image"
 	"image/color"
-	"image/png"
 	"os"
 	"strings"
+	"time"
 
-	"github.com/briandowns/openweathermap"
-	owm "github.com/briandowns/openweathermap"
 	"github.com/disintegration/imaging"
 	"github.com/fogleman/gg"
-	"github.com/golang/freetype/truetype"
 	epd "github.com/justmiles/epd/lib/epd7in5v2"
-	mdpng "github.com/justmiles/epd/lib/md-png"
-	"golang.org/x/image/font/gofont/goregular"
+	"github.com/justmiles/epd/lib/fonts"
+	"github.com/justmiles/epd/lib/locale"
 )
 
 const (
@@ -32,15 +30,76 @@ type Dashboard struct {
 
 	// WeatherAPI
 	weatherAPIOptions *WeatherAPIOptions
-	weatherAPIService *openweathermap.CurrentWeatherData
+	weather           WeatherProvider
 
 	// Calendar Location
 	location string
+
+	// locale for dates, numbers and labels, "en" unless set
+	locale string
+
+	// layout arranges the widgets, DefaultLayout unless set
+	layout *Layout
+
+	// templates lets ExecuteTemplate execute header and body text as
+	// templates, which may read the environment and files if templateHost is
+	// set and run shell commands if templateShell is set
+	templates     bool
+	templateHost  bool
+	templateShell bool
 }
 
 // Options provides options for a new Dashboard
 type Options func(d *Dashboard)
 
+// WithLocation sets the time zone, e.g. "America/Chicago", used for the date
+func WithLocation(location string) Options {
+	return func(d *Dashboard) {
+		d.location = location
+	}
+}
+
+// WithLocale sets the locale, e.g. "de" or "en-GB", used for the names of
+// days and months, the order of dates, numbers and labels
+func WithLocale(tag string) Options {
+	return func(d *Dashboard) {
+		d.locale = tag
+	}
+}
+
+// WithLayout arranges the dashboard's widgets with l instead of the default
+// layout
+func WithLayout(l *Layout) Options {
+	return func(d *Dashboard) {
+		d.layout = l
+	}
+}
+
+// WithTemplates lets ExecuteTemplate execute header and body text as Go
+// text/templates, e.g. "Sprint ends in {{ daysUntil "2026-11-01" }} days",
+// with the functions listed in template.go
+func WithTemplates(enabled bool) Options {
+	return func(d *Dashboard) {
+		d.templates = enabled
+	}
+}
+
+// WithTemplateHost lets templates read environment variables and files with
+// the env, file and json functions. Only allow it for text you trust.
+func WithTemplateHost(allowed bool) Options {
+	return func(d *Dashboard) {
+		d.templateHost = allowed
+	}
+}
+
+// WithTemplateShell lets templates run shell commands with the shell function.
+// Only allow it for text you trust.
+func WithTemplateShell(allowed bool) Options {
+	return func(d *Dashboard) {
+		d.templateShell = allowed
+	}
+}
+
 // NewDashboard creates a custom dashboard
 func NewDashboard(opts ...Options) (*Dashboard, error) {
 	var err error
@@ -63,9 +122,9 @@ func NewDashboard(opts ...Options) (*Dashboard, error) {
 		}
 	}
 
-	// init weatherAPI
-	if d.weatherAPIOptions != nil {
-		d.weatherAPIService, err = owm.NewCurrent(d.weatherAPIOptions.WeatherTempUnit, d.weatherAPIOptions.WeatherLanguage, d.weatherAPIOptions.WeatherAPIKey)
+	// init weatherAPI, unless another provider is set
+	if d.weatherAPIOptions != nil && d.weather == nil {
+		d.weather, err = NewOpenWeatherMap(d.weatherAPIOptions)
 		if err != nil {
 			return nil, fmt.Errorf("could not initialize weather api: %s", err)
 		}
@@ -103,85 +162,112 @@ func NewDashboard(opts ...Options) (*Dashboard, error) {
 // ││                            ││                                                                      ││
 // │└────────────────────────────┘└──────────────────────────────────────────────────────────────────────┘│
 // └──────────────────────────────────────────────────────────────────────────────────────────────────────┘
-// Generate a dashboard
+// Generate a dashboard, drawn as above by DefaultLayout unless WithLayout
+// sets another
 func (d *Dashboard) Generate(outputFile string, headerText string, bodyText string) error {
-	var (
-		xWidth, xHeight = float64(epdWidth), float64(epdHeight)
-		oneSmeckle      = xWidth / 500
-		err             error
-	)
-	// 800 x 480
-	dc := gg.NewContext(epdWidth, epdHeight)
+	img, err := d.Render(headerText, bodyText)
+	if err != nil {
+		return err
+	}
 
-	// set white background
-	dc.DrawRectangle(0, 0, xWidth, xHeight)
-	dc.SetRGB(1, 1, 1)
-	dc.Fill()
+	// Save the image
+	return gg.SavePNG(outputFile, img)
+}
 
-	// Draw the calendar widget
-	calendarWidgetWidth := int(xWidth * .32)
-	calendarWidgetHeight := int(xHeight * .45)
-	cal, err := buildCalendarWidget(calendarWidgetWidth, calendarWidgetHeight, d.location)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: could not build calendar widget: %s\n", err)
+// Render draws the dashboard and returns it as an image
+func (d *Dashboard) Render(headerText string, bodyText string) (image.Image, error) {
+	return d.RenderContext(context.Background(), headerText, bodyText)
+}
+
+// RenderContext draws the dashboard and returns it as an image, giving up on
+// fetching widget data, such as the weather, when ctx is done. The header and
+// body text are drawn as is; run trusted text through ExecuteTemplate first.
+func (d *Dashboard) RenderContext(ctx context.Context, headerText string, bodyText string) (image.Image, error) {
+	layout := d.layout
+	if layout == nil {
+		layout = DefaultLayout()
 	}
-	if cal != nil {
-		dc.DrawImage(cal, 0, 0)
+
+	env, err := d.newEnv(headerText, bodyText)
+	if err != nil {
+		return nil, err
 	}
 
-	// Draw the weather widget
-	weatherWidgetWidth := int(xWidth * .32)
-	weatherWidgetHeight := int(xHeight) - calendarWidgetHeight
-	if d.weatherAPIOptions != nil {
-		weatherImg, err := d.buildWeatherWidget(weatherWidgetWidth, weatherWidgetHeight)
+	dc := gg.NewContext(layout.Width, layout.Height)
+
+	// set white background
+	dc.SetRGB(1, 1, 1)
+	dc.Clear()
+
+	err = layout.Root.walk(image.Rect(0, 0, layout.Width, layout.Height), func(r *Region, rect image.Rectangle) error {
+		w, err := newWidget(r)
+		if err != nil {
+			return err
+		}
+		if err := w.Fetch(ctx, env); err != nil {
+			return fmt.Errorf("could not build %s widget: %s", r.Widget, err)
+		}
+		img, err := w.Render(rect.Dx(), rect.Dy())
 		if err != nil {
-			return fmt.Errorf("could not build weather widget: %s", err)
+			return fmt.Errorf("could not build %s widget: %s", r.Widget, err)
 		}
-		if weatherImg != nil {
-			dc.DrawImage(weatherImg, 0, calendarWidgetHeight)
+		if img != nil {
+			dc.DrawImage(img, rect.Min.X, rect.Min.Y)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Draw the dashboard header background
-	taskHeaderStart := float64(calendarWidgetWidth) + oneSmeckle
-	taskHeaderWidth := xWidth - taskHeaderStart
-	taskHeaderHeight := xWidth * .08
-	dc.DrawRectangle(taskHeaderStart, 0, taskHeaderWidth, taskHeaderHeight)
-	dc.SetRGB(0, 0, 0)
-	dc.Fill()
-
-	// Draw the dashboard header text
-	setFont(dc, taskHeaderHeight/2)
-	dc.SetRGB(1, 1, 1)
-	dc.DrawStringAnchored(headerText, taskHeaderStart+taskHeaderWidth/2, taskHeaderHeight/2, 0.5, 0.25)
-
-	dc.SetRGB(0, 0, 0)
+	return dc.Image(), nil
+}
 
-	bodyX := taskHeaderStart + oneSmeckle
-	bodyY := taskHeaderHeight + oneSmeckle
-	bodyWidth := taskHeaderWidth
-	bodyHeight := xHeight - taskHeaderHeight
-
-	// Convert body text to an image using mdpng
-	var bodyBuf bytes.Buffer
-	err = mdpng.Convert([]byte(bodyText), &bodyBuf,
-		mdpng.WithWidth(int(bodyWidth)),
-		mdpng.WithHeight(int(bodyHeight)),
-		mdpng.WithFontSize(20),
-	)
+// newEnv returns what widgets and templates see of a render of the dashboard
+// with the given header and body text
+func (d *Dashboard) newEnv(headerText string, bodyText string) (*Env, error) {
+	loc, err := d.loadLocation()
 	if err != nil {
-		return fmt.Errorf("could not render body text: %s", err)
+		return nil, err
+	}
+	l, err := d.loadLocale()
+	if err != nil {
+		return nil, err
 	}
+	return &Env{
+		HeaderText:      headerText,
+		BodyText:        bodyText,
+		Now:             time.Now().In(loc),
+		Location:        loc,
+		Locale:          l,
+		weatherProvider: d.weather,
+	}, nil
+}
 
-	bodyImg, err := png.Decode(&bodyBuf)
+// loadLocation returns the dashboard's time zone, the local one unless set
+// with WithLocation
+func (d *Dashboard) loadLocation() (*time.Location, error) {
+	if d.location == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(d.location)
 	if err != nil {
-		return fmt.Errorf("could not decode body image: %s", err)
+		return nil, fmt.Errorf("Invalid location: %s", err)
 	}
-	dc.DrawImage(bodyImg, int(bodyX), int(bodyY))
+	return loc, nil
+}
 
-	// Save the image
-	dc.SavePNG(outputFile)
-	return nil
+// loadLocale returns the dashboard's locale, English unless set with
+// WithLocale
+func (d *Dashboard) loadLocale() (*locale.Locale, error) {
+	if d.locale == "" {
+		return locale.Lookup("en")
+	}
+	l, err := locale.Lookup(d.locale)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid locale: %s", err)
+	}
+	return l, nil
 }
 
 // DisplayImage accepts a path to image file and displays it on the screen
@@ -231,7 +317,7 @@ func (d *Dashboard) DisplayText(text string) error {
 
 	maxWidth, maxHeight := float64(d.EPDService.Width), float64(d.EPDService.Height)
 
-	fontSize, measuredHeight, err := fitTextToArea(dc, text, maxWidth, maxHeight)
+	fontSize, measuredHeight, err := fitTextToArea(dc, defaultFont(), text, maxWidth, maxHeight)
 	if err != nil {
 		return fmt.Errorf("unable to fit text on screen: \n %s", text)
 	}
@@ -246,8 +332,8 @@ func (d *Dashboard) DisplayText(text string) error {
 
 // fitTextToArea dynamically adjusts the font size on the given context until the text
 // fits within maxWidth x maxHeight. It returns the final fontSize, measuredHeight, and
-// any error if the text cannot fit. The font face is set on dc upon return.
-func fitTextToArea(dc *gg.Context, text string, maxWidth, maxHeight float64) (float64, float64, error) {
+// any error if the text cannot fit. The font face, in f, is set on dc upon return.
+func fitTextToArea(dc *gg.Context, f *fonts.Font, text string, maxWidth, maxHeight float64) (float64, float64, error) {
 	var (
 		fontSize          float64 = 300  // initial font size
 		fontSizeReduction float64 = 0.95 // reduce the font size by this much until message fits in the display
@@ -257,13 +343,8 @@ func fitTextToArea(dc *gg.Context, text string, maxWidth, maxHeight float64) (fl
 		measuredHeight    float64
 	)
 
-	font, err := truetype.Parse(goregular.TTF)
-	if err != nil {
-		return 0, 0, err
-	}
 	for {
-		face := truetype.NewFace(font, &truetype.Options{Size: fontSize})
-		dc.SetFontFace(face)
+		setFont(dc, f, fontSize)
 
 		wrappedLines := dc.WordWrap(text, maxWidth)
 		wrappedText := strings.Join(wrappedLines, "\n")
@@ -289,7 +370,7 @@ func fitTextToArea(dc *gg.Context, text string, maxWidth, maxHeight float64) (fl
 // all pre-formatted lines fit within maxWidth x maxHeight. Unlike fitTextToArea, it does
 // not re-wrap text — each line is measured individually, preserving original line breaks
 // and indentation.
-func fitPreformattedTextToArea(dc *gg.Context, lines []string, maxWidth, maxHeight float64) (float64, float64, error) {
+func fitPreformattedTextToArea(dc *gg.Context, f *fonts.Font, lines []string, maxWidth, maxHeight float64) (float64, float64, error) {
 	var (
 		fontSize          float64 = 300
 		fontSizeReduction float64 = 0.95
@@ -297,14 +378,8 @@ func fitPreformattedTextToArea(dc *gg.Context, lines []string, maxWidth, maxHeig
 		lineSpacing       float64 = 1.4
 	)
 
-	font, err := truetype.Parse(goregular.TTF)
-	if err != nil {
-		return 0, 0, err
-	}
-
 	for {
-		face := truetype.NewFace(font, &truetype.Options{Size: fontSize})
-		dc.SetFontFace(face)
+		setFont(dc, f, fontSize)
 
 		totalHeight := float64(len(lines)) * fontSize * lineSpacing
 		maxLineWidth := 0.0
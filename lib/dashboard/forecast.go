@@ -0,0 +1,271 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/fogleman/gg"
+	"github.com/justmiles/epd/lib/fonts"
+	"github.com/justmiles/epd/lib/locale"
+)
+
+func init() {
+	Register("forecast", func() Widget { return &forecastWidget{Mode: "daily"} })
+}
+
+// forecastMinCell is the smallest width or height, in pixels, of a day or hour
+// in the forecast widget.
+const forecastMinCell = 64
+
+// forecastWidget shows the forecast in white on black, one cell per day or
+// hour with an icon, the temperature and the chance of precipitation. Cells
+// sit side by side, or stacked when the region is taller than it is wide.
+type forecastWidget struct {
+	// Mode is "daily", for each day's high and low, or "hourly", for the
+	// temperature every few hours.
+	Mode string `yaml:"mode"`
+
+	// Count is the number of days or hours to show. By default, up to five
+	// days, or as many hours as fit.
+	Count int `yaml:"count"`
+
+	fontConfig `yaml:",inline"`
+
+	periods     []forecastPeriod
+	weather     *Weather
+	unavailable bool
+	loc         *time.Location
+	locale      *locale.Locale
+}
+
+// forecastPeriod is a cell of the forecast widget.
+type forecastPeriod struct {
+	label  string
+	icon   string
+	temp   string
+	chance string
+}
+
+func (w *forecastWidget) Configure(config Config) error {
+	if err := config.Decode(w); err != nil {
+		return err
+	}
+	if w.Mode != "daily" && w.Mode != "hourly" {
+		return fmt.Errorf("mode must be daily or hourly, got %q", w.Mode)
+	}
+	if w.Count < 0 {
+		return fmt.Errorf("count must not be negative")
+	}
+	return w.load()
+}
+
+func (w *forecastWidget) Fetch(ctx context.Context, env *Env) error {
+	weather, err := env.Weather(ctx)
+	if errors.Is(err, ErrWeatherUnavailable) {
+		w.unavailable, w.locale = true, env.Locale
+		return nil
+	}
+	if err != nil || weather == nil {
+		return err
+	}
+
+	w.weather, w.loc, w.locale = weather, env.Location, env.Locale
+	if w.Mode == "hourly" {
+		w.periods = hourlyForecast(weather.Forecast, env.Now, env.Locale)
+	} else {
+		w.periods = dailyForecast(weather.Forecast, env.Now, env.Locale)
+	}
+	return nil
+}
+
+// hourlyForecast returns the forecasts from the current hour on.
+func hourlyForecast(forecast []Forecast, now time.Time, l *locale.Locale) []forecastPeriod {
+	var periods []forecastPeriod
+	for _, f := range forecast {
+		if f.Time.Before(now.Truncate(time.Hour)) {
+			continue
+		}
+		periods = append(periods, forecastPeriod{
+			label:  l.Hour(f.Time.In(now.Location())),
+			icon:   f.Icon,
+			temp:   formatTemp(l, f.Temp),
+			chance: l.Percent(f.PrecipitationChance * 100),
+		})
+	}
+	return periods
+}
+
+// dailyForecast sums up the forecasts from today on by day, in now's time
+// zone, with each day's high, low and highest chance of precipitation, and
+// the daytime icon closest to noon.
+func dailyForecast(forecast []Forecast, now time.Time, l *locale.Locale) []forecastPeriod {
+	type day struct {
+		date      time.Time
+		high, low float64
+		chance    float64
+		icon      string
+		fromNoon  time.Duration
+	}
+
+	today := startOfDay(now)
+	var days []*day
+	for _, f := range forecast {
+		date := startOfDay(f.Time.In(now.Location()))
+		if date.Before(today) {
+			continue
+		}
+		if len(days) == 0 || !days[len(days)-1].date.Equal(date) {
+			days = append(days, &day{date: date, high: f.TempMax, low: f.TempMin, fromNoon: -1})
+		}
+		d := days[len(days)-1]
+		d.high = math.Max(d.high, f.TempMax)
+		d.low = math.Min(d.low, f.TempMin)
+		d.chance = math.Max(d.chance, f.PrecipitationChance)
+
+		fromNoon := f.Time.Sub(date.Add(12 * time.Hour)).Abs()
+		if d.fromNoon < 0 || fromNoon < d.fromNoon {
+			d.icon, d.fromNoon = strings.Replace(f.Icon, "n", "d", 1), fromNoon
+		}
+	}
+
+	periods := make([]forecastPeriod, len(days))
+	for i, d := range days {
+		periods[i] = forecastPeriod{
+			label:  l.ShortWeekday(d.date.Weekday()),
+			icon:   d.icon,
+			temp:   formatTemp(l, d.high) + " " + formatTemp(l, d.low),
+			chance: l.Percent(d.chance * 100),
+		}
+	}
+	return periods
+}
+
+// startOfDay returns midnight at the start of t's day, in t's time zone.
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// formatTemp formats a temperature in whole degrees, e.g. "41°".
+func formatTemp(l *locale.Locale, t float64) string {
+	return l.Number(math.Floor(t), 0) + "°"
+}
+
+// Render draws the forecast x pixels wide and y pixels tall
+func (w *forecastWidget) Render(x, y int) (image.Image, error) {
+	if (len(w.periods) == 0 && !w.unavailable) || x < forecastMinCell/2 || y < forecastMinCell/2 {
+		return nil, nil
+	}
+	if w.unavailable {
+		dc := gg.NewContext(x, y)
+		dc.SetRGB(0, 0, 0)
+		dc.Clear()
+		drawWeatherMarker(dc, w.regular, w.locale.Translate("weather unavailable"), float64(x), float64(y))
+		return dc.Image(), nil
+	}
+
+	// Lay the cells out along the longer side, as many as fit
+	stacked := y > x
+	length := x
+	if stacked {
+		length = y
+	}
+	count := w.Count
+	if count == 0 && w.Mode == "daily" {
+		count = 5
+	}
+	if fit := max(length/forecastMinCell, 1); count == 0 || count > fit {
+		count = fit
+	}
+	periods := w.periods[:min(count, len(w.periods))]
+
+	dc := gg.NewContext(x, y)
+	dc.SetRGB(0, 0, 0)
+	dc.Clear()
+	dc.SetRGB(1, 1, 1)
+
+	// Leave room at the bottom for the stale marker
+	height := float64(y)
+	if !w.weather.StaleSince.IsZero() {
+		height -= staleMarkerHeight(height)
+	}
+
+	if stacked {
+		drawForecastRows(dc, w.regular, periods, float64(x), height/float64(len(periods)))
+	} else {
+		drawForecastColumns(dc, w.regular, periods, float64(x)/float64(len(periods)), height)
+	}
+	drawStaleMarker(dc, w.regular, w.weather, w.loc, w.locale, float64(x), float64(y))
+	return dc.Image(), nil
+}
+
+// drawForecastColumns draws the periods side by side in w by h cells, each top
+// to bottom: the label, icon, temperature and chance of precipitation, in f.
+func drawForecastColumns(dc *gg.Context, f *fonts.Font, periods []forecastPeriod, w, h float64) {
+	// Keep tall cells from spreading out, centering them instead
+	top := 0.0
+	if h > w*2 {
+		top, h = (h-w*2)/2, w*2
+	}
+	text := w * 0.9
+	iconSize := math.Min(w*0.6, h*0.35)
+	labelSize := fitFontSize(dc, f, text, h*0.18, periods, func(p forecastPeriod) string { return p.label })
+	tempSize := fitFontSize(dc, f, text, h*0.16, periods, func(p forecastPeriod) string { return p.temp })
+	chanceSize := fitFontSize(dc, f, text, h*0.12, periods, func(p forecastPeriod) string { return p.chance })
+
+	for i, p := range periods {
+		cx := float64(i)*w + w/2
+
+		setFont(dc, f, labelSize)
+		dc.DrawStringAnchored(p.label, cx, top+h*0.12, 0.5, 0.5)
+
+		icon := convertSVGToImage(getIcon(p.icon), iconSize, iconSize)
+		dc.DrawImage(icon, int(cx-iconSize/2), int(top+h*0.24))
+
+		setFont(dc, f, tempSize)
+		dc.DrawStringAnchored(p.temp, cx, top+h*0.24+iconSize+h*0.12, 0.5, 0.5)
+
+		setFont(dc, f, chanceSize)
+		dc.DrawStringAnchored(p.chance, cx, top+h*0.9, 0.5, 0.5)
+	}
+}
+
+// drawForecastRows draws the periods top to bottom in w by h cells, each left
+// to right: the label, icon, temperature and chance of precipitation, in f.
+func drawForecastRows(dc *gg.Context, f *fonts.Font, periods []forecastPeriod, w, h float64) {
+	iconSize := math.Min(w*0.2, h*0.7)
+	labelSize := fitFontSize(dc, f, w*0.22, h*0.5, periods, func(p forecastPeriod) string { return p.label })
+	tempSize := fitFontSize(dc, f, w*0.32, h*0.45, periods, func(p forecastPeriod) string { return p.temp })
+	chanceSize := fitFontSize(dc, f, w*0.15, h*0.4, periods, func(p forecastPeriod) string { return p.chance })
+
+	for i, p := range periods {
+		cy := float64(i)*h + h/2
+
+		setFont(dc, f, labelSize)
+		dc.DrawStringAnchored(p.label, w*0.02, cy, 0, 0.5)
+
+		icon := convertSVGToImage(getIcon(p.icon), iconSize, iconSize)
+		dc.DrawImage(icon, int(w*0.26), int(cy-iconSize/2))
+
+		setFont(dc, f, tempSize)
+		dc.DrawStringAnchored(p.temp, w*0.5, cy, 0, 0.5)
+
+		setFont(dc, f, chanceSize)
+		dc.DrawStringAnchored(p.chance, w*0.98, cy, 1, 0.5)
+	}
+}
+
+// fitFontSize returns the largest font size at which the text of every period
+// fits in maxWidth by maxHeight, so the cells match.
+func fitFontSize(dc *gg.Context, f *fonts.Font, maxWidth, maxHeight float64, periods []forecastPeriod, text func(forecastPeriod) string) float64 {
+	size := maxHeight
+	for _, p := range periods {
+		size = math.Min(size, setDynamicFont(dc, f, maxWidth, maxHeight, text(p)))
+	}
+	return size
+}
@@ -0,0 +1,53 @@
+package dashboard
+
+import (
+	"context"
+	"image"
+
+	"github.com/fogleman/gg"
+)
+
+func init() {
+	Register("header", func() Widget { return &headerWidget{} })
+}
+
+// headerWidget shows a line of text centered in white on black, by default
+// the dashboard's header text.
+type headerWidget struct {
+	Text       string  `yaml:"text"`
+	FontSize   float64 `yaml:"font-size"`
+	fontConfig `yaml:",inline"`
+}
+
+func (w *headerWidget) Configure(config Config) error {
+	if err := config.Decode(w); err != nil {
+		return err
+	}
+	return w.load()
+}
+
+func (w *headerWidget) Fetch(ctx context.Context, env *Env) error {
+	if w.Text == "" {
+		w.Text = env.HeaderText
+	}
+	return nil
+}
+
+// Render draws the header x pixels wide and y pixels tall, with the text half
+// as tall as the header unless a font size is set
+func (w *headerWidget) Render(x, y int) (image.Image, error) {
+	fontSize := w.FontSize
+	if fontSize == 0 {
+		fontSize = float64(y) / 2
+	}
+
+	dc := gg.NewContext(x, y)
+	dc.SetRGB(0, 0, 0)
+	dc.Clear()
+
+	setFont(dc, w.regular, fontSize)
+	dc.SetRGB(1, 1, 1)
+	dc.DrawStringAnchored(w.Text, float64(x)/2, float64(y)/2, 0.5, 0.25)
+
+	return dc.Image(), nil
+}
@@ -59,9 +59,9 @@ func getIcon(s string) io.Reader {
 	case "13n":
 		s = iconSnowFlake
 	case "50d":
-		s = "fog"
+		s = iconSmog
 	case "50n":
-		s = "fog"
+		s = iconSmog
 	}
 
 	return bytes.NewReader([]byte(s))
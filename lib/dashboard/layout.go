@@ -0,0 +1,221 @@
+package dashboard
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed default-layout.yaml
+var defaultLayout []byte
+
+// Layout divides the dashboard into regions, each drawn by a widget. Layouts
+// are written in YAML or JSON; see default-layout.yaml for the built-in one.
+type Layout struct {
+	// Width and Height are the dashboard size in pixels, defaulting to the
+	// panel's 800x480.
+	Width  int `yaml:"width"`
+	Height int `yaml:"height"`
+
+	// Root is the region covering the whole dashboard.
+	Root *Region `yaml:"root"`
+}
+
+// Region is a rectangle of the dashboard, either drawn by a widget or split
+// among child regions laid out side by side ("row") or top to bottom
+// ("column"), like a flexbox.
+type Region struct {
+	// Direction lays out the children in a "row" or, by default, a "column".
+	Direction string `yaml:"direction"`
+
+	// Size is the region's length along its parent's direction, in pixels
+	// ("64") or as a percentage of the parent ("32%"). Regions without a size
+	// share the space left over in proportion to Grow, which defaults to 1.
+	Size string  `yaml:"size"`
+	Grow float64 `yaml:"grow"`
+
+	// Gap is the space between children and Padding the space inside the
+	// region's edges, around its widget or children, in pixels.
+	Gap     int `yaml:"gap"`
+	Padding int `yaml:"padding"`
+
+	Children []*Region `yaml:"children"`
+
+	// Widget names the registered widget that draws the region, configured
+	// by Config, e.g. the header's text.
+	Widget string    `yaml:"widget"`
+	Config yaml.Node `yaml:"config"`
+}
+
+// DefaultLayout returns the built-in layout: the date and weather on the left,
+// a header and the markdown body on the right.
+func DefaultLayout() *Layout {
+	l, err := ParseLayout(defaultLayout)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in layout: %s", err))
+	}
+	return l
+}
+
+// LoadLayout reads a YAML or JSON layout file.
+func LoadLayout(path string) (*Layout, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read layout: %w", err)
+	}
+	l, err := ParseLayout(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid layout %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// ParseLayout parses a YAML or JSON layout, rejecting unknown fields, widgets
+// that are not registered and invalid widget configs.
+func ParseLayout(data []byte) (*Layout, error) {
+	l := &Layout{Width: epdWidth, Height: epdHeight}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(l); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if l.Width <= 0 || l.Height <= 0 {
+		return nil, fmt.Errorf("invalid size %dx%d", l.Width, l.Height)
+	}
+	if l.Root == nil {
+		return nil, errors.New("missing root region")
+	}
+	if err := l.Root.validate("root"); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// validate checks the region and its children, naming them by their path in
+// errors.
+func (r *Region) validate(path string) error {
+	switch {
+	case r.Widget != "" && len(r.Children) > 0:
+		return fmt.Errorf("%s: a region has either a widget or children, not both", path)
+	case r.Direction != "" && r.Direction != "row" && r.Direction != "column":
+		return fmt.Errorf("%s: direction must be row or column, got %q", path, r.Direction)
+	case r.Grow < 0 || r.Gap < 0 || r.Padding < 0:
+		return fmt.Errorf("%s: grow, gap and padding must not be negative", path)
+	}
+	if _, _, err := r.size(); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if r.Widget != "" {
+		if _, err := newWidget(r); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	for i, c := range r.Children {
+		if c == nil {
+			return fmt.Errorf("%s.children[%d]: empty region", path, i)
+		}
+		if err := c.validate(fmt.Sprintf("%s.children[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// size parses Size as pixels or a percentage. It reports zero pixels for a
+// region without a size.
+func (r *Region) size() (value float64, percent bool, err error) {
+	if r.Size == "" {
+		return 0, false, nil
+	}
+	s, percent := strings.CutSuffix(strings.TrimSpace(r.Size), "%")
+	value, err = strconv.ParseFloat(strings.TrimSuffix(s, "px"), 64)
+	if err != nil || value < 0 {
+		return 0, false, fmt.Errorf("invalid size %q, expected pixels or a percentage", r.Size)
+	}
+	return value, percent, nil
+}
+
+// walk calls fn with every widget region and the rectangle it covers when the
+// region is laid out in rect.
+func (r *Region) walk(rect image.Rectangle, fn func(*Region, image.Rectangle) error) error {
+	inner := rect.Inset(r.Padding)
+	if inner.Empty() {
+		return nil
+	}
+	if r.Widget != "" {
+		return fn(r, inner)
+	}
+	if len(r.Children) == 0 {
+		return nil
+	}
+
+	row := r.Direction == "row"
+	length := inner.Dy()
+	if row {
+		length = inner.Dx()
+	}
+
+	// Fixed sizes first, then share what is left among the rest
+	sizes := make([]float64, len(r.Children))
+	free := float64(length - r.Gap*(len(r.Children)-1))
+	var grow float64
+	for i, c := range r.Children {
+		value, percent, _ := c.size()
+		switch {
+		case c.Size == "":
+			grow += c.weight()
+			continue
+		case percent:
+			sizes[i] = float64(length) * value / 100
+		default:
+			sizes[i] = value
+		}
+		free -= sizes[i]
+	}
+	for i, c := range r.Children {
+		if c.Size == "" && grow > 0 && free > 0 {
+			sizes[i] = free * c.weight() / grow
+		}
+	}
+
+	// Round the running position rather than each size, so rounding never
+	// leaves a gap at the end
+	var pos float64
+	start := inner.Min
+	for i, c := range r.Children {
+		from, to := int(pos+0.5), int(pos+sizes[i]+0.5)
+		pos += sizes[i] + float64(r.Gap)
+
+		child := image.Rect(start.X, start.Y+from, inner.Max.X, start.Y+to)
+		if row {
+			child = image.Rect(start.X+from, start.Y, start.X+to, inner.Max.Y)
+		}
+		child = child.Intersect(inner)
+		if child.Empty() {
+			continue
+		}
+		if err := c.walk(child, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// weight is the region's share of the space left over by sized regions.
+func (r *Region) weight() float64 {
+	if r.Grow == 0 {
+		return 1
+	}
+	return r.Grow
+}
@@ -0,0 +1,212 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fogleman/gg"
+	"github.com/justmiles/epd/lib/ical"
+	"github.com/justmiles/epd/lib/locale"
+)
+
+func init() {
+	Register("month", func() Widget { return &monthWidget{} })
+}
+
+// monthWidget shows this month as a grid of days in black on white, with
+// today highlighted and a dot under the days that have events. The grid scales
+// to its region, from a narrow side panel to the whole display. Calendars that
+// can't be read are left out and marked as unavailable.
+type monthWidget struct {
+	// WeekStart is the first day of the week, "sunday" or "monday",
+	// defaulting to the locale's.
+	WeekStart string `yaml:"week-start"`
+
+	// WeekNumbers adds a column of ISO week numbers.
+	WeekNumbers bool `yaml:"week-numbers"`
+
+	// Events are dates to mark, as YYYY-MM-DD, and Calendars the paths or
+	// URLs of .ics files whose events mark their days.
+	Events    []string `yaml:"events"`
+	Calendars []string `yaml:"calendars"`
+
+	fontConfig `yaml:",inline"`
+
+	firstDay    time.Weekday
+	now         time.Time
+	locale      *locale.Locale
+	marked      map[int]bool // days of the month with events
+	unavailable bool
+}
+
+func (w *monthWidget) Configure(config Config) error {
+	if err := config.Decode(w); err != nil {
+		return err
+	}
+	switch strings.ToLower(w.WeekStart) {
+	case "", "sunday", "monday":
+	default:
+		return fmt.Errorf(`week-start must be "sunday" or "monday", not %q`, w.WeekStart)
+	}
+	for _, d := range w.Events {
+		if _, err := time.Parse(time.DateOnly, d); err != nil {
+			return fmt.Errorf("invalid event date %q, expected YYYY-MM-DD", d)
+		}
+	}
+	return w.load()
+}
+
+func (w *monthWidget) Fetch(ctx context.Context, env *Env) error {
+	w.now, w.locale = env.Now, env.Locale
+	w.marked = map[int]bool{}
+
+	switch strings.ToLower(w.WeekStart) {
+	case "sunday":
+		w.firstDay = time.Sunday
+	case "monday":
+		w.firstDay = time.Monday
+	default:
+		w.firstDay = env.Locale.FirstDay
+	}
+
+	first := time.Date(env.Now.Year(), env.Now.Month(), 1, 0, 0, 0, 0, env.Location)
+	next := first.AddDate(0, 1, 0)
+	mark := func(d time.Time) {
+		if !d.Before(first) && d.Before(next) {
+			w.marked[d.Day()] = true
+		}
+	}
+
+	for _, s := range w.Events {
+		d, _ := time.ParseInLocation(time.DateOnly, s, env.Location)
+		mark(d)
+	}
+
+	if len(w.Calendars) == 0 {
+		return nil
+	}
+	cal := &ical.Calendar{}
+	w.unavailable = len(addCalendars(ctx, cal, w.Calendars)) > 0
+	for _, e := range cal.Events(first, next, env.Location) {
+		// Every day the event overlaps, or the day it starts if it takes no time
+		for d := startOfDay(e.Start); d.Before(next); d = d.AddDate(0, 0, 1) {
+			mark(d)
+			if !d.AddDate(0, 0, 1).Before(e.End) {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Render draws the month x pixels wide and y pixels tall
+func (w *monthWidget) Render(x, y int) (image.Image, error) {
+	var (
+		first  = time.Date(w.now.Year(), w.now.Month(), 1, 0, 0, 0, 0, w.now.Location())
+		days   = first.AddDate(0, 1, -1).Day()
+		offset = (int(first.Weekday()) - int(w.firstDay) + 7) % 7 // blank cells before the 1st
+		weeks  = (offset + days + 6) / 7
+		cols   = 7
+	)
+	if w.WeekNumbers {
+		cols++
+	}
+
+	dc := gg.NewContext(x, y)
+	dc.SetRGB(1, 1, 1)
+	dc.Clear()
+	dc.SetRGB(0, 0, 0)
+
+	// Note calendars left out below the grid
+	if w.unavailable {
+		y -= int(math.Ceil(drawUnavailableMarker(dc, w.regular, w.locale, float64(x)-4, float64(y))))
+	}
+
+	// A row each for the title, the weekdays and the weeks, in cells no more
+	// than twice as wide as they are tall, centered in the region
+	pad := math.Min(float64(x), float64(y)) * 0.03
+	cellW := (float64(x) - 2*pad) / float64(cols)
+	cellH := (float64(y) - 2*pad) / float64(weeks+2)
+	cellH = math.Min(cellH, cellW)
+	cellW = math.Min(cellW, 2*cellH)
+	left := (float64(x) - cellW*float64(cols)) / 2
+	top := (float64(y) - cellH*float64(weeks+2)) / 2
+	gridW := cellW * float64(cols)
+
+	fontSize := setDynamicFont(dc, w.regular, cellW*0.8, cellH*0.5, "28")
+
+	// Title
+	title := locale.Heading(w.locale.MonthYear(w.now))
+	setFont(dc, w.bold, math.Min(fontSize*1.2, setDynamicFont(dc, w.bold, gridW*0.95, cellH*0.75, title)))
+	dc.DrawStringAnchored(truncateText(dc, title, gridW), float64(x)/2, top+cellH/2, 0.5, 0.35)
+	top += cellH
+
+	// Weekdays, abbreviated to one letter when they don't fit
+	labels := make([]string, 0, cols)
+	if w.WeekNumbers {
+		labels = append(labels, w.locale.Translate("Wk"))
+	}
+	for i := range 7 {
+		labels = append(labels, w.locale.MinWeekday((w.firstDay+time.Weekday(i))%7))
+	}
+	setFont(dc, w.bold, fontSize*0.8)
+	for _, l := range labels {
+		if width, _ := dc.MeasureString(l); width > cellW*0.9 {
+			for i := range labels {
+				labels[i] = string([]rune(labels[i])[:1])
+			}
+			break
+		}
+	}
+	for i, l := range labels {
+		dc.DrawStringAnchored(l, left+(float64(i)+0.5)*cellW, top+cellH/2, 0.5, 0.35)
+	}
+	top += cellH
+	dc.DrawRectangle(left, top-cellH*0.1, gridW, math.Max(1, fontSize/12))
+	dc.Fill()
+
+	// Week numbers, from the Monday of each row
+	if w.WeekNumbers {
+		setFont(dc, w.regular, fontSize*0.7)
+		for row := range weeks {
+			monday := first.AddDate(0, 0, row*7-offset+(int(time.Monday)-int(w.firstDay)+7)%7)
+			_, week := monday.ISOWeek()
+			dc.DrawStringAnchored(strconv.Itoa(week), left+cellW/2, top+(float64(row)+0.5)*cellH, 0.5, 0.35)
+		}
+		dc.DrawRectangle(left+cellW-0.5, top, math.Max(1, fontSize/24), cellH*float64(weeks))
+		dc.Fill()
+	}
+
+	// Days, today in white on a black circle
+	setFont(dc, w.regular, fontSize)
+	radius := math.Min(cellW, cellH) * 0.45
+	dot := math.Max(1.5, fontSize*0.08)
+	for day := 1; day <= days; day++ {
+		i := offset + day - 1
+		col := i % 7
+		if w.WeekNumbers {
+			col++
+		}
+		cx := left + (float64(col)+0.5)*cellW
+		cy := top + (float64(i/7)+0.5)*cellH
+
+		if day == w.now.Day() {
+			dc.DrawCircle(cx, cy, radius)
+			dc.Fill()
+			dc.SetRGB(1, 1, 1)
+		}
+		dc.DrawStringAnchored(strconv.Itoa(day), cx, cy-radius*0.15, 0.5, 0.35)
+		if w.marked[day] {
+			dc.DrawCircle(cx, cy+radius*0.65, dot)
+			dc.Fill()
+		}
+		dc.SetRGB(0, 0, 0)
+	}
+
+	return dc.Image(), nil
+}
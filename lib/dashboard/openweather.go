@@ -1,11 +1,18 @@
 package dashboard
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"image"
-	"math"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
-	"github.com/fogleman/gg"
+	owm "github.com/briandowns/openweathermap"
+	"github.com/justmiles/epd/lib/locale"
 )
 
 // WeatherAPIOptions defines options for the WeatherAPI
@@ -24,42 +31,162 @@ func WithWeatherAPI(weatherAPIOptions *WeatherAPIOptions) Options {
 	}
 }
 
-// buildWeatherWidget x pixels wide and y pixels tall
-func (d *Dashboard) buildWeatherWidget(x, y int) (image.Image, error) {
-	var (
-		xWidth, xHeight = float64(x), float64(y)
-	)
+// OpenWeatherMapLanguage returns the openweathermap.org language code for a
+// locale, e.g. "DE" for German, or "EN" for languages it doesn't support
+func OpenWeatherMapLanguage(l *locale.Locale) string {
+	lang := strings.ToUpper(l.Language())
+	if !owm.ValidLangCode(lang) {
+		return "EN"
+	}
+	return lang
+}
 
-	// Draw background
-	dc := gg.NewContext(x, y)
-	dc.DrawRectangle(0, 0, xWidth, xHeight)
-	dc.SetRGB(0, 0, 0)
-	dc.Fill()
+// OpenWeatherMapURL is the API called by OpenWeatherMap unless its BaseURL is
+// changed.
+const OpenWeatherMapURL = "https://api.openweathermap.org/data/2.5"
 
-	// Draw HR
-	dc.DrawRectangle(0+10, 0, xWidth-20, 2)
-	dc.SetRGB(1, 1, 1)
-	dc.Fill()
+// OpenWeatherMap is a WeatherProvider looking up the current weather and the
+// five day forecast, in three hour steps, for a zip code on
+// openweathermap.org.
+type OpenWeatherMap struct {
+	// BaseURL is the API to call, e.g. a test server in place of
+	// OpenWeatherMapURL.
+	BaseURL string
 
-	// Get Weather info
-	d.weatherAPIService.CurrentByZip(d.weatherAPIOptions.WeatherZipCode, d.weatherAPIOptions.WeatherCountry)
+	// Client makes the requests, http.DefaultClient if nil.
+	Client *http.Client
+
+	options  WeatherAPIOptions
+	tempUnit string
+}
 
-	if len(d.weatherAPIService.Weather) == 0 {
-		return nil, fmt.Errorf("Unable to get weather data")
+// NewOpenWeatherMap creates an OpenWeatherMap provider, checking the API key,
+// temperature unit and language in options
+func NewOpenWeatherMap(options *WeatherAPIOptions) (*OpenWeatherMap, error) {
+	tempUnit := strings.ToUpper(options.WeatherTempUnit)
+	if !owm.ValidDataUnit(tempUnit) {
+		return nil, fmt.Errorf("invalid temperature unit %q, expected C, F or K", options.WeatherTempUnit)
+	}
+	if !owm.ValidLangCode(strings.ToUpper(options.WeatherLanguage)) {
+		return nil, fmt.Errorf("unsupported weather language %q", options.WeatherLanguage)
+	}
+	if err := owm.ValidAPIKey(options.WeatherAPIKey); err != nil {
+		return nil, fmt.Errorf("invalid weather API key: %s", err)
 	}
 
-	img := convertSVGToImage(getIcon(d.weatherAPIService.Weather[0].Icon), 64, 64)
-	dc.DrawImageAnchored(img, 32, 32, 0, 0)
+	return &OpenWeatherMap{
+		BaseURL:  OpenWeatherMapURL,
+		options:  *options,
+		tempUnit: tempUnit,
+	}, nil
+}
 
-	// Draw weather description
-	dc.SetRGB(1, 1, 1)
-	setDynamicFont(dc, 128, 32, d.weatherAPIService.Weather[0].Main)
-	dc.DrawStringAnchored(d.weatherAPIService.Weather[0].Main, 170, 32, 0.5, 0.5)
+// owmCurrent is the response of the current weather API.
+type owmCurrent struct {
+	Dt      int64         `json:"dt"`
+	Weather []owm.Weather `json:"weather"`
+	Main    owm.Main      `json:"main"`
+	Wind    owm.Wind      `json:"wind"`
+}
+
+// owmForecast is the response of the five day forecast API.
+type owmForecast struct {
+	List []struct {
+		Dt      int64         `json:"dt"`
+		Weather []owm.Weather `json:"weather"`
+		Main    owm.Main      `json:"main"`
+		Rain    owm.Rain      `json:"rain"`
+		Snow    owm.Snow      `json:"snow"`
+		Pop     float64       `json:"pop"`
+	} `json:"list"`
+}
 
-	// Draw temp
-	temp := fmt.Sprintf("%v°", math.Floor(d.weatherAPIService.Main.Temp))
-	setFont(dc, 32)
-	dc.DrawStringAnchored(temp, 170, 74, 0.5, 0.5)
+// Weather gets the current weather and the forecast.
+func (o *OpenWeatherMap) Weather(ctx context.Context) (*Weather, error) {
+	var current owmCurrent
+	if err := o.get(ctx, "weather", &current); err != nil {
+		return nil, err
+	}
+	if len(current.Weather) == 0 {
+		return nil, errors.New("openweathermap returned no weather conditions")
+	}
+	var forecast owmForecast
+	if err := o.get(ctx, "forecast", &forecast); err != nil {
+		return nil, err
+	}
 
-	return dc.Image(), nil
+	w := &Weather{
+		Time:          time.Unix(current.Dt, 0),
+		Conditions:    current.Weather[0].Main,
+		Description:   current.Weather[0].Description,
+		Icon:          current.Weather[0].Icon,
+		TempUnit:      o.tempUnit,
+		Temp:          current.Main.Temp,
+		FeelsLike:     current.Main.FeelsLike,
+		Humidity:      current.Main.Humidity,
+		WindSpeed:     current.Wind.Speed,
+		WindDirection: current.Wind.Deg,
+	}
+	for _, f := range forecast.List {
+		if len(f.Weather) == 0 {
+			continue
+		}
+		w.Forecast = append(w.Forecast, Forecast{
+			Time:                time.Unix(f.Dt, 0),
+			Conditions:          f.Weather[0].Main,
+			Description:         f.Weather[0].Description,
+			Icon:                f.Weather[0].Icon,
+			Temp:                f.Main.Temp,
+			TempMin:             f.Main.TempMin,
+			TempMax:             f.Main.TempMax,
+			Precipitation:       f.Rain.ThreeH + f.Snow.ThreeH,
+			PrecipitationChance: f.Pop,
+		})
+	}
+	return w, nil
+}
+
+// get calls the endpoint for the configured zip code and decodes its JSON
+// response into v.
+func (o *OpenWeatherMap) get(ctx context.Context, endpoint string, v any) error {
+	query := url.Values{
+		"appid": {o.options.WeatherAPIKey},
+		"zip":   {fmt.Sprintf("%d,%s", o.options.WeatherZipCode, o.options.WeatherCountry)},
+		"units": {owm.DataUnits[o.tempUnit]},
+		"lang":  {strings.ToLower(o.options.WeatherLanguage)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.BaseURL+"/"+endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	client := o.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		// Drop the URL, which holds the API key
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("could not get %s from openweathermap: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&apiErr)
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return fmt.Errorf("openweathermap %s failed: %s (%d)", endpoint, apiErr.Message, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("could not decode %s from openweathermap: %w", endpoint, err)
+	}
+	return nil
 }
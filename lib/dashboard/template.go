@@ -0,0 +1,167 @@
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// shellTimeout bounds how long a template's shell command may run.
+const shellTimeout = 30 * time.Second
+
+// errHostDisabled is returned by the env, file and json functions unless
+// WithTemplateHost allows them.
+var errHostDisabled = errors.New("reading the environment and files is disabled")
+
+// ExecuteTemplate executes text, the dashboard's header or body named name,
+// as a Go text/template with the functions of templateFuncs and the render's
+// Env as its data, e.g. {{ .Locale.LongDate .Now }}. Text is returned as is
+// unless WithTemplates enabled templates or when it has no actions.
+//
+// Only execute text you trust, such as the operator's own files; RenderContext
+// never executes the text it is given, so text sent by clients stays text.
+func (d *Dashboard) ExecuteTemplate(ctx context.Context, name, text string) (string, error) {
+	if !d.templates || !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	env, err := d.newEnv("", "")
+	if err != nil {
+		return "", err
+	}
+	t, err := template.New(name).Funcs(d.templateFuncs(ctx, env)).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, env); err != nil {
+		return "", fmt.Errorf("could not execute %s template: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// templateFuncs are the functions header and body templates can call:
+//
+//	now                    the time, in the dashboard's location
+//	date "Mon Jan 2"       the time formatted with a Go time layout
+//	daysUntil "2026-11-01" whole days from today until a date, negative once past
+//	env "HOME"             an environment variable, if WithTemplateHost allows it
+//	file "notes.md"        the contents of a file, if WithTemplateHost allows it
+//	json "f.json" "a.0.b"  a value from a JSON file, if WithTemplateHost allows it
+//	weather                the weather, with fields such as .Temp and .Conditions
+//	temp .Temp             a temperature in whole degrees, as the widgets show it
+//	shell "uptime -p"      a command's output, if WithTemplateShell allows it
+func (d *Dashboard) templateFuncs(ctx context.Context, env *Env) template.FuncMap {
+	return template.FuncMap{
+		"now": func() time.Time {
+			return env.Now
+		},
+		"date": func(layout string) string {
+			return env.Now.Format(layout)
+		},
+		"daysUntil": func(date string) (int, error) {
+			t, err := time.ParseInLocation(time.DateOnly, date, env.Location)
+			if err != nil {
+				return 0, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", date)
+			}
+			return int(math.Round(t.Sub(startOfDay(env.Now)).Hours() / 24)), nil
+		},
+		"env": func(key string) (string, error) {
+			if !d.templateHost {
+				return "", errHostDisabled
+			}
+			return os.Getenv(key), nil
+		},
+		"file": func(path string) (string, error) {
+			if !d.templateHost {
+				return "", errHostDisabled
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimRight(string(data), "\n"), nil
+		},
+		"json": func(path, key string) (any, error) {
+			if !d.templateHost {
+				return nil, errHostDisabled
+			}
+			return jsonLookup(path, key)
+		},
+		"weather": func() (*Weather, error) {
+			return env.Weather(ctx)
+		},
+		"temp": func(t float64) string {
+			return formatTemp(env.Locale, t)
+		},
+		"shell": func(command string) (string, error) {
+			if !d.templateShell {
+				return "", errors.New("shell commands are disabled")
+			}
+			return runShell(ctx, command)
+		},
+	}
+}
+
+// jsonLookup returns the value in the JSON file at path found by following
+// key, object keys and array indexes separated by dots, e.g. "items.0.name".
+// An empty key returns the whole document.
+func jsonLookup(path, key string) (any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("invalid JSON in %s: %w", path, err)
+	}
+	if key == "" {
+		return v, nil
+	}
+
+	for _, k := range strings.Split(key, ".") {
+		switch t := v.(type) {
+		case map[string]any:
+			var ok bool
+			if v, ok = t[k]; !ok {
+				return nil, fmt.Errorf("%s has no %q", path, key)
+			}
+		case []any:
+			i, err := strconv.Atoi(k)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, fmt.Errorf("%s has no %q", path, key)
+			}
+			v = t[i]
+		default:
+			return nil, fmt.Errorf("%s has no %q", path, key)
+		}
+	}
+	return v, nil
+}
+
+// runShell runs command with sh and returns its output, without the final
+// newline.
+func runShell(ctx context.Context, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, shellTimeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%q failed: %w: %s", command, err, msg)
+		}
+		return "", fmt.Errorf("%q failed: %w", command, err)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
@@ -0,0 +1,144 @@
+package dashboard_test
+
+import (
+	"image"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/justmiles/epd/lib/dashboard"
+)
+
+// hasBlack reports whether any pixel in r is black, e.g. text.
+func hasBlack(img image.Image, r image.Rectangle) bool {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if isBlack(img, x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renderAgenda renders a 400x300 agenda of the calendars at srcs.
+func renderAgenda(t *testing.T, srcs ...string) (image.Image, error) {
+	t.Helper()
+	l, err := dashboard.ParseLayout([]byte(`
+width: 400
+height: 300
+root:
+  widget: agenda
+  config:
+    calendars: ["` + strings.Join(srcs, `", "`) + `"]
+`))
+	if err != nil {
+		t.Fatalf("ParseLayout failed: %v", err)
+	}
+	d, err := dashboard.NewDashboard(dashboard.WithLayout(l))
+	if err != nil {
+		t.Fatalf("NewDashboard failed: %v", err)
+	}
+	return d.Render("Header", "Body")
+}
+
+func TestAgendaWidget(t *testing.T) {
+	// The second of today's events; with none, only "No events" and the
+	// start of tomorrow's heading
+	secondEvent := image.Rect(150, 71, 390, 99)
+
+	img, err := renderAgenda(t, "testdata/agenda.ics")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !hasBlack(img, image.Rect(0, 0, 400, 40)) {
+		t.Error("Expected today's heading")
+	}
+	if !hasBlack(img, secondEvent) {
+		t.Error("Expected two events today")
+	}
+
+	empty := t.TempDir() + "/empty.ics"
+	if err := os.WriteFile(empty, []byte("BEGIN:VCALENDAR\nEND:VCALENDAR\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if img, err = renderAgenda(t, empty); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if hasBlack(img, secondEvent) {
+		t.Error("Expected no events")
+	}
+}
+
+func TestAgendaWidget_URL(t *testing.T) {
+	ics, err := os.ReadFile("testdata/agenda.ics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/private-secret/basic.ics" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(ics)
+	}))
+	t.Cleanup(ts.Close)
+
+	if _, err := renderAgenda(t, ts.URL+"/private-secret/basic.ics"); err != nil {
+		t.Errorf("Render failed: %v", err)
+	}
+
+	_, err = renderAgenda(t, ts.URL+"/private-other/basic.ics")
+	if err == nil || !strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "private") {
+		t.Errorf("Expected a 404 without the secret path, got %v", err)
+	}
+}
+
+func TestAgendaWidget_Unavailable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(ts.Close)
+	marker := image.Rect(200, 285, 400, 300)
+
+	// A calendar that can't be read is left out and marked
+	img, err := renderAgenda(t, "testdata/agenda.ics", ts.URL+"/basic.ics")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !hasBlack(img, image.Rect(150, 71, 390, 99)) {
+		t.Error("Expected the events of the other calendar")
+	}
+	if !hasBlack(img, marker) {
+		t.Error("Expected a marker for the unavailable calendar")
+	}
+
+	img, err = renderAgenda(t, "testdata/agenda.ics")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if hasBlack(img, marker) {
+		t.Error("Expected no marker with every calendar available")
+	}
+
+	// With none to show, the dashboard fails
+	if _, err := renderAgenda(t, ts.URL+"/basic.ics", "testdata/missing.ics"); err == nil || !strings.Contains(err.Error(), "404") || !strings.Contains(err.Error(), "could not read calendar") {
+		t.Errorf("Expected both calendars' errors, got %v", err)
+	}
+}
+
+func TestAgendaWidget_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		config, err string
+	}{
+		{"{}", "calendars must list"},
+		{"{calendars: [a.ics], days: 0}", "days must be at least 1"},
+		{"{calendars: a.ics}", "invalid agenda config"},
+	}
+	for _, tt := range tests {
+		_, err := dashboard.ParseLayout([]byte("root: {widget: agenda, config: " + tt.config + "}"))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.config, tt.err, err)
+		}
+	}
+}
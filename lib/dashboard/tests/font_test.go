@@ -0,0 +1,69 @@
+package dashboard_test
+
+import (
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/justmiles/epd/lib/dashboard"
+	"golang.org/x/image/font/gofont/gomono"
+)
+
+// renderHeader renders a header widget with config in a 400x80 region.
+func renderHeader(t *testing.T, config string) image.Image {
+	t.Helper()
+	l, err := dashboard.ParseLayout([]byte(`{"width": 400, "height": 80, "root": {"widget": "header", "config": ` + config + `}}`))
+	if err != nil {
+		t.Fatalf("ParseLayout failed: %v", err)
+	}
+	return render(t, l)
+}
+
+func TestWidgetFonts(t *testing.T) {
+	plain := renderHeader(t, `{"text": "Hello"}`)
+	if !sameImage(plain, renderHeader(t, `{"text": "Hello", "font": "go", "font-weight": "regular"}`)) {
+		t.Error("Expected the regular Go font by default")
+	}
+
+	mono := filepath.Join(t.TempDir(), "mono.ttf")
+	if err := os.WriteFile(mono, gomono.TTF, 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name, config string
+	}{
+		{"bold", `{"text": "Hello", "font-weight": "bold"}`},
+		{"italic", `{"text": "Hello", "font-style": "italic"}`},
+		{"mono", `{"text": "Hello", "font": "go-mono"}`},
+		{"file", `{"text": "Hello", "font": "` + mono + `"}`},
+		{"size", `{"text": "Hello", "font-size": 20}`},
+	}
+	for _, tt := range tests {
+		if sameImage(plain, renderHeader(t, tt.config)) {
+			t.Errorf("%s: expected the header to change", tt.name)
+		}
+	}
+	if !sameImage(renderHeader(t, tests[2].config), renderHeader(t, tests[3].config)) {
+		t.Error("Expected the font file to match the built-in font it holds")
+	}
+}
+
+func TestWidgetFonts_Invalid(t *testing.T) {
+	tests := []struct {
+		widget, config, err string
+	}{
+		{"header", "{font: comic-sans}", "could not read font"},
+		{"calendar", "{font-weight: heavy}", "unknown weight"},
+		{"month", "{font: go-mono, font-style: oblique}", "unknown style"},
+		{"weather", "{font: /fonts/a.ttf, font-weight: bold}", "only apply to built-in fonts"},
+		{"body", "{font: /nonexistent/a.ttf}", "could not read font"},
+	}
+	for _, tt := range tests {
+		_, err := dashboard.ParseLayout([]byte("root: {widget: " + tt.widget + ", config: " + tt.config + "}"))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s %s: expected an error containing %q, got %v", tt.widget, tt.config, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package dashboard_test
+
+import (
+	"encoding/json"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/dashboard"
+)
+
+// forecastFile writes five days of forecasts in three hour steps from now.
+func forecastFile(t *testing.T) dashboard.WeatherFile {
+	t.Helper()
+	w := dashboard.Weather{Conditions: "Clouds", Icon: "04d", Temp: 41}
+	start := time.Now().Truncate(time.Hour)
+	icons := []string{"01d", "02n", "04d", "10d", "13d"}
+	for i := range 40 {
+		temp := float64(30 + i%8)
+		w.Forecast = append(w.Forecast, dashboard.Forecast{
+			Time:                start.Add(time.Duration(i) * 3 * time.Hour),
+			Icon:                icons[i%len(icons)],
+			Temp:                temp,
+			TempMin:             temp - 2,
+			TempMax:             temp + 2,
+			PrecipitationChance: float64(i%5) / 5,
+		})
+	}
+
+	data, err := json.Marshal(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "weather.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dashboard.WeatherFile(path)
+}
+
+// hasWhite reports whether any pixel in r is white, e.g. text or an icon.
+func hasWhite(img image.Image, r image.Rectangle) bool {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if !isBlack(img, x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestForecastWidget(t *testing.T) {
+	l, err := dashboard.ParseLayout([]byte(`
+width: 600
+height: 400
+root:
+  direction: row
+  children:
+    - widget: forecast
+      size: 100
+      config: {mode: hourly, count: 3}
+    - widget: forecast
+`))
+	if err != nil {
+		t.Fatalf("ParseLayout failed: %v", err)
+	}
+	d, err := dashboard.NewDashboard(dashboard.WithLayout(l), dashboard.WithWeatherProvider(forecastFile(t)))
+	if err != nil {
+		t.Fatalf("NewDashboard failed: %v", err)
+	}
+	img, err := d.Render("Header", "Body")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	if !isBlack(img, 1, 1) || !isBlack(img, 598, 398) {
+		t.Error("Expected the forecasts to fill the dashboard")
+	}
+	// Three hours stacked in the tall region on the left
+	for i := range 3 {
+		if r := image.Rect(0, i*133+10, 100, i*133+120); !hasWhite(img, r) {
+			t.Errorf("Expected hour %d to be drawn in %v", i, r)
+		}
+	}
+	// Five days side by side in the wide region on the right
+	for i := range 5 {
+		if r := image.Rect(100+i*100, 0, 200+i*100, 400); !hasWhite(img, r) {
+			t.Errorf("Expected day %d to be drawn in %v", i, r)
+		}
+	}
+}
+
+func TestForecastWidget_NoWeather(t *testing.T) {
+	l, err := dashboard.ParseLayout([]byte("root: {widget: forecast}"))
+	if err != nil {
+		t.Fatalf("ParseLayout failed: %v", err)
+	}
+	if img := render(t, l); isBlack(img, 400, 240) {
+		t.Error("Expected no forecast without a weather provider")
+	}
+}
+
+func TestForecastWidget_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		config, err string
+	}{
+		{"{mode: weekly}", "mode must be daily or hourly"},
+		{"{count: -1}", "count must not be negative"},
+	}
+	for _, tt := range tests {
+		_, err := dashboard.ParseLayout([]byte("root: {widget: forecast, config: " + tt.config + "}"))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.config, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package dashboard_test
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/justmiles/epd/lib/dashboard"
+)
+
+// isBlack reports whether the pixel at x, y is closer to black than white.
+func isBlack(img image.Image, x, y int) bool {
+	return color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y < 128
+}
+
+func render(t *testing.T, layout *dashboard.Layout) image.Image {
+	t.Helper()
+	d, err := dashboard.NewDashboard(dashboard.WithLayout(layout))
+	if err != nil {
+		t.Fatalf("NewDashboard failed: %v", err)
+	}
+	img, err := d.Render("Header", "Body")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	return img
+}
+
+func TestDefaultLayout(t *testing.T) {
+	img := render(t, dashboard.DefaultLayout())
+	if b := img.Bounds(); b.Dx() != 800 || b.Dy() != 480 {
+		t.Fatalf("Expected an 800x480 dashboard, got %v", b)
+	}
+
+	tests := []struct {
+		name  string
+		x, y  int
+		black bool
+	}{
+		{"calendar", 5, 5, true},
+		{"gap between the columns", 257, 300, false},
+		{"header", 795, 5, true},
+		{"body", 795, 400, false},
+	}
+	for _, tt := range tests {
+		if got := isBlack(img, tt.x, tt.y); got != tt.black {
+			t.Errorf("Expected the %s at %d,%d to be black=%t", tt.name, tt.x, tt.y, tt.black)
+		}
+	}
+}
+
+func TestLoadLayout_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "layout.json")
+	layout := `{
+		"width": 400, "height": 300,
+		"root": {"direction": "row", "children": [
+			{"widget": "header", "size": "25%", "config": {"text": "Hi", "font-size": 12}},
+			{"widget": "body", "grow": 3}
+		]}
+	}`
+	if err := os.WriteFile(path, []byte(layout), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := dashboard.LoadLayout(path)
+	if err != nil {
+		t.Fatalf("LoadLayout failed: %v", err)
+	}
+	img := render(t, l)
+	if b := img.Bounds(); b.Dx() != 400 || b.Dy() != 300 {
+		t.Fatalf("Expected a 400x300 dashboard, got %v", b)
+	}
+	if !isBlack(img, 5, 5) || !isBlack(img, 95, 295) {
+		t.Error("Expected the header to fill the left quarter")
+	}
+	if isBlack(img, 105, 295) {
+		t.Error("Expected the body to fill the rest")
+	}
+}
+
+func TestParseLayout_Invalid(t *testing.T) {
+	tests := []struct {
+		name, layout, err string
+	}{
+		{"no root", "width: 800", "missing root"},
+		{"unknown widget", "root: {widget: clock}", `unknown widget "clock"`},
+		{"widget and children", "root: {widget: body, children: [{widget: header}]}", "not both"},
+		{"bad size", "root: {children: [{widget: body, size: wide}]}", `invalid size "wide"`},
+		{"bad direction", "root: {direction: diagonal}", "direction"},
+		{"unknown field", "root: {widgt: body}", "widgt"},
+	}
+	for _, tt := range tests {
+		_, err := dashboard.ParseLayout([]byte(tt.layout))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
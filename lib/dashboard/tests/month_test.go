@@ -0,0 +1,135 @@
+package dashboard_test
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/dashboard"
+)
+
+// renderMonth renders a month widget with config in a region of the size.
+func renderMonth(t *testing.T, width, height int, config string) image.Image {
+	t.Helper()
+	l, err := dashboard.ParseLayout([]byte(fmt.Sprintf(
+		`{"width": %d, "height": %d, "root": {"widget": "month", "config": %s}}`, width, height, config)))
+	if err != nil {
+		t.Fatalf("ParseLayout failed: %v", err)
+	}
+	return render(t, l)
+}
+
+// sameImage reports whether a and b have the same pixels.
+func sameImage(a, b image.Image) bool {
+	if a.Bounds() != b.Bounds() {
+		return false
+	}
+	r := a.Bounds()
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if isBlack(a, x, y) != isBlack(b, x, y) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestMonthWidget(t *testing.T) {
+	plain := renderMonth(t, 800, 480, "{}")
+	if !hasBlack(plain, plain.Bounds()) {
+		t.Fatal("Expected the month to be drawn")
+	}
+
+	today := time.Now().Format(time.DateOnly)
+	tests := []struct {
+		name, config string
+	}{
+		{"monday", `{"week-start": "monday"}`},
+		{"week numbers", `{"week-numbers": true}`},
+		{"events", `{"events": ["` + today + `"]}`},
+	}
+	for _, tt := range tests {
+		if sameImage(plain, renderMonth(t, 800, 480, tt.config)) {
+			t.Errorf("%s: expected the month to change", tt.name)
+		}
+	}
+
+	// Dates in other months aren't marked
+	if !sameImage(plain, renderMonth(t, 800, 480, `{"events": ["1999-01-01"]}`)) {
+		t.Error("Expected a date in another month to be left out")
+	}
+
+	// Small regions still fit the whole month
+	small := renderMonth(t, 140, 100, `{"week-numbers": true}`)
+	if !hasBlack(small, small.Bounds()) {
+		t.Error("Expected a small month to be drawn")
+	}
+}
+
+func TestMonthWidget_Unavailable(t *testing.T) {
+	marker := image.Rect(400, 462, 800, 480)
+	plain := renderMonth(t, 800, 480, "{}")
+	if hasBlack(plain, marker) {
+		t.Fatal("Expected no marker with no calendars")
+	}
+
+	// A calendar that can't be read is left out and marked, and the days of
+	// the others are still marked
+	missing := `"testdata/missing.ics"`
+	partial := renderMonth(t, 800, 480, `{"calendars": ["testdata/agenda.ics", `+missing+`]}`)
+	if !hasBlack(partial, marker) {
+		t.Error("Expected a marker for the unavailable calendar")
+	}
+	if sameImage(partial, renderMonth(t, 800, 480, `{"calendars": [`+missing+`]}`)) {
+		t.Error("Expected the days of the other calendar to be marked")
+	}
+}
+
+func TestMonthWidget_Locale(t *testing.T) {
+	l, err := dashboard.ParseLayout([]byte(`{"width": 400, "height": 300, "root": {"widget": "month"}}`))
+	if err != nil {
+		t.Fatalf("ParseLayout failed: %v", err)
+	}
+	renderLocale := func(tag string) (image.Image, error) {
+		d, err := dashboard.NewDashboard(dashboard.WithLayout(l), dashboard.WithLocale(tag))
+		if err != nil {
+			t.Fatalf("NewDashboard failed: %v", err)
+		}
+		return d.Render("Header", "Body")
+	}
+
+	// German weeks start on Monday, and name days and months in German
+	en, err := renderLocale("en")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	de, err := renderLocale("de")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if sameImage(en, de) {
+		t.Error("Expected the German month to differ")
+	}
+
+	if _, err := renderLocale("xx"); err == nil || !strings.Contains(err.Error(), "Invalid locale") {
+		t.Errorf("Expected an invalid locale error, got %v", err)
+	}
+}
+
+func TestMonthWidget_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		config, err string
+	}{
+		{"{week-start: tuesday}", `week-start must be "sunday" or "monday"`},
+		{"{events: [6/4/2024]}", "invalid event date"},
+	}
+	for _, tt := range tests {
+		_, err := dashboard.ParseLayout([]byte("root: {widget: month, config: " + tt.config + "}"))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.config, tt.err, err)
+		}
+	}
+}
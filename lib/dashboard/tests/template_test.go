@@ -0,0 +1,176 @@
+package dashboard_test
+
+import (
+	"context"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/dashboard"
+)
+
+// textWidget records the header and body text it's given, and draws nothing.
+type textWidget struct{}
+
+var renderedText [2]string
+
+func (w *textWidget) Configure(config dashboard.Config) error { return nil }
+
+func (w *textWidget) Fetch(ctx context.Context, env *dashboard.Env) error {
+	renderedText = [2]string{env.HeaderText, env.BodyText}
+	return nil
+}
+
+func (w *textWidget) Render(width, height int) (image.Image, error) { return nil, nil }
+
+func init() {
+	dashboard.Register("test-text", func() dashboard.Widget { return &textWidget{} })
+}
+
+// executeTemplate executes text as the body of a dashboard with opts.
+func executeTemplate(t *testing.T, text string, opts ...dashboard.Options) (string, error) {
+	t.Helper()
+	d, err := dashboard.NewDashboard(append([]dashboard.Options{dashboard.WithLocation("UTC")}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewDashboard failed: %v", err)
+	}
+	return d.ExecuteTemplate(context.Background(), "body", text)
+}
+
+func TestTemplates(t *testing.T) {
+	dir := t.TempDir()
+	notes := filepath.Join(dir, "notes.md")
+	if err := os.WriteFile(notes, []byte("- milk\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	status := filepath.Join(dir, "status.json")
+	if err := os.WriteFile(status, []byte(`{"builds": [{"name": "api", "ok": true}], "count": 3}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("EPD_TEST_GREETING", "Hi")
+
+	now := time.Now().UTC()
+	today := now.Format(time.DateOnly)
+	inAWeek := now.AddDate(0, 0, 7).Format(time.DateOnly)
+	tests := []struct {
+		template, want string
+	}{
+		{`Plain {text}`, `Plain {text}`},
+		{`{{ date "2006-01-02" }}`, today},
+		{`{{ now.Year }}`, now.Format("2006")},
+		{`{{ .Locale.Weekday .Now.Weekday }}`, now.Weekday().String()},
+		{`Sprint ends in {{ daysUntil "` + inAWeek + `" }} days`, "Sprint ends in 7 days"},
+		{`{{ daysUntil "` + today + `" }}`, "0"},
+		{`{{ env "EPD_TEST_GREETING" }}`, "Hi"},
+		{"Shopping:\n{{ file `" + notes + "` }}", "Shopping:\n- milk"},
+		{`{{ json "` + status + `" "builds.0.name" }} {{ json "` + status + `" "count" }}`, "api 3"},
+		{`{{ with weather }}{{ .Conditions }}{{ else }}none{{ end }}`, "none"},
+	}
+	for _, tt := range tests {
+		body, err := executeTemplate(t, tt.template, dashboard.WithTemplates(true), dashboard.WithTemplateHost(true))
+		if err != nil {
+			t.Errorf("%s: execute failed: %v", tt.template, err)
+			continue
+		}
+		if body != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.template, tt.want, body)
+		}
+	}
+
+	// Weather comes from the dashboard's provider
+	body, err := executeTemplate(t, `{{ with weather }}{{ .Conditions }}, {{ temp .Temp }}{{ end }}`,
+		dashboard.WithTemplates(true), dashboard.WithWeatherProvider(forecastFile(t)))
+	if err != nil || body != "Clouds, 41°" {
+		t.Errorf("Expected the weather, got %q, %v", body, err)
+	}
+
+	// Templates are only executed when enabled
+	if body, _ := executeTemplate(t, `{{ date "2006" }}`); body != `{{ date "2006" }}` {
+		t.Errorf("Expected the text untouched, got %q", body)
+	}
+}
+
+func TestTemplates_Render(t *testing.T) {
+	l, err := dashboard.ParseLayout([]byte("width: 10\nheight: 10\nroot: {widget: test-text}"))
+	if err != nil {
+		t.Fatalf("ParseLayout failed: %v", err)
+	}
+	d, err := dashboard.NewDashboard(dashboard.WithLayout(l), dashboard.WithTemplates(true), dashboard.WithTemplateHost(true))
+	if err != nil {
+		t.Fatalf("NewDashboard failed: %v", err)
+	}
+
+	// Rendering never executes the text it's given
+	renderedText = [2]string{}
+	if _, err := d.Render(`{{ env "HOME" }}`, `{{ date "2006" }}`); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if renderedText != [2]string{`{{ env "HOME" }}`, `{{ date "2006" }}`} {
+		t.Errorf("Expected the text untouched, got %q", renderedText)
+	}
+}
+
+func TestTemplates_Host(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secret.json")
+	if err := os.WriteFile(file, []byte(`{"key": "s3cret"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("EPD_TEST_SECRET", "s3cret")
+
+	for _, text := range []string{
+		`{{ env "EPD_TEST_SECRET" }}`,
+		"{{ (file `" + file + "`) | len }}",
+		"{{ json `" + file + "` `key` }}",
+	} {
+		body, err := executeTemplate(t, text, dashboard.WithTemplates(true))
+		if err == nil || !strings.Contains(err.Error(), "reading the environment and files is disabled") {
+			t.Errorf("%s: expected host access to be disabled by default, got %q, %v", text, body, err)
+		}
+
+		if _, err = executeTemplate(t, text, dashboard.WithTemplates(true), dashboard.WithTemplateHost(true)); err != nil {
+			t.Errorf("%s: expected host access once allowed, got %v", text, err)
+		}
+	}
+}
+
+func TestTemplates_Shell(t *testing.T) {
+	if _, err := executeTemplate(t, `{{ shell "echo hi" }}`, dashboard.WithTemplates(true)); err == nil || !strings.Contains(err.Error(), "shell commands are disabled") {
+		t.Errorf("Expected shell commands to be disabled by default, got %v", err)
+	}
+
+	out, err := executeTemplate(t, `{{ shell "echo hi" }}`, dashboard.WithTemplates(true), dashboard.WithTemplateShell(true))
+	if err != nil || out != "hi" {
+		t.Errorf("Expected the command's output, got %q, %v", out, err)
+	}
+
+	_, err = executeTemplate(t, `{{ shell "echo oops >&2; exit 3" }}`, dashboard.WithTemplates(true), dashboard.WithTemplateShell(true))
+	if err == nil || !strings.Contains(err.Error(), "oops") {
+		t.Errorf("Expected the command's error, got %v", err)
+	}
+}
+
+func TestTemplates_Errors(t *testing.T) {
+	status := filepath.Join(t.TempDir(), "status.json")
+	if err := os.WriteFile(status, []byte(`{"builds": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		template, err string
+	}{
+		{`{{ date }`, "invalid body template"},
+		{`{{ daysUntil "11/01/2026" }}`, "expected YYYY-MM-DD"},
+		{`{{ file "/nonexistent/notes.md" }}`, "no such file"},
+		{`{{ json "` + status + `" "builds.0" }}`, `has no "builds.0"`},
+		{`{{ json "` + status + `" "builds.name" }}`, `has no "builds.name"`},
+	}
+	for _, tt := range tests {
+		_, err := executeTemplate(t, tt.template, dashboard.WithTemplates(true), dashboard.WithTemplateHost(true))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.template, tt.err, err)
+		}
+	}
+}
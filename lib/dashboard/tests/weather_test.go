@@ -0,0 +1,164 @@
+package dashboard_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/justmiles/epd/lib/dashboard"
+)
+
+const (
+	testAPIKey = "0123456789abcdef0123456789abcdef"
+
+	currentJSON = `{
+		"dt": 1700000000,
+		"weather": [{"main": "Clouds", "description": "broken clouds", "icon": "04d"}],
+		"main": {"temp": 41.5, "feels_like": 36.2, "humidity": 71},
+		"wind": {"speed": 9.2, "deg": 240}
+	}`
+
+	forecastJSON = `{"list": [
+		{"dt": 1700010800, "weather": [{"main": "Rain", "description": "light rain", "icon": "10d"}],
+		 "main": {"temp": 40, "temp_min": 38, "temp_max": 42}, "rain": {"3h": 1.5}, "pop": 0.6},
+		{"dt": 1700021600, "weather": [{"main": "Snow", "description": "snow", "icon": "13n"}],
+		 "main": {"temp": 31, "temp_min": 30, "temp_max": 33}, "snow": {"3h": 2}, "pop": 0.9}
+	]}`
+)
+
+// fakeOpenWeatherMap serves the current weather and forecast, checking the
+// query, and returns a provider calling it.
+func fakeOpenWeatherMap(t *testing.T, handler http.HandlerFunc) *dashboard.OpenWeatherMap {
+	t.Helper()
+	if handler == nil {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if q.Get("appid") != testAPIKey || q.Get("zip") != "60601,US" || q.Get("units") != "imperial" {
+				http.Error(w, `{"cod": 400, "message": "bad query"}`, http.StatusBadRequest)
+				return
+			}
+			switch r.URL.Path {
+			case "/weather":
+				w.Write([]byte(currentJSON))
+			case "/forecast":
+				w.Write([]byte(forecastJSON))
+			default:
+				http.NotFound(w, r)
+			}
+		}
+	}
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	o, err := dashboard.NewOpenWeatherMap(&dashboard.WeatherAPIOptions{
+		WeatherAPIKey:   testAPIKey,
+		WeatherLanguage: "EN",
+		WeatherTempUnit: "F",
+		WeatherCountry:  "US",
+		WeatherZipCode:  60601,
+	})
+	if err != nil {
+		t.Fatalf("NewOpenWeatherMap failed: %v", err)
+	}
+	o.BaseURL = ts.URL
+	return o
+}
+
+func TestOpenWeatherMap(t *testing.T) {
+	w, err := fakeOpenWeatherMap(t, nil).Weather(context.Background())
+	if err != nil {
+		t.Fatalf("Weather failed: %v", err)
+	}
+
+	if w.Conditions != "Clouds" || w.Description != "broken clouds" || w.Icon != "04d" {
+		t.Errorf("Unexpected conditions %q, %q, %q", w.Conditions, w.Description, w.Icon)
+	}
+	if w.Temp != 41.5 || w.FeelsLike != 36.2 || w.TempUnit != "F" || w.Humidity != 71 {
+		t.Errorf("Unexpected temperature %v%s, feels like %v, humidity %d", w.Temp, w.TempUnit, w.FeelsLike, w.Humidity)
+	}
+	if w.WindSpeed != 9.2 || w.WindDirection != 240 || w.Time.Unix() != 1700000000 {
+		t.Errorf("Unexpected wind %v at %v, time %v", w.WindSpeed, w.WindDirection, w.Time)
+	}
+
+	if len(w.Forecast) != 2 {
+		t.Fatalf("Expected 2 forecasts, got %d", len(w.Forecast))
+	}
+	rain, snow := w.Forecast[0], w.Forecast[1]
+	if rain.Icon != "10d" || rain.TempMax != 42 || rain.Precipitation != 1.5 || rain.PrecipitationChance != 0.6 {
+		t.Errorf("Unexpected rain forecast %+v", rain)
+	}
+	if snow.Conditions != "Snow" || snow.TempMin != 30 || snow.Precipitation != 2 {
+		t.Errorf("Unexpected snow forecast %+v", snow)
+	}
+}
+
+func TestOpenWeatherMap_Error(t *testing.T) {
+	o := fakeOpenWeatherMap(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"cod": 401, "message": "Invalid API key."}`))
+	})
+	_, err := o.Weather(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "Invalid API key. (401)") {
+		t.Fatalf("Expected the API's error, got %v", err)
+	}
+
+	o.BaseURL = "http://127.0.0.1:1"
+	_, err = o.Weather(context.Background())
+	if err == nil || strings.Contains(err.Error(), testAPIKey) {
+		t.Errorf("Expected an error without the API key, got %v", err)
+	}
+}
+
+func TestNewOpenWeatherMap_Invalid(t *testing.T) {
+	tests := []dashboard.WeatherAPIOptions{
+		{WeatherAPIKey: "short", WeatherLanguage: "EN", WeatherTempUnit: "F"},
+		{WeatherAPIKey: testAPIKey, WeatherLanguage: "EN", WeatherTempUnit: "X"},
+		{WeatherAPIKey: testAPIKey, WeatherLanguage: "XX", WeatherTempUnit: "C"},
+	}
+	for _, tt := range tests {
+		if _, err := dashboard.NewOpenWeatherMap(&tt); err == nil {
+			t.Errorf("Expected %+v to be rejected", tt)
+		}
+	}
+}
+
+func TestWeatherFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weather.json")
+	weather := `{"conditions": "Clear", "icon": "01d", "temp_unit": "C", "temp": 21,
+		"forecast": [{"time": "2024-06-01T15:00:00Z", "temp_max": 24}]}`
+	if err := os.WriteFile(path, []byte(weather), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := dashboard.WeatherFile(path).Weather(context.Background())
+	if err != nil {
+		t.Fatalf("Weather failed: %v", err)
+	}
+	if w.Conditions != "Clear" || w.Temp != 21 || len(w.Forecast) != 1 || w.Forecast[0].TempMax != 24 {
+		t.Errorf("Unexpected weather %+v", w)
+	}
+
+	// The weather widget draws a black panel under the calendar
+	d, err := dashboard.NewDashboard(dashboard.WithWeatherProvider(dashboard.WeatherFile(path)))
+	if err != nil {
+		t.Fatalf("NewDashboard failed: %v", err)
+	}
+	img, err := d.Render("Header", "Body")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !isBlack(img, 5, 470) {
+		t.Error("Expected the weather to be drawn")
+	}
+	if img := render(t, dashboard.DefaultLayout()); isBlack(img, 5, 470) {
+		t.Error("Expected no weather without a provider")
+	}
+
+	if _, err := dashboard.WeatherFile(filepath.Join(t.TempDir(), "missing.json")).Weather(context.Background()); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
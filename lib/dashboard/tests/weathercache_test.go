@@ -0,0 +1,125 @@
+package dashboard_test
+
+import (
+	"context"
+	"errors"
+	"image"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/dashboard"
+)
+
+// fakeProvider returns its weather or error, counting the calls.
+type fakeProvider struct {
+	weather *dashboard.Weather
+	err     error
+	calls   int
+}
+
+func (p *fakeProvider) Weather(ctx context.Context) (*dashboard.Weather, error) {
+	p.calls++
+	return p.weather, p.err
+}
+
+func TestWeatherCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "weather.json")
+	p := &fakeProvider{weather: &dashboard.Weather{Conditions: "Clear", Temp: 70}}
+	cache := dashboard.NewWeatherCache(p, path, "60601", time.Hour)
+
+	for range 2 {
+		w, err := cache.Weather(context.Background())
+		if err != nil {
+			t.Fatalf("Weather failed: %v", err)
+		}
+		if w.Conditions != "Clear" || !w.StaleSince.IsZero() {
+			t.Errorf("Expected fresh weather, got %+v", w)
+		}
+	}
+	if p.calls != 1 {
+		t.Errorf("Expected the provider to be asked once, got %d", p.calls)
+	}
+
+	// Another cache of the same file shares the weather, unless the key differs
+	if _, err := dashboard.NewWeatherCache(p, path, "60601", time.Hour).Weather(context.Background()); err != nil || p.calls != 1 {
+		t.Errorf("Expected the saved weather, got %d calls, %v", p.calls, err)
+	}
+	if _, err := dashboard.NewWeatherCache(p, path, "10001", time.Hour).Weather(context.Background()); err != nil || p.calls != 2 {
+		t.Errorf("Expected weather saved for another key to be ignored, got %d calls, %v", p.calls, err)
+	}
+}
+
+func TestWeatherCache_Stale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weather.json")
+	p := &fakeProvider{weather: &dashboard.Weather{Conditions: "Clear", Temp: 70}}
+
+	// Without a TTL, the provider is always asked
+	cache := dashboard.NewWeatherCache(p, path, "60601", 0)
+	before := time.Now()
+	if _, err := cache.Weather(context.Background()); err != nil {
+		t.Fatalf("Weather failed: %v", err)
+	}
+
+	p.weather, p.err = nil, errors.New("network is unreachable")
+	w, err := cache.Weather(context.Background())
+	if err != nil {
+		t.Fatalf("Expected the saved weather, got %v", err)
+	}
+	if p.calls != 2 {
+		t.Errorf("Expected the provider to be asked twice, got %d", p.calls)
+	}
+	if w.Conditions != "Clear" || w.StaleSince.Before(before.Truncate(time.Second)) || w.StaleSince.After(time.Now()) {
+		t.Errorf("Expected stale weather from the first call, got %+v", w)
+	}
+
+	// Nothing to fall back on
+	empty := dashboard.NewWeatherCache(p, filepath.Join(t.TempDir(), "weather.json"), "60601", time.Hour)
+	if _, err := empty.Weather(context.Background()); !errors.Is(err, dashboard.ErrWeatherUnavailable) || !errors.Is(err, p.err) {
+		t.Errorf("Expected the weather unavailable with the provider's error, got %v", err)
+	}
+}
+
+func TestWeatherCache_StaleMarker(t *testing.T) {
+	weather := &dashboard.Weather{Conditions: "Clear", Icon: "01d", Temp: 70}
+	// The marker goes along the bottom right of the weather widget
+	marker := image.Rect(150, 458, 250, 478)
+
+	for _, stale := range []bool{false, true} {
+		if stale {
+			weather.StaleSince = time.Now().Add(-time.Hour)
+		}
+		d, err := dashboard.NewDashboard(dashboard.WithWeatherProvider(&fakeProvider{weather: weather}))
+		if err != nil {
+			t.Fatalf("NewDashboard failed: %v", err)
+		}
+		img, err := d.Render("Header", "Body")
+		if err != nil {
+			t.Fatalf("Render failed: %v", err)
+		}
+		if hasWhite(img, marker) != stale {
+			t.Errorf("Expected the stale marker to be drawn=%t", stale)
+		}
+	}
+}
+
+func TestWeatherCache_Unavailable(t *testing.T) {
+	// With nothing saved yet, a failing provider still renders the dashboard,
+	// with the weather marked as unavailable
+	p := &fakeProvider{err: errors.New("network is unreachable")}
+	cache := dashboard.NewWeatherCache(p, filepath.Join(t.TempDir(), "weather.json"), "60601", time.Hour)
+	d, err := dashboard.NewDashboard(dashboard.WithWeatherProvider(cache))
+	if err != nil {
+		t.Fatalf("NewDashboard failed: %v", err)
+	}
+	img, err := d.Render("Header", "Body")
+	if err != nil {
+		t.Fatalf("Expected the dashboard to render without weather, got %v", err)
+	}
+	if !hasWhite(img, image.Rect(150, 458, 250, 478)) {
+		t.Error("Expected the unavailable marker to be drawn")
+	}
+	if hasWhite(img, image.Rect(32, 248, 96, 312)) {
+		t.Error("Expected no weather icon")
+	}
+}
@@ -0,0 +1,100 @@
+package dashboard_test
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"testing"
+
+	"github.com/justmiles/epd/lib/dashboard"
+)
+
+// boxWidget fills its region with black, or fails to fetch when told to.
+type boxWidget struct {
+	Fail bool `yaml:"fail"`
+}
+
+func (w *boxWidget) Configure(config dashboard.Config) error {
+	return config.Decode(w)
+}
+
+func (w *boxWidget) Fetch(ctx context.Context, env *dashboard.Env) error {
+	if w.Fail {
+		return errors.New("no data")
+	}
+	return nil
+}
+
+func (w *boxWidget) Render(width, height int) (image.Image, error) {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+	return img, nil
+}
+
+func init() {
+	dashboard.Register("test-box", func() dashboard.Widget { return &boxWidget{} })
+}
+
+func TestRegister_CustomWidget(t *testing.T) {
+	l, err := dashboard.ParseLayout([]byte(`
+width: 200
+height: 100
+root:
+  direction: row
+  children:
+    - widget: test-box
+      size: 50
+    - widget: body
+`))
+	if err != nil {
+		t.Fatalf("ParseLayout failed: %v", err)
+	}
+
+	img := render(t, l)
+	if !isBlack(img, 5, 95) || !isBlack(img, 49, 5) {
+		t.Error("Expected the custom widget to fill the left 50 pixels")
+	}
+	if isBlack(img, 150, 95) {
+		t.Error("Expected the body to be white")
+	}
+}
+
+func TestWidgets(t *testing.T) {
+	names := strings.Join(dashboard.Widgets(), ",")
+	for _, name := range []string{"body", "calendar", "header", "test-box", "weather"} {
+		if !strings.Contains(names, name) {
+			t.Errorf("Expected %s among the widgets, got %s", name, names)
+		}
+	}
+}
+
+func TestRegister_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected registering a widget twice to panic")
+		}
+	}()
+	dashboard.Register("test-box", func() dashboard.Widget { return &boxWidget{} })
+}
+
+func TestWidget_Errors(t *testing.T) {
+	_, err := dashboard.ParseLayout([]byte("root: {widget: test-box, config: {fail: maybe}}"))
+	if err == nil || !strings.Contains(err.Error(), "invalid test-box config") {
+		t.Errorf("Expected a config error, got %v", err)
+	}
+
+	l, err := dashboard.ParseLayout([]byte("root: {widget: test-box, config: {fail: true}}"))
+	if err != nil {
+		t.Fatalf("ParseLayout failed: %v", err)
+	}
+	d, err := dashboard.NewDashboard(dashboard.WithLayout(l))
+	if err != nil {
+		t.Fatalf("NewDashboard failed: %v", err)
+	}
+	if _, err := d.Render("Header", "Body"); err == nil || !strings.Contains(err.Error(), "no data") {
+		t.Errorf("Expected the fetch error, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package dashboard
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
@@ -9,13 +10,11 @@ import (
 	"net/url"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/fogleman/gg"
-	"github.com/golang/freetype/truetype"
+	"github.com/justmiles/epd/lib/fonts"
 	"github.com/srwiley/oksvg"
 	"github.com/srwiley/rasterx"
-	"golang.org/x/image/font/gofont/goregular"
 )
 
 func convertSVGToImage(stream io.Reader, width, height float64) image.Image {
@@ -38,62 +37,6 @@ func convertSVGToImage(stream io.Reader, width, height float64) image.Image {
 	return img
 }
 
-// buildCalendarWidget x pixels wide and y pixels tall
-func buildCalendarWidget(x, y int, location string) (image.Image, error) {
-
-	//init the loc
-	var loc *time.Location
-	if location != "" {
-		var err error
-		loc, err = time.LoadLocation(location)
-		if err != nil {
-			return nil, fmt.Errorf("Invalid location: %s", err)
-		}
-	} else {
-		loc = time.Now().Location()
-	}
-
-	var (
-		xWidth, xHeight          = float64(x), float64(y)
-		fontSize, widgetLocation float64
-		now                      = time.Now().In(loc)
-	)
-
-	// Draw background
-	dc := gg.NewContext(x, y)
-	dc.DrawRectangle(0, 0, xWidth, xHeight)
-	dc.SetRGB(0, 0, 0)
-	dc.Fill()
-
-	// Set font color
-	dc.SetRGB(1, 1, 1)
-
-	// Draw day of the week
-	dow := now.Format("Monday")
-	fontSize = setDynamicFont(dc, xWidth-(xWidth*.1), xHeight*.2, dow)
-	widgetLocation = fontSize/2 + 10
-	// fmt.Println(widgetLocation)
-	dc.DrawStringAnchored(dow, xWidth/2, widgetLocation, 0.5, 0.5)
-
-	// Draw day of month
-	domText := now.Format("02")
-	fontSize = setDynamicFont(dc, xWidth-(xWidth*.1), xHeight*.5, domText)
-	widgetLocation = (widgetLocation) + fontSize/2 + 10
-	// fmt.Println(widgetLocation)
-
-	dc.DrawStringAnchored(domText, xWidth/2, widgetLocation, 0.5, 0.5)
-
-	// Draw month, year
-	ymText := now.Format("January 2006")
-	fontSize = setDynamicFont(dc, xWidth-(xWidth*.1), xHeight*.3, ymText)
-	widgetLocation = (widgetLocation * 1.75) + fontSize/2
-	// fmt.Println(widgetLocation)
-
-	dc.DrawStringAnchored(ymText, xWidth/2, widgetLocation, 0.5, 0.5)
-
-	return dc.Image(), nil
-}
-
 func fitTextInBox(dc *gg.Context, text string, xx, yy float64, x, y int) error {
 	var (
 		// maxWidth, maxHeight           float64 = float64(x), float64(y)
@@ -104,7 +47,7 @@ func fitTextInBox(dc *gg.Context, text string, xx, yy float64, x, y int) error {
 		// measuredWidth, measuredHeight float64
 	)
 
-	setFont(dc, fontSize)
+	setFont(dc, defaultFont(), fontSize)
 
 	// // Fit the text in the box!
 	// for {
@@ -157,7 +100,7 @@ func drawTextInBox(text string, x, y int) (image.Image, error) {
 
 	// Fit the text in the box!
 	for {
-		setFont(dc, fontSize)
+		setFont(dc, defaultFont(), fontSize)
 
 		stringLines := dc.WordWrap(text, maxWidth)
 
@@ -180,19 +123,71 @@ func drawTextInBox(text string, x, y int) (image.Image, error) {
 	return dc.Image(), nil
 }
 
-func setFont(dc *gg.Context, fontSize float64) {
-	font, _ := truetype.Parse(goregular.TTF)
-	face := truetype.NewFace(font, &truetype.Options{
-		Size: fontSize,
-	})
-	dc.SetFontFace(face)
+// fontConfig is the font a widget draws text with, set in its config by
+//
+//	font: go-mono       # a built-in family, or the path of a .ttf or .otf file
+//	font-weight: bold   # regular, medium or bold, for built-in families
+//	font-style: italic  # normal or italic, for built-in families
+type fontConfig struct {
+	Family string `yaml:"font"`
+	Weight string `yaml:"font-weight"`
+	Style  string `yaml:"font-style"`
+
+	// regular draws text, and bold headings
+	regular, bold *fonts.Font
+}
+
+// load loads the font, and the family's bold weight for headings. Files and
+// families without a bold weight use the font for headings too.
+func (c *fontConfig) load() (err error) {
+	if !fonts.IsBuiltin(c.Family) && (c.Weight != "" || c.Style != "") {
+		return errors.New("font-weight and font-style only apply to built-in fonts, " + strings.Join(fonts.Families(), ", "))
+	}
+	if c.regular, err = fonts.Load(c.Family, c.Weight, c.Style); err != nil {
+		return err
+	}
+	if c.bold, err = fonts.Load(c.Family, fonts.Bold, c.Style); err != nil || !fonts.IsBuiltin(c.Family) {
+		c.bold = c.regular
+	}
+	return nil
+}
+
+// defaultFont is the regular Go font, for text without a configured font
+func defaultFont() *fonts.Font {
+	// The built-in fonts always load
+	f, _ := fonts.Load(fonts.DefaultFamily, fonts.Regular, fonts.Normal)
+	return f
+}
+
+// setFont sets f, size points tall, as the font on dc
+func setFont(dc *gg.Context, f *fonts.Font, fontSize float64) {
+	dc.SetFontFace(f.Face(fontSize))
+}
+
+// truncateText shortens s with an ellipsis until it fits in maxWidth with the
+// current font
+func truncateText(dc *gg.Context, s string, maxWidth float64) string {
+	if w, _ := dc.MeasureString(s); w <= maxWidth {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		t := strings.TrimRight(string(runes), " ") + "…"
+		if w, _ := dc.MeasureString(t); w <= maxWidth {
+			return t
+		}
+	}
+	return ""
 }
 
-func setDynamicFont(dc *gg.Context, maxWidth, maxHeight float64, s string) float64 {
+// setDynamicFont sets f on dc at the largest size s fits in maxWidth by
+// maxHeight, and returns the size
+func setDynamicFont(dc *gg.Context, f *fonts.Font, maxWidth, maxHeight float64, s string) float64 {
 	fontSize := maxHeight
 
 	for {
-		setFont(dc, fontSize)
+		setFont(dc, f, fontSize)
 		width, height := dc.MeasureMultilineString(s, 0)
 		if width < maxWidth && height < maxHeight {
 			break
@@ -0,0 +1,201 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fogleman/gg"
+	"github.com/justmiles/epd/lib/fonts"
+	"github.com/justmiles/epd/lib/locale"
+)
+
+// Weather is the current weather and the forecast, as reported by a
+// WeatherProvider.
+type Weather struct {
+	// Time is when the conditions were observed.
+	Time time.Time `json:"time"`
+
+	// Conditions is a short summary, e.g. "Clouds", and Description a longer
+	// one, e.g. "broken clouds", both in the provider's language.
+	Conditions  string `json:"conditions"`
+	Description string `json:"description"`
+
+	// Icon is an OpenWeatherMap icon code, e.g. "04d", drawn with the
+	// matching icon from icons.go.
+	Icon string `json:"icon"`
+
+	// TempUnit is the unit of the temperatures, "C", "F" or "K".
+	TempUnit  string  `json:"temp_unit"`
+	Temp      float64 `json:"temp"`
+	FeelsLike float64 `json:"feels_like"`
+
+	// Humidity is the relative humidity in percent.
+	Humidity int `json:"humidity"`
+
+	// WindSpeed is in miles per hour for "F" and meters per second otherwise,
+	// and WindDirection in degrees clockwise from north.
+	WindSpeed     float64 `json:"wind_speed"`
+	WindDirection float64 `json:"wind_direction"`
+
+	// Forecast is the weather expected over the coming days, earliest first.
+	Forecast []Forecast `json:"forecast"`
+
+	// StaleSince is set, when the weather could not be refreshed, to when
+	// this weather was fetched.
+	StaleSince time.Time `json:"stale_since,omitempty"`
+}
+
+// Forecast is the weather expected over a period of a few hours.
+type Forecast struct {
+	// Time is when the period starts.
+	Time time.Time `json:"time"`
+
+	Conditions  string `json:"conditions"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+
+	Temp    float64 `json:"temp"`
+	TempMin float64 `json:"temp_min"`
+	TempMax float64 `json:"temp_max"`
+
+	// Precipitation is the expected rain and snow in millimeters, and
+	// PrecipitationChance the probability of any, from 0 to 1.
+	Precipitation       float64 `json:"precipitation"`
+	PrecipitationChance float64 `json:"precipitation_chance"`
+}
+
+// WeatherProvider looks up the weather shown on the dashboard.
+type WeatherProvider interface {
+	Weather(ctx context.Context) (*Weather, error)
+}
+
+// WithWeatherProvider creates a custom dashboard showing the weather from p,
+// instead of the Weather API configured by WithWeatherAPI
+func WithWeatherProvider(p WeatherProvider) Options {
+	return func(d *Dashboard) {
+		d.weather = p
+	}
+}
+
+// WeatherFile is a WeatherProvider reading the weather from a JSON file in the
+// form of Weather, e.g. to design layouts or test without an API key.
+type WeatherFile string
+
+// Weather reads the file every time, so it can be edited between renders.
+func (f WeatherFile) Weather(ctx context.Context) (*Weather, error) {
+	data, err := os.ReadFile(string(f))
+	if err != nil {
+		return nil, fmt.Errorf("could not read weather: %w", err)
+	}
+	var w Weather
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, fmt.Errorf("invalid weather file %s: %w", f, err)
+	}
+	return &w, nil
+}
+
+func init() {
+	Register("weather", func() Widget { return &weatherWidget{} })
+}
+
+// weatherWidget shows the current conditions, with an icon and the
+// temperature, when the dashboard has a weather provider.
+type weatherWidget struct {
+	fontConfig `yaml:",inline"`
+
+	weather     *Weather
+	unavailable bool
+	location    *time.Location
+	locale      *locale.Locale
+}
+
+func (w *weatherWidget) Configure(config Config) error {
+	if err := config.Decode(w); err != nil {
+		return err
+	}
+	return w.load()
+}
+
+func (w *weatherWidget) Fetch(ctx context.Context, env *Env) (err error) {
+	w.weather, err = env.Weather(ctx)
+	w.location, w.locale = env.Location, env.Locale
+	if errors.Is(err, ErrWeatherUnavailable) {
+		w.unavailable = true
+		return nil
+	}
+	return err
+}
+
+// Render draws the weather x pixels wide and y pixels tall
+func (w *weatherWidget) Render(x, y int) (image.Image, error) {
+	if w.weather == nil && !w.unavailable {
+		return nil, nil
+	}
+
+	var (
+		xWidth, xHeight = float64(x), float64(y)
+	)
+
+	// Draw background
+	dc := gg.NewContext(x, y)
+	dc.DrawRectangle(0, 0, xWidth, xHeight)
+	dc.SetRGB(0, 0, 0)
+	dc.Fill()
+
+	// Draw HR
+	dc.DrawRectangle(0+10, 0, xWidth-20, 2)
+	dc.SetRGB(1, 1, 1)
+	dc.Fill()
+
+	if w.unavailable {
+		drawWeatherMarker(dc, w.regular, w.locale.Translate("weather unavailable"), xWidth, xHeight)
+		return dc.Image(), nil
+	}
+
+	img := convertSVGToImage(getIcon(w.weather.Icon), 64, 64)
+	dc.DrawImageAnchored(img, 32, 32, 0, 0)
+
+	// Draw weather description
+	dc.SetRGB(1, 1, 1)
+	setDynamicFont(dc, w.regular, 128, 32, w.weather.Conditions)
+	dc.DrawStringAnchored(w.weather.Conditions, 170, 32, 0.5, 0.5)
+
+	// Draw temp
+	temp := formatTemp(w.locale, w.weather.Temp)
+	setFont(dc, w.regular, 32)
+	dc.DrawStringAnchored(temp, 170, 74, 0.5, 0.5)
+
+	drawStaleMarker(dc, w.regular, w.weather, w.location, w.locale, xWidth, xHeight)
+
+	return dc.Image(), nil
+}
+
+// staleMarkerHeight is the height of the band at the bottom of a weather
+// widget marking stale weather, for a widget y pixels tall.
+func staleMarkerHeight(y float64) float64 {
+	return min(max(y*0.08, 12), 18)
+}
+
+// drawStaleMarker notes when weather that could not be refreshed is from, in
+// white in f along the bottom right of the x by y context.
+func drawStaleMarker(dc *gg.Context, f *fonts.Font, w *Weather, loc *time.Location, l *locale.Locale, x, y float64) {
+	if w.StaleSince.IsZero() {
+		return
+	}
+	marker := strings.ReplaceAll(l.Translate("stale since {time}"), "{time}", l.Time(w.StaleSince.In(loc)))
+	drawWeatherMarker(dc, f, marker, x, y)
+}
+
+// drawWeatherMarker draws marker in white in f along the bottom right of the x
+// by y context.
+func drawWeatherMarker(dc *gg.Context, f *fonts.Font, marker string, x, y float64) {
+	setFont(dc, f, staleMarkerHeight(y)*0.8)
+	dc.SetRGB(1, 1, 1)
+	dc.DrawStringAnchored(marker, x-4, y-staleMarkerHeight(y)/2, 1, 0.35)
+}
@@ -0,0 +1,117 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrWeatherUnavailable is returned, wrapping the provider's error, when the
+// weather can't be fetched and there is none saved to fall back on. Widgets
+// show the weather as unavailable rather than failing the dashboard.
+var ErrWeatherUnavailable = errors.New("weather unavailable")
+
+// WeatherCache is a WeatherProvider that saves another provider's weather to a
+// file, asking the provider again only once the saved weather is older than
+// the TTL. When the provider fails, it falls back to the saved weather, with
+// StaleSince set to when it was fetched.
+type WeatherCache struct {
+	provider WeatherProvider
+	path     string
+	key      string
+	ttl      time.Duration
+
+	// mu keeps concurrent renders from fetching and saving at the same time
+	mu sync.Mutex
+}
+
+// weatherCacheEntry is the content of a WeatherCache file.
+type weatherCacheEntry struct {
+	Key     string    `json:"key"`
+	Fetched time.Time `json:"fetched"`
+	Weather *Weather  `json:"weather"`
+}
+
+// NewWeatherCache caches p's weather in the file at path for ttl. The key
+// names what p looks up, such as the zip code and units, so weather saved
+// for another key is ignored.
+func NewWeatherCache(p WeatherProvider, path, key string, ttl time.Duration) *WeatherCache {
+	return &WeatherCache{provider: p, path: path, key: key, ttl: ttl}
+}
+
+// Weather returns the saved weather while it is fresh, and otherwise asks the
+// provider, falling back to the saved weather if that fails.
+func (c *WeatherCache) Weather(ctx context.Context) (*Weather, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, err := c.load()
+	if err != nil {
+		log.Printf("Ignoring weather cache: %v", err)
+	}
+	if cached != nil && time.Since(cached.Fetched) < c.ttl {
+		return cached.Weather, nil
+	}
+
+	w, err := c.provider.Weather(ctx)
+	if err != nil {
+		if cached == nil {
+			log.Printf("Weather unavailable: %v", err)
+			return nil, fmt.Errorf("%w: %w", ErrWeatherUnavailable, err)
+		}
+		log.Printf("Showing weather from %s: %v", cached.Fetched.Format(time.DateTime), err)
+		stale := *cached.Weather
+		stale.StaleSince = cached.Fetched
+		return &stale, nil
+	}
+
+	if err := c.save(w); err != nil {
+		log.Printf("Could not cache weather: %v", err)
+	}
+	return w, nil
+}
+
+// load reads the saved weather, returning nil if there is none for the key.
+func (c *WeatherCache) load() (*weatherCacheEntry, error) {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry weatherCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("invalid weather cache %s: %w", c.path, err)
+	}
+	if entry.Key != c.key || entry.Weather == nil {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+// save writes the weather to a temporary file first so a crash never leaves a
+// partial file.
+func (c *WeatherCache) save(w *Weather) error {
+	data, err := json.Marshal(weatherCacheEntry{Key: c.key, Fetched: time.Now(), Weather: w})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
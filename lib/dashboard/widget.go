@@ -0,0 +1,137 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/justmiles/epd/lib/locale"
+)
+
+// Widget draws one region of a dashboard. Each region gets its own widget,
+// which is configured from the layout, fetches its data and then renders:
+//
+//	w.Configure(config)
+//	w.Fetch(ctx, env)
+//	img, err := w.Render(width, height)
+type Widget interface {
+	// Configure reads the widget's settings from its region's config. It is
+	// also called when a layout is parsed, to report mistakes early.
+	Configure(config Config) error
+
+	// Fetch gathers what the widget shows, such as the weather, before it is
+	// rendered. An error fails the whole dashboard.
+	Fetch(ctx context.Context, env *Env) error
+
+	// Render draws the widget width pixels wide and height pixels tall, or
+	// returns a nil image to leave the region blank.
+	Render(width, height int) (image.Image, error)
+}
+
+// Config is a region's widget configuration.
+type Config interface {
+	// Decode decodes the configuration into v, typically a pointer to a
+	// struct with yaml tags, leaving v untouched when there is none.
+	Decode(v any) error
+}
+
+// Env is what widgets know about the dashboard being rendered.
+type Env struct {
+	// HeaderText and BodyText are the dashboard's header and body, as passed
+	// to Render.
+	HeaderText string
+	BodyText   string
+
+	// Now is when the dashboard is rendered, in Location, the time zone for
+	// dates and times.
+	Now      time.Time
+	Location *time.Location
+
+	// Locale names days and months, orders dates and formats numbers.
+	Locale *locale.Locale
+
+	weatherProvider WeatherProvider
+	weatherOnce     sync.Once
+	weather         *Weather
+	weatherErr      error
+}
+
+// Weather returns the weather from the dashboard's provider, or nil without
+// one. The provider is asked once per render, however many widgets show the
+// weather.
+func (e *Env) Weather(ctx context.Context) (*Weather, error) {
+	if e.weatherProvider == nil {
+		return nil, nil
+	}
+	e.weatherOnce.Do(func() {
+		e.weather, e.weatherErr = e.weatherProvider.Weather(ctx)
+	})
+	return e.weather, e.weatherErr
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]func() Widget{}
+)
+
+// Register makes a widget available to layouts under name, creating one with
+// newWidget for every region that uses it. Call it from an init function; it
+// panics if name is already registered.
+func Register(name string, newWidget func() Widget) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if newWidget == nil {
+		panic("dashboard: Register widget is nil")
+	}
+	if _, ok := registry[name]; ok {
+		panic("dashboard: Register called twice for widget " + name)
+	}
+	registry[name] = newWidget
+}
+
+// Widgets returns the names of the registered widgets, sorted.
+func Widgets() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newWidget creates and configures the widget bound to r.
+func newWidget(r *Region) (Widget, error) {
+	registryMu.RLock()
+	factory, ok := registry[r.Widget]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown widget %q, expected one of %s", r.Widget, strings.Join(Widgets(), ", "))
+	}
+
+	w := factory()
+	if err := w.Configure(regionConfig{r}); err != nil {
+		return nil, fmt.Errorf("invalid %s config: %w", r.Widget, err)
+	}
+	return w, nil
+}
+
+// regionConfig is the Config of a region's widget.
+type regionConfig struct {
+	r *Region
+}
+
+func (c regionConfig) Decode(v any) error {
+	// A missing config would otherwise decode as null, zeroing v
+	if c.r.Config.Kind == 0 {
+		return nil
+	}
+	return c.r.Config.Decode(v)
+}
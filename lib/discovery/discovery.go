@@ -0,0 +1,398 @@
+// Package discovery advertises EPD daemons on the local network with mDNS and
+// DNS-SD (RFC 6762 and RFC 6763), and finds them again.
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/net/ipv4"
+)
+
+// ServiceType is the DNS-SD service type EPD daemons advertise.
+const ServiceType = "_epd._tcp"
+
+const (
+	// ttl is how long, in seconds, other hosts may cache our records.
+	ttl = 120
+
+	// queryInterval is how often browsing repeats its query.
+	queryInterval = time.Second
+)
+
+var (
+	// serviceName is the DNS-SD name browsers query to enumerate daemons.
+	serviceName = ServiceType + ".local."
+
+	// group is the mDNS IPv4 multicast group.
+	group = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+)
+
+// Service describes an EPD daemon on the network.
+type Service struct {
+	// Instance is the daemon's name, unique on the network, e.g. "lobby".
+	Instance string
+
+	// Host is the daemon's mDNS host name without the ".local." suffix, and
+	// IPs its addresses.
+	Host string
+	IPs  []net.IP
+
+	// Port is the daemon's gRPC port.
+	Port int
+
+	// Model, Width, Height and Version describe the panel and the daemon,
+	// published as TXT records.
+	Model         string
+	Width, Height int
+	Version       string
+}
+
+// Addr returns the daemon's gRPC address as host:port, preferring an IP
+// address to the mDNS host name.
+func (s Service) Addr() string {
+	host := s.Host + ".local"
+	if len(s.IPs) > 0 {
+		host = s.IPs[0].String()
+	}
+	return net.JoinHostPort(host, strconv.Itoa(s.Port))
+}
+
+// Conn is a connection to the mDNS multicast group: every message sent is
+// delivered to every member. Multicast returns the real group; tests can
+// substitute an in-memory stand-in.
+type Conn interface {
+	Send(msg []byte) error
+	Receive(buf []byte) (int, error)
+	Close() error
+}
+
+// udpConn is a Conn on the IPv4 mDNS multicast group.
+type udpConn struct {
+	*net.UDPConn
+}
+
+// Multicast joins the mDNS multicast group on the default interface.
+func Multicast() (Conn, error) {
+	conn, err := net.ListenMulticastUDP("udp4", nil, group)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join mDNS group: %w", err)
+	}
+	// ListenMulticastUDP disables loopback, which would hide a daemon from
+	// browsers on the same host.
+	if err := ipv4.NewPacketConn(conn).SetMulticastLoopback(true); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to enable multicast loopback: %w", err)
+	}
+	return udpConn{conn}, nil
+}
+
+func (c udpConn) Send(msg []byte) error {
+	_, err := c.WriteToUDP(msg, group)
+	return err
+}
+
+func (c udpConn) Receive(buf []byte) (int, error) {
+	n, _, err := c.ReadFromUDP(buf)
+	return n, err
+}
+
+// Advertise announces svc on conn and answers queries for it until ctx is
+// cancelled, when it withdraws the announcement and closes conn.
+func Advertise(ctx context.Context, conn Conn, svc Service) error {
+	svc.Instance = instanceLabel(svc.Instance)
+	instance := instanceName(svc.Instance)
+	host := svc.Host + ".local."
+
+	announce := func(ttl uint32) error {
+		msg, err := response(svc, ttl)
+		if err != nil {
+			return err
+		}
+		return conn.Send(msg)
+	}
+	if err := announce(ttl); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to announce %s: %w", instance, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		announce(0)
+		conn.Close()
+	}()
+
+	buf := make([]byte, 9000)
+	for {
+		n, err := conn.Receive(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to read mDNS query: %w", err)
+		}
+
+		var query dnsmessage.Message
+		if err := query.Unpack(buf[:n]); err != nil || query.Response {
+			continue
+		}
+		for _, q := range query.Questions {
+			name := strings.ToLower(q.Name.String())
+			if name == serviceName || name == strings.ToLower(instance) || name == strings.ToLower(host) {
+				if err := announce(ttl); err != nil && ctx.Err() == nil {
+					return fmt.Errorf("failed to answer mDNS query: %w", err)
+				}
+				break
+			}
+		}
+	}
+}
+
+// Browse queries conn for EPD daemons and returns those that answer before ctx
+// is done. It closes conn when it returns.
+func Browse(ctx context.Context, conn Conn) ([]Service, error) {
+	found := map[string]*Service{}
+	err := browse(ctx, conn, func(svc *Service) bool {
+		found[svc.Instance] = svc
+		return false
+	}, func(instance string) {
+		delete(found, instance)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]Service, 0, len(found))
+	for _, svc := range found {
+		services = append(services, *svc)
+	}
+	return services, nil
+}
+
+// Lookup queries conn for the EPD daemon named instance, returning as soon as
+// it answers. It closes conn when it returns.
+func Lookup(ctx context.Context, conn Conn, instance string) (Service, error) {
+	var found *Service
+	err := browse(ctx, conn, func(svc *Service) bool {
+		if strings.EqualFold(svc.Instance, instanceLabel(instance)) {
+			found = svc
+		}
+		return found != nil
+	}, func(string) {})
+	if err != nil {
+		return Service{}, err
+	}
+	if found == nil {
+		return Service{}, fmt.Errorf("no EPD daemon named %q found", instance)
+	}
+	return *found, nil
+}
+
+// browse queries every second, in case a query or answer is lost, and passes
+// each complete service that answers to add until add returns true or ctx is
+// done. Services withdrawn with a zero TTL are passed to remove.
+func browse(ctx context.Context, conn Conn, add func(*Service) bool, remove func(string)) error {
+	if err := conn.Send(query()); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to send mDNS query: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		ticker := time.NewTicker(queryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				conn.Send(query())
+			case <-ctx.Done():
+				conn.Close()
+				return
+			}
+		}
+	}()
+
+	buf := make([]byte, 9000)
+	for {
+		n, err := conn.Receive(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to read mDNS response: %w", err)
+		}
+
+		var msg dnsmessage.Message
+		if err := msg.Unpack(buf[:n]); err != nil || !msg.Response {
+			continue
+		}
+		svc, withdrawn := parseResponse(msg)
+		switch {
+		case svc == nil:
+		case withdrawn:
+			remove(svc.Instance)
+		case add(svc):
+			return nil
+		}
+	}
+}
+
+// query returns a DNS-SD query for every EPD daemon.
+func query() []byte {
+	msg := dnsmessage.Message{
+		Questions: []dnsmessage.Question{{
+			Name:  dnsmessage.MustNewName(serviceName),
+			Type:  dnsmessage.TypePTR,
+			Class: dnsmessage.ClassINET,
+		}},
+	}
+	b, _ := msg.Pack()
+	return b
+}
+
+// response returns the PTR, SRV, TXT and A records describing svc, with the
+// given TTL; zero withdraws them.
+func response(svc Service, ttl uint32) ([]byte, error) {
+	instance, err := dnsmessage.NewName(instanceName(svc.Instance))
+	if err != nil {
+		return nil, fmt.Errorf("invalid instance name %q: %w", svc.Instance, err)
+	}
+	host, err := dnsmessage.NewName(svc.Host + ".local.")
+	if err != nil {
+		return nil, fmt.Errorf("invalid host name %q: %w", svc.Host, err)
+	}
+	header := func(name dnsmessage.Name, typ dnsmessage.Type) dnsmessage.ResourceHeader {
+		return dnsmessage.ResourceHeader{Name: name, Type: typ, Class: dnsmessage.ClassINET, TTL: ttl}
+	}
+
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{Response: true, Authoritative: true},
+		Answers: []dnsmessage.Resource{{
+			Header: header(dnsmessage.MustNewName(serviceName), dnsmessage.TypePTR),
+			Body:   &dnsmessage.PTRResource{PTR: instance},
+		}},
+		Additionals: []dnsmessage.Resource{
+			{
+				Header: header(instance, dnsmessage.TypeSRV),
+				Body:   &dnsmessage.SRVResource{Target: host, Port: uint16(svc.Port)},
+			},
+			{
+				Header: header(instance, dnsmessage.TypeTXT),
+				Body: &dnsmessage.TXTResource{TXT: []string{
+					"model=" + svc.Model,
+					fmt.Sprintf("resolution=%dx%d", svc.Width, svc.Height),
+					"version=" + svc.Version,
+				}},
+			},
+		},
+	}
+	for _, ip := range svc.IPs {
+		if ip4 := ip.To4(); ip4 != nil {
+			msg.Additionals = append(msg.Additionals, dnsmessage.Resource{
+				Header: header(host, dnsmessage.TypeA),
+				Body:   &dnsmessage.AResource{A: [4]byte(ip4)},
+			})
+		}
+	}
+	return msg.Pack()
+}
+
+// parseResponse extracts the EPD daemon a response describes, if any, and
+// whether it is being withdrawn.
+func parseResponse(msg dnsmessage.Message) (svc *Service, withdrawn bool) {
+	records := append(msg.Answers, msg.Additionals...)
+
+	var instance string
+	for _, r := range records {
+		if ptr, ok := r.Body.(*dnsmessage.PTRResource); ok && strings.EqualFold(r.Header.Name.String(), serviceName) {
+			instance = ptr.PTR.String()
+			withdrawn = r.Header.TTL == 0
+		}
+	}
+	if instance == "" {
+		return nil, false
+	}
+
+	svc = &Service{Instance: strings.TrimSuffix(instance, "."+serviceName)}
+	var host string
+	for _, r := range records {
+		if !strings.EqualFold(r.Header.Name.String(), instance) {
+			continue
+		}
+		switch body := r.Body.(type) {
+		case *dnsmessage.SRVResource:
+			host = body.Target.String()
+			svc.Host = strings.TrimSuffix(host, ".local.")
+			svc.Port = int(body.Port)
+		case *dnsmessage.TXTResource:
+			parseTXT(svc, body.TXT)
+		}
+	}
+	if host == "" {
+		return nil, false
+	}
+
+	for _, r := range records {
+		if a, ok := r.Body.(*dnsmessage.AResource); ok && strings.EqualFold(r.Header.Name.String(), host) {
+			svc.IPs = append(svc.IPs, net.IP(a.A[:]))
+		}
+	}
+	return svc, withdrawn
+}
+
+// parseTXT fills in the service fields published as TXT records.
+func parseTXT(svc *Service, txt []string) {
+	for _, kv := range txt {
+		key, value, _ := strings.Cut(kv, "=")
+		switch key {
+		case "model":
+			svc.Model = value
+		case "resolution":
+			fmt.Sscanf(value, "%dx%d", &svc.Width, &svc.Height)
+		case "version":
+			svc.Version = value
+		}
+	}
+}
+
+// instanceName returns the full DNS-SD name of an instance.
+func instanceName(instance string) string {
+	return instance + "." + serviceName
+}
+
+// instanceLabel makes instance usable as a single DNS label.
+func instanceLabel(instance string) string {
+	return strings.ReplaceAll(instance, ".", "-")
+}
+
+// LocalService describes the daemon on this host: its host name, used as the
+// instance name unless one is given, and its IPv4 addresses.
+func LocalService(instance string, port int) (Service, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return Service{}, fmt.Errorf("failed to get host name: %w", err)
+	}
+	hostname, _, _ = strings.Cut(hostname, ".")
+	if instance == "" {
+		instance = hostname
+	}
+
+	svc := Service{Instance: instance, Host: hostname, Port: port}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return Service{}, fmt.Errorf("failed to list addresses: %w", err)
+	}
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLoopback() && ipnet.IP.To4() != nil {
+			svc.IPs = append(svc.IPs, ipnet.IP.To4())
+		}
+	}
+	return svc, nil
+}
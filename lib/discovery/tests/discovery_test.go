@@ -0,0 +1,169 @@
+package discovery_test
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/discovery"
+)
+
+// bus is a loopback stand-in for the mDNS multicast group: every message sent
+// by a member is delivered to every member, including the sender.
+type bus struct {
+	mu      sync.Mutex
+	members map[*member]bool
+}
+
+type member struct {
+	bus    *bus
+	inbox  chan []byte
+	closed chan struct{}
+	once   sync.Once
+}
+
+func (b *bus) join() *member {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.members == nil {
+		b.members = map[*member]bool{}
+	}
+	m := &member{bus: b, inbox: make(chan []byte, 64), closed: make(chan struct{})}
+	b.members[m] = true
+	return m
+}
+
+func (m *member) Send(msg []byte) error {
+	m.bus.mu.Lock()
+	defer m.bus.mu.Unlock()
+	for other := range m.bus.members {
+		select {
+		case other.inbox <- append([]byte(nil), msg...):
+		default: // dropped, as UDP would
+		}
+	}
+	return nil
+}
+
+func (m *member) Receive(buf []byte) (int, error) {
+	select {
+	case msg := <-m.inbox:
+		return copy(buf, msg), nil
+	case <-m.closed:
+		return 0, net.ErrClosed
+	}
+}
+
+func (m *member) Close() error {
+	m.once.Do(func() {
+		m.bus.mu.Lock()
+		delete(m.bus.members, m)
+		m.bus.mu.Unlock()
+		close(m.closed)
+	})
+	return nil
+}
+
+// advertise advertises svc on the bus until the test ends.
+func advertise(t *testing.T, b *bus, svc discovery.Service) context.CancelFunc {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	conn := b.join()
+	done := make(chan error, 1)
+	go func() { done <- discovery.Advertise(ctx, conn, svc) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Advertise failed: %v", err)
+		}
+	})
+	return cancel
+}
+
+func lobby() discovery.Service {
+	return discovery.Service{
+		Instance: "lobby",
+		Host:     "pi-lobby",
+		IPs:      []net.IP{net.IPv4(10, 0, 0, 12).To4()},
+		Port:     50051,
+		Model:    "epd7in5v2",
+		Width:    800,
+		Height:   480,
+		Version:  "1.4.0",
+	}
+}
+
+func TestBrowse(t *testing.T) {
+	var b bus
+	advertise(t, &b, lobby())
+	kitchen := lobby()
+	kitchen.Instance, kitchen.Host, kitchen.IPs = "kitchen", "pi-kitchen", nil
+	advertise(t, &b, kitchen)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	services, err := discovery.Browse(ctx, b.join())
+	if err != nil {
+		t.Fatalf("Browse failed: %v", err)
+	}
+
+	sort.Slice(services, func(i, j int) bool { return services[i].Instance < services[j].Instance })
+	if len(services) != 2 || services[0].Instance != "kitchen" || services[1].Instance != "lobby" {
+		t.Fatalf("Expected kitchen and lobby, got %+v", services)
+	}
+
+	got := services[1]
+	if got.Model != "epd7in5v2" || got.Width != 800 || got.Height != 480 || got.Version != "1.4.0" {
+		t.Errorf("Expected the TXT records to describe the panel, got %+v", got)
+	}
+	if got.Addr() != "10.0.0.12:50051" {
+		t.Errorf("Expected address 10.0.0.12:50051, got %s", got.Addr())
+	}
+	if addr := services[0].Addr(); addr != "pi-kitchen.local:50051" {
+		t.Errorf("Expected a daemon without addresses to be reached by host name, got %s", addr)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	var b bus
+	advertise(t, &b, lobby())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	svc, err := discovery.Lookup(ctx, b.join(), "Lobby")
+	if err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+	if svc.Addr() != "10.0.0.12:50051" {
+		t.Errorf("Expected address 10.0.0.12:50051, got %s", svc.Addr())
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := discovery.Lookup(ctx, b.join(), "attic"); err == nil {
+		t.Error("Expected looking up an unknown daemon to fail")
+	}
+}
+
+func TestAdvertise_Withdraws(t *testing.T) {
+	var b bus
+	stop := advertise(t, &b, lobby())
+
+	// A browser that saw the daemon forgets it when the daemon says goodbye.
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		stop()
+	}()
+	services, err := discovery.Browse(ctx, b.join())
+	if err != nil {
+		t.Fatalf("Browse failed: %v", err)
+	}
+	if len(services) != 0 {
+		t.Errorf("Expected the withdrawn daemon to be forgotten, got %+v", services)
+	}
+}
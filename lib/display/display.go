@@ -1,6 +1,21 @@
 package display
 
-import "strings"
+import (
+	"net"
+	"strings"
+	"time"
+)
+
+// SupportedDevices lists the EPD device types that can be driven locally.
+var SupportedDevices = []string{"epd7in5v2"}
+
+// UnixScheme prefixes a --device that names the daemon's Unix socket, as in
+// unix:///run/epd.sock.
+const UnixScheme = "unix:"
+
+// MDNSDomain suffixes a --device that names a daemon by the instance name it
+// advertises over mDNS, as in lobby.local.
+const MDNSDomain = ".local"
 
 // Service abstracts over local hardware and remote gRPC display operations.
 type Service interface {
@@ -16,11 +31,36 @@ type Service interface {
 	// Sleep puts the EPD into sleep mode.
 	Sleep() error
 
+	// CurrentFrame returns the image currently shown on the EPD as PNG data
+	// and the time it was displayed.
+	CurrentFrame() ([]byte, time.Time, error)
+
 	// Close releases any resources held by the display service.
 	Close() error
 }
 
-// IsRemote returns true if the device string looks like a remote host:port address.
+// IsRemote returns true if the device string names a daemon, as a host:port
+// address, a unix: socket or an instance name advertised over mDNS with the
+// MDNSDomain suffix, rather than a local device type. Any of these may name one
+// of the daemon's displays, as described by SplitDisplay. Anything else, such
+// as a misspelled device type, is local.
 func IsRemote(device string) bool {
-	return strings.Contains(device, ":")
+	if strings.HasPrefix(device, UnixScheme) {
+		return true
+	}
+	address, _ := SplitDisplay(device)
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return true
+	}
+	_, ok := InstanceName(address)
+	return ok
+}
+
+// InstanceName returns the mDNS instance name of an address with the
+// MDNSDomain suffix, lobby for lobby.local, and whether it has one.
+func InstanceName(address string) (string, bool) {
+	if len(address) <= len(MDNSDomain) || !strings.EqualFold(address[len(address)-len(MDNSDomain):], MDNSDomain) {
+		return "", false
+	}
+	return address[:len(address)-len(MDNSDomain)], true
 }
@@ -2,68 +2,193 @@ package display
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
 	"image/png"
 	"io"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"net/url"
 	"os"
-	"strings"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/disintegration/imaging"
-	"github.com/fogleman/gg"
-	"github.com/golang/freetype/truetype"
 	epd "github.com/justmiles/epd/lib/epd7in5v2"
-	"golang.org/x/image/font/gofont/goregular"
 )
 
+var (
+	// ErrNoFrame is returned by CurrentFrame when nothing has been displayed yet.
+	ErrNoFrame = errors.New("no frame has been displayed yet")
+
+	// ErrInvalidImage is returned when image data cannot be decoded.
+	ErrInvalidImage = errors.New("invalid image")
+)
+
+// Driver is the low-level panel interface implemented by device packages
+// such as epd7in5v2. Buffers are packed one bit per pixel, eight pixels per
+// byte, with set bits drawn black.
+type Driver interface {
+	HardwareInit() error
+	Display(buf []byte)
+	Clear()
+	Sleep()
+}
+
+// BusyReporter is implemented by drivers that can report how long they wait
+// for the panel to finish each operation.
+type BusyReporter interface {
+	SetBusyHook(fn func(wait time.Duration, timedOut bool))
+}
+
 // LocalDisplay implements Service for direct hardware access via SPI/GPIO.
 type LocalDisplay struct {
-	epd    *epd.EPD
+	epd    Driver
 	device string
+	width  int
+	height int
+
+	// The last frame sent to the panel, optionally persisted to framePath.
+	frameMu   sync.Mutex
+	frame     image.Image
+	frameTime time.Time
+	framePath string
+
+	textOpts TextOptions
+
+	busyMu    sync.Mutex
+	busyHooks []func(wait time.Duration, timedOut bool)
+}
+
+// LocalOption configures a LocalDisplay.
+type LocalOption func(l *LocalDisplay)
+
+// WithFrameFile persists the last displayed frame as a PNG at path, restoring
+// it on startup so CurrentFrame survives restarts.
+func WithFrameFile(path string) LocalOption {
+	return func(l *LocalDisplay) {
+		l.framePath = path
+	}
 }
 
-// NewLocalDisplay creates a new local display service for the given device.
-func NewLocalDisplay(device string) (*LocalDisplay, error) {
-	if device != "epd7in5v2" {
+// WithTextOptions sets the font DisplayText draws text in.
+func WithTextOptions(opts TextOptions) LocalOption {
+	return func(l *LocalDisplay) {
+		l.textOpts = opts
+	}
+}
+
+// Pins are the BCM GPIO numbers a panel is wired to. Panels sharing the SPI
+// bus use different chip selects: GPIO 8 (CE0) or GPIO 7 (CE1).
+type Pins struct {
+	Reset, DC, CS, Busy uint8
+}
+
+// DefaultPins are the pins of the Waveshare Raspberry Pi HAT.
+var DefaultPins = Pins{Reset: 17, DC: 25, CS: 8, Busy: 24}
+
+// NewLocalDisplay creates a new local display service for the given device
+// wired to the default pins.
+func NewLocalDisplay(device string, opts ...LocalOption) (*LocalDisplay, error) {
+	return NewLocalDisplayWithPins(device, DefaultPins, opts...)
+}
+
+// NewLocalDisplayWithPins creates a new local display service for the given
+// device wired to pins, for a panel that is not on the default HAT pins.
+func NewLocalDisplayWithPins(device string, pins Pins, opts ...LocalOption) (*LocalDisplay, error) {
+	if !slices.Contains(SupportedDevices, device) {
 		return nil, fmt.Errorf("device %s is not supported", device)
 	}
 
-	epdDevice, err := epd.NewRaspberryPiHat()
+	epdDevice, err := epd.New(pins.Reset, pins.DC, pins.CS, pins.Busy)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize EPD hardware: %w", err)
 	}
 
-	return &LocalDisplay{
-		epd:    epdDevice,
+	return NewLocalDisplayWithDriver(device, epdDevice, epdDevice.Width, epdDevice.Height, opts...)
+}
+
+// NewLocalDisplayWithDriver creates a local display service on top of an
+// already initialized driver with the given panel dimensions.
+func NewLocalDisplayWithDriver(device string, drv Driver, width, height int, opts ...LocalOption) (*LocalDisplay, error) {
+	l := &LocalDisplay{
+		epd:    drv,
 		device: device,
-	}, nil
+		width:  width,
+		height: height,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	if br, ok := drv.(BusyReporter); ok {
+		br.SetBusyHook(l.busyWait)
+	}
+
+	if err := l.loadFrame(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
 }
 
 // HardwareInit initializes (wakes) the display hardware.
-func (l *LocalDisplay) HardwareInit() {
-	l.epd.HardwareInit()
+func (l *LocalDisplay) HardwareInit() error {
+	return l.epd.HardwareInit()
+}
+
+// OnBusyWait registers fn to be called after every wait for the panel to
+// finish an operation. It is never called if the driver does not implement
+// BusyReporter.
+func (l *LocalDisplay) OnBusyWait(fn func(wait time.Duration, timedOut bool)) {
+	l.busyMu.Lock()
+	defer l.busyMu.Unlock()
+	l.busyHooks = append(l.busyHooks, fn)
+}
+
+// busyWait fans a driver's busy wait out to the registered hooks.
+func (l *LocalDisplay) busyWait(wait time.Duration, timedOut bool) {
+	l.busyMu.Lock()
+	hooks := l.busyHooks
+	l.busyMu.Unlock()
+
+	for _, fn := range hooks {
+		fn(wait, timedOut)
+	}
 }
 
 // DisplayImage accepts raw PNG data and displays it on the EPD.
 func (l *LocalDisplay) DisplayImage(pngData []byte) error {
-	img, err := png.Decode(bytes.NewReader(pngData))
+	return l.DisplayImageReader(bytes.NewReader(pngData), ImageOptions{})
+}
+
+// DisplayImageReader decodes an image (PNG, JPEG, GIF, ...) from r, renders it
+// according to opts and displays it on the EPD.
+func (l *LocalDisplay) DisplayImageReader(r io.Reader, opts ImageOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	img, err := imaging.Decode(r, imaging.AutoOrientation(true))
 	if err != nil {
-		return fmt.Errorf("failed to decode PNG: %w", err)
+		return fmt.Errorf("%w: %s", ErrInvalidImage, err)
 	}
 
-	processed := processImage(img, l.epd.Width, l.epd.Height)
-	buf := convertImage(processed, l.epd.Width, l.epd.Height)
+	frame := RenderImage(img, l.width, l.height, opts)
+	buf := convertImage(frame, l.width, l.height)
 	l.epd.Display(buf)
+	l.setFrame(bufferToImage(buf, l.width, l.height))
 	return nil
 }
 
 // DisplayImageFromFile reads an image from a file path or URL and displays it.
 func (l *LocalDisplay) DisplayImageFromFile(filePath string) error {
-	pngData, err := ReadImageFile(filePath, l.epd.Width, l.epd.Height)
+	pngData, err := ReadImageFile(filePath, l.width, l.height)
 	if err != nil {
 		return err
 	}
@@ -72,19 +197,21 @@ func (l *LocalDisplay) DisplayImageFromFile(filePath string) error {
 
 // DisplayText renders text and displays it on the EPD.
 func (l *LocalDisplay) DisplayText(text string) error {
-	img, err := renderText(text, l.epd.Width, l.epd.Height)
+	img, err := RenderText(text, l.width, l.height, l.textOpts)
 	if err != nil {
 		return err
 	}
 
-	buf := convertImage(img, l.epd.Width, l.epd.Height)
+	buf := convertImage(img, l.width, l.height)
 	l.epd.Display(buf)
+	l.setFrame(bufferToImage(buf, l.width, l.height))
 	return nil
 }
 
 // Clear clears the EPD to white.
 func (l *LocalDisplay) Clear() error {
 	l.epd.Clear()
+	l.setFrame(bufferToImage(nil, l.width, l.height))
 	return nil
 }
 
@@ -99,11 +226,111 @@ func (l *LocalDisplay) Close() error {
 	return nil
 }
 
-// EPD returns the underlying EPD device for direct access (e.g. HardwareInit).
-func (l *LocalDisplay) EPD() *epd.EPD {
+// CurrentFrame returns the last frame sent to the panel as PNG data, along with
+// the time it was displayed. It returns ErrNoFrame if nothing has been shown.
+func (l *LocalDisplay) CurrentFrame() ([]byte, time.Time, error) {
+	l.frameMu.Lock()
+	frame, updated := l.frame, l.frameTime
+	l.frameMu.Unlock()
+
+	if frame == nil {
+		return nil, time.Time{}, ErrNoFrame
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, frame); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to encode frame: %w", err)
+	}
+	return buf.Bytes(), updated, nil
+}
+
+// setFrame records img as the frame currently on the panel and persists it if
+// a frame file is configured. The panel has already been updated by then, so
+// failing to persist the frame is logged rather than failing the update.
+func (l *LocalDisplay) setFrame(img image.Image) {
+	l.frameMu.Lock()
+	defer l.frameMu.Unlock()
+
+	l.frame = img
+	l.frameTime = time.Now()
+
+	if l.framePath == "" {
+		return
+	}
+	if err := l.persistFrame(img); err != nil {
+		log.Printf("Failed to persist frame: %v", err)
+	}
+}
+
+// persistFrame writes img to the frame file.
+func (l *LocalDisplay) persistFrame(img image.Image) error {
+	// Write to a temporary file first so a crash never leaves a truncated PNG
+	tmp, err := os.CreateTemp(filepath.Dir(l.framePath), ".frame-*.png")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := png.Encode(tmp, img); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), l.framePath)
+}
+
+// loadFrame restores a previously persisted frame, if any.
+func (l *LocalDisplay) loadFrame() error {
+	if l.framePath == "" {
+		return nil
+	}
+
+	f, err := os.Open(l.framePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open frame file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat frame file: %w", err)
+	}
+
+	img, err := png.Decode(f)
+	if err != nil {
+		return fmt.Errorf("failed to decode frame file %s: %w", l.framePath, err)
+	}
+
+	l.frame = img
+	l.frameTime = info.ModTime()
+	return nil
+}
+
+// EPD returns the underlying EPD driver for direct access (e.g. HardwareInit).
+func (l *LocalDisplay) EPD() Driver {
 	return l.epd
 }
 
+// Width returns the panel width in pixels.
+func (l *LocalDisplay) Width() int {
+	return l.width
+}
+
+// Height returns the panel height in pixels.
+func (l *LocalDisplay) Height() int {
+	return l.height
+}
+
+// Device returns the device type of the panel.
+func (l *LocalDisplay) Device() string {
+	return l.device
+}
+
 // --- Shared image processing utilities ---
 
 // ReadImageFile reads an image from a file path or URL and returns PNG-encoded bytes.
@@ -123,7 +350,19 @@ func ReadImageFile(filePath string, width, height int) ([]byte, error) {
 		filePath = tmpfile.Name()
 	}
 
-	img, err := imaging.Open(filePath, imaging.AutoOrientation(true))
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	defer f.Close()
+
+	return ReadImage(f, width, height)
+}
+
+// ReadImage decodes an image in any supported format from r, fits it to the
+// display and returns PNG-encoded bytes.
+func ReadImage(r io.Reader, width, height int) ([]byte, error) {
+	img, err := imaging.Decode(r, imaging.AutoOrientation(true))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open image: %w", err)
 	}
@@ -138,14 +377,14 @@ func ReadImageFile(filePath string, width, height int) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-func processImageNRGBA(img *image.NRGBA, width, height int) *image.NRGBA {
+func processImageNRGBA(img *image.NRGBA, width, height int, fit string) *image.NRGBA {
 	// Rotate if necessary
 	if img.Bounds().Max.X == height && img.Bounds().Max.Y == width {
 		img = imaging.Rotate90(img)
 	}
 
 	// Resize to match display dimensions
-	img = imaging.Resize(img, width, height, imaging.Lanczos)
+	img = fitImage(img, width, height, fit)
 
 	// Greyscale and enhance
 	img = imaging.Grayscale(img)
@@ -157,54 +396,7 @@ func processImageNRGBA(img *image.NRGBA, width, height int) *image.NRGBA {
 
 func processImage(img image.Image, width, height int) image.Image {
 	nrgba := imaging.Clone(img)
-	return processImageNRGBA(nrgba, width, height)
-}
-
-func renderText(text string, epdWidth, epdHeight int) (image.Image, error) {
-	dc := gg.NewContext(epdWidth, epdHeight)
-
-	// Set Background Color
-	dc.SetRGB(1, 1, 1)
-	dc.Clear()
-
-	// Set font color
-	dc.SetColor(color.Black)
-	dc.Fill()
-	dc.SetRGB(0, 0, 0)
-
-	var (
-		maxWidth, maxHeight           float64 = float64(epdWidth), float64(epdHeight)
-		fontSize                      float64 = 300
-		fontSizeReduction             float64 = 0.95
-		fontSizeMinimum               float64 = 10
-		lineSpacing                   float64 = 1
-		measuredWidth, measuredHeight float64
-	)
-
-	font, err := truetype.Parse(goregular.TTF)
-	if err != nil {
-		return nil, err
-	}
-	for {
-		face := truetype.NewFace(font, &truetype.Options{Size: fontSize})
-		dc.SetFontFace(face)
-
-		stringLines := dc.WordWrap(text, maxWidth)
-		measuredWidth, measuredHeight = dc.MeasureMultilineString(strings.Join(stringLines, "\n"), lineSpacing)
-
-		if measuredWidth < maxWidth && measuredHeight <= maxHeight {
-			break
-		} else {
-			fontSize = fontSize * fontSizeReduction
-		}
-
-		if fontSize < fontSizeMinimum {
-			return nil, fmt.Errorf("unable to fit text on screen: \n %s", text)
-		}
-	}
-
-	dc.DrawStringWrapped(text, 0, (maxHeight-measuredHeight)/2-(fontSize/4), 0, 0, maxWidth, lineSpacing, gg.AlignCenter)
-	return dc.Image(), nil
+	return processImageNRGBA(nrgba, width, height, FitStretch)
 }
 
 // convertImage converts an image into a ready-to-display byte buffer for the EPD.
@@ -236,6 +428,27 @@ func convertImage(img image.Image, epdWidth, epdHeight int) []byte {
 	return buffer
 }
 
+// bufferToImage converts an EPD byte buffer back into the 1-bit image it
+// produces on the panel. A nil buffer yields a blank (white) frame.
+func bufferToImage(buf []byte, epdWidth, epdHeight int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, epdWidth, epdHeight))
+
+	for j := 0; j < epdHeight; j++ {
+		for i := 0; i < epdWidth; i++ {
+			c := color.Gray{Y: 0xff}
+
+			idx := (i / 8) + (j * (epdWidth / 8))
+			if idx < len(buf) && buf[idx]&(0x80>>(uint32(i)%8)) != 0 {
+				c = color.Gray{Y: 0x00}
+			}
+
+			img.SetGray(i, j, c)
+		}
+	}
+
+	return img
+}
+
 func downloadFile(fullURLFile, localFilePath string) error {
 	file, err := os.Create(localFilePath)
 	if err != nil {
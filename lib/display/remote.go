@@ -2,12 +2,36 @@ package display
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strings"
 	"time"
 
 	pb "github.com/justmiles/epd/proto/epdpb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// DefaultStreamThreshold is the image size above which DisplayImage
+	// switches to the chunked UploadImage RPC.
+	DefaultStreamThreshold = 1 << 20
+
+	// uploadChunkSize is the size of each chunk sent by UploadImage.
+	uploadChunkSize = 64 << 10
+
+	// ClientIDHeader is the gRPC metadata key clients use to identify themselves.
+	ClientIDHeader = "epd-client-id"
+
+	// DisplayHeader is the gRPC metadata key clients use to address one of
+	// several displays served by a daemon.
+	DisplayHeader = "epd-display"
 )
 
 // RemoteDisplay implements Service by forwarding calls to a remote daemon via gRPC.
@@ -15,31 +39,110 @@ type RemoteDisplay struct {
 	conn   *grpc.ClientConn
 	client pb.EPDServiceClient
 	addr   string
+
+	// display names one of several displays served by the daemon; empty
+	// addresses its default display.
+	display string
+
+	clientID        string
+	token           string
+	maxMessageSize  int
+	streamThreshold int
 }
 
-// NewRemoteDisplay creates a new remote display service connected to the given address.
-func NewRemoteDisplay(address string) (*RemoteDisplay, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// RemoteOption configures a RemoteDisplay.
+type RemoteOption func(r *RemoteDisplay)
+
+// WithClientID sets the identity reported to the daemon with every call.
+// It defaults to user@hostname.
+func WithClientID(id string) RemoteOption {
+	return func(r *RemoteDisplay) {
+		r.clientID = id
+	}
+}
+
+// WithToken sends token as a bearer token with every call.
+func WithToken(token string) RemoteOption {
+	return func(r *RemoteDisplay) {
+		r.token = token
+	}
+}
+
+// WithMaxMessageSize sets the maximum gRPC message size, in bytes, the client
+// will send or receive.
+func WithMaxMessageSize(size int) RemoteOption {
+	return func(r *RemoteDisplay) {
+		r.maxMessageSize = size
+	}
+}
+
+// WithStreamThreshold sets the image size, in bytes, above which DisplayImage
+// streams the image in chunks instead of sending a single message.
+func WithStreamThreshold(size int) RemoteOption {
+	return func(r *RemoteDisplay) {
+		r.streamThreshold = size
+	}
+}
 
-	conn, err := grpc.DialContext(ctx, address,
+// SplitDisplay splits a remote device address naming one of several displays
+// served by a daemon, host:port/name or unix:///path#name, into the daemon's
+// address and the display name. The name is empty when none is given.
+func SplitDisplay(device string) (address, name string) {
+	if strings.HasPrefix(device, UnixScheme) {
+		address, name, _ = strings.Cut(device, "#")
+		return address, name
+	}
+	address, name, _ = strings.Cut(device, "/")
+	return address, name
+}
+
+// NewRemoteDisplay creates a new remote display service connected to the given
+// address, which may name one of the daemon's displays as described by
+// SplitDisplay.
+func NewRemoteDisplay(device string, opts ...RemoteOption) (*RemoteDisplay, error) {
+	address, name := SplitDisplay(device)
+	r := &RemoteDisplay{
+		addr:            address,
+		display:         name,
+		clientID:        defaultClientID(),
+		streamThreshold: DefaultStreamThreshold,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	dialOpts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithBlock(),
-	)
+	}
+	if r.maxMessageSize > 0 {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(r.maxMessageSize),
+			grpc.MaxCallSendMsgSize(r.maxMessageSize),
+		))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, address, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to EPD daemon at %s: %w", address, err)
 	}
 
-	return &RemoteDisplay{
-		conn:   conn,
-		client: pb.NewEPDServiceClient(conn),
-		addr:   address,
-	}, nil
+	r.conn = conn
+	r.client = pb.NewEPDServiceClient(conn)
+	return r, nil
 }
 
-// DisplayImage sends raw PNG data to the remote daemon for display.
+// DisplayImage sends raw PNG data to the remote daemon for display. Images
+// larger than the stream threshold are uploaded in chunks.
 func (r *RemoteDisplay) DisplayImage(pngData []byte) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	if len(pngData) > r.streamThreshold {
+		return r.uploadImage(pngData)
+	}
+
+	ctx, cancel := r.callContext(60 * time.Second)
 	defer cancel()
 
 	_, err := r.client.DisplayImage(ctx, &pb.DisplayImageRequest{
@@ -51,9 +154,41 @@ func (r *RemoteDisplay) DisplayImage(pngData []byte) error {
 	return nil
 }
 
+// uploadImage streams PNG data to the remote daemon in chunks via UploadImage.
+func (r *RemoteDisplay) uploadImage(pngData []byte) error {
+	ctx, cancel := r.callContext(120 * time.Second)
+	defer cancel()
+
+	stream, err := r.client.UploadImage(ctx)
+	if err != nil {
+		return fmt.Errorf("remote UploadImage failed: %w", err)
+	}
+
+	sum := sha256.Sum256(pngData)
+	for offset := 0; offset < len(pngData); offset += uploadChunkSize {
+		end := min(offset+uploadChunkSize, len(pngData))
+
+		chunk := &pb.ImageChunk{Data: pngData[offset:end]}
+		if offset == 0 {
+			chunk.TotalSize = int64(len(pngData))
+			chunk.Sha256 = hex.EncodeToString(sum[:])
+		}
+
+		if err := stream.Send(chunk); err != nil {
+			// The server's status is only available from CloseAndRecv
+			break
+		}
+	}
+
+	if _, err := stream.CloseAndRecv(); err != nil {
+		return fmt.Errorf("remote UploadImage failed: %w", err)
+	}
+	return nil
+}
+
 // DisplayText sends text to the remote daemon for rendering and display.
 func (r *RemoteDisplay) DisplayText(text string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := r.callContext(60 * time.Second)
 	defer cancel()
 
 	_, err := r.client.DisplayText(ctx, &pb.DisplayTextRequest{
@@ -65,9 +200,39 @@ func (r *RemoteDisplay) DisplayText(text string) error {
 	return nil
 }
 
+// DisplayMarkdown sends markdown to the remote daemon for rendering and display.
+func (r *RemoteDisplay) DisplayMarkdown(markdown string) error {
+	ctx, cancel := r.callContext(60 * time.Second)
+	defer cancel()
+
+	_, err := r.client.DisplayMarkdown(ctx, &pb.DisplayMarkdownRequest{
+		Markdown: markdown,
+	})
+	if err != nil {
+		return fmt.Errorf("remote DisplayMarkdown failed: %w", err)
+	}
+	return nil
+}
+
+// RenderDashboard asks the remote daemon to render its dashboard with the
+// given header and body and display it.
+func (r *RemoteDisplay) RenderDashboard(headerText, bodyText string) error {
+	ctx, cancel := r.callContext(90 * time.Second)
+	defer cancel()
+
+	_, err := r.client.RenderDashboard(ctx, &pb.RenderDashboardRequest{
+		HeaderText: headerText,
+		BodyText:   bodyText,
+	})
+	if err != nil {
+		return fmt.Errorf("remote RenderDashboard failed: %w", err)
+	}
+	return nil
+}
+
 // Clear sends a clear command to the remote daemon.
 func (r *RemoteDisplay) Clear() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := r.callContext(30 * time.Second)
 	defer cancel()
 
 	_, err := r.client.Clear(ctx, &pb.ClearRequest{})
@@ -79,7 +244,7 @@ func (r *RemoteDisplay) Clear() error {
 
 // Sleep sends a sleep command to the remote daemon.
 func (r *RemoteDisplay) Sleep() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := r.callContext(30 * time.Second)
 	defer cancel()
 
 	_, err := r.client.Sleep(ctx, &pb.SleepRequest{})
@@ -89,6 +254,196 @@ func (r *RemoteDisplay) Sleep() error {
 	return nil
 }
 
+// CurrentFrame fetches the image currently shown on the remote display.
+func (r *RemoteDisplay) CurrentFrame() ([]byte, time.Time, error) {
+	ctx, cancel := r.callContext(30 * time.Second)
+	defer cancel()
+
+	resp, err := r.client.GetCurrentFrame(ctx, &pb.GetCurrentFrameRequest{})
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("remote GetCurrentFrame failed: %w", err)
+	}
+	return resp.ImageData, resp.UpdatedAt.AsTime(), nil
+}
+
+// WatchEvents streams display activity events from the remote daemon, calling
+// handle for each one until ctx is cancelled or the stream ends.
+func (r *RemoteDisplay) WatchEvents(ctx context.Context, handle func(*pb.Event)) error {
+	ctx = r.withMetadata(ctx)
+
+	stream, err := r.client.WatchEvents(ctx, &pb.WatchEventsRequest{})
+	if err != nil {
+		return fmt.Errorf("remote WatchEvents failed: %w", err)
+	}
+
+	for {
+		e, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("remote WatchEvents failed: %w", err)
+		}
+		handle(e)
+	}
+}
+
+// Health checks the remote daemon with the standard gRPC health service.
+func (r *RemoteDisplay) Health() (healthpb.HealthCheckResponse_ServingStatus, error) {
+	ctx, cancel := r.callContext(10 * time.Second)
+	defer cancel()
+
+	// A daemon serving several displays reports each as its own service.
+	service := pb.EPDService_ServiceDesc.ServiceName
+	if r.display != "" {
+		service += "/" + r.display
+	}
+	resp, err := healthpb.NewHealthClient(r.conn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: service,
+	})
+	if err != nil {
+		return healthpb.HealthCheckResponse_UNKNOWN, fmt.Errorf("remote health check failed: %w", err)
+	}
+	return resp.Status, nil
+}
+
+// ListDisplays returns the state of every display the remote daemon serves,
+// its default display first.
+func (r *RemoteDisplay) ListDisplays() ([]*pb.GetStatusResponse, error) {
+	ctx, cancel := r.callContext(10 * time.Second)
+	defer cancel()
+
+	resp, err := r.client.ListDisplays(ctx, &pb.ListDisplaysRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("remote ListDisplays failed: %w", err)
+	}
+	return resp.Displays, nil
+}
+
+// AddSchedule adds a scheduled entry on the remote daemon and returns it with
+// its assigned ID.
+func (r *RemoteDisplay) AddSchedule(schedule *pb.Schedule) (*pb.Schedule, error) {
+	ctx, cancel := r.callContext(10 * time.Second)
+	defer cancel()
+
+	resp, err := r.client.AddSchedule(ctx, &pb.AddScheduleRequest{Schedule: schedule})
+	if err != nil {
+		return nil, fmt.Errorf("remote AddSchedule failed: %w", err)
+	}
+	return resp, nil
+}
+
+// RemoveSchedule removes a scheduled entry from the remote daemon.
+func (r *RemoteDisplay) RemoveSchedule(id string) error {
+	ctx, cancel := r.callContext(10 * time.Second)
+	defer cancel()
+
+	_, err := r.client.RemoveSchedule(ctx, &pb.RemoveScheduleRequest{Id: id})
+	if err != nil {
+		return fmt.Errorf("remote RemoveSchedule failed: %w", err)
+	}
+	return nil
+}
+
+// ListSchedules returns the remote daemon's scheduled entries and quiet hours.
+func (r *RemoteDisplay) ListSchedules() (*pb.ListSchedulesResponse, error) {
+	ctx, cancel := r.callContext(10 * time.Second)
+	defer cancel()
+
+	resp, err := r.client.ListSchedules(ctx, &pb.ListSchedulesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("remote ListSchedules failed: %w", err)
+	}
+	return resp, nil
+}
+
+// SetQuietHours sets the daily window, as "HH:MM" times, in which the remote
+// daemon skips scheduled entries. Empty times disable quiet hours.
+func (r *RemoteDisplay) SetQuietHours(start, end string) error {
+	ctx, cancel := r.callContext(10 * time.Second)
+	defer cancel()
+
+	_, err := r.client.SetQuietHours(ctx, &pb.QuietHours{Start: start, End: end})
+	if err != nil {
+		return fmt.Errorf("remote SetQuietHours failed: %w", err)
+	}
+	return nil
+}
+
+// AddPlaylistItem appends an item to the remote daemon's playlist and returns
+// it with its assigned ID.
+func (r *RemoteDisplay) AddPlaylistItem(item *pb.PlaylistItem) (*pb.PlaylistItem, error) {
+	ctx, cancel := r.callContext(10 * time.Second)
+	defer cancel()
+
+	resp, err := r.client.AddPlaylistItem(ctx, &pb.AddPlaylistItemRequest{Item: item})
+	if err != nil {
+		return nil, fmt.Errorf("remote AddPlaylistItem failed: %w", err)
+	}
+	return resp, nil
+}
+
+// RemovePlaylistItem removes an item from the remote daemon's playlist.
+func (r *RemoteDisplay) RemovePlaylistItem(id string) error {
+	ctx, cancel := r.callContext(10 * time.Second)
+	defer cancel()
+
+	_, err := r.client.RemovePlaylistItem(ctx, &pb.RemovePlaylistItemRequest{Id: id})
+	if err != nil {
+		return fmt.Errorf("remote RemovePlaylistItem failed: %w", err)
+	}
+	return nil
+}
+
+// Playlist returns the remote daemon's playlist and what it is showing.
+func (r *RemoteDisplay) Playlist() (*pb.Playlist, error) {
+	ctx, cancel := r.callContext(10 * time.Second)
+	defer cancel()
+
+	resp, err := r.client.GetPlaylist(ctx, &pb.GetPlaylistRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("remote GetPlaylist failed: %w", err)
+	}
+	return resp, nil
+}
+
+// SkipPlaylistItem asks the remote daemon to show the next playlist item now.
+func (r *RemoteDisplay) SkipPlaylistItem() (*pb.Playlist, error) {
+	ctx, cancel := r.callContext(10 * time.Second)
+	defer cancel()
+
+	resp, err := r.client.SkipPlaylistItem(ctx, &pb.SkipPlaylistItemRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("remote SkipPlaylistItem failed: %w", err)
+	}
+	return resp, nil
+}
+
+// PausePlaylist pauses or resumes the remote daemon's playlist.
+func (r *RemoteDisplay) PausePlaylist(paused bool) (*pb.Playlist, error) {
+	ctx, cancel := r.callContext(10 * time.Second)
+	defer cancel()
+
+	resp, err := r.client.PausePlaylist(ctx, &pb.PausePlaylistRequest{Paused: paused})
+	if err != nil {
+		return nil, fmt.Errorf("remote PausePlaylist failed: %w", err)
+	}
+	return resp, nil
+}
+
+// ShufflePlaylist switches the remote daemon's playlist between playing in
+// order and shuffled.
+func (r *RemoteDisplay) ShufflePlaylist(shuffle bool) (*pb.Playlist, error) {
+	ctx, cancel := r.callContext(10 * time.Second)
+	defer cancel()
+
+	resp, err := r.client.ShufflePlaylist(ctx, &pb.ShufflePlaylistRequest{Shuffle: shuffle})
+	if err != nil {
+		return nil, fmt.Errorf("remote ShufflePlaylist failed: %w", err)
+	}
+	return resp, nil
+}
+
 // Close closes the gRPC connection.
 func (r *RemoteDisplay) Close() error {
 	if r.conn != nil {
@@ -96,3 +451,35 @@ func (r *RemoteDisplay) Close() error {
 	}
 	return nil
 }
+
+// callContext returns a context for a single call, carrying the client identity
+// and token.
+func (r *RemoteDisplay) callContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	return r.withMetadata(ctx), cancel
+}
+
+// withMetadata attaches the client identity, display name and token to an
+// outgoing context.
+func (r *RemoteDisplay) withMetadata(ctx context.Context) context.Context {
+	ctx = metadata.AppendToOutgoingContext(ctx, ClientIDHeader, r.clientID)
+	if r.display != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, DisplayHeader, r.display)
+	}
+	if r.token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+r.token)
+	}
+	return ctx
+}
+
+// defaultClientID identifies this process as user@hostname.
+func defaultClientID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username + "@" + host
+	}
+	return host
+}
@@ -0,0 +1,214 @@
+package display
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/fogleman/gg"
+	"github.com/justmiles/epd/lib/fonts"
+)
+
+// Fit modes control how an image is scaled to the panel.
+const (
+	FitStretch = "stretch" // scale to exactly fill the panel, ignoring aspect ratio (default)
+	FitContain = "contain" // scale to fit within the panel, padding with white
+	FitCover   = "cover"   // scale to cover the panel, cropping the overflow
+)
+
+// Dither modes control how greyscale is reduced to black and white.
+const (
+	DitherNone           = "none"            // threshold each pixel at 50% (default)
+	DitherFloydSteinberg = "floyd-steinberg" // Floyd-Steinberg error diffusion
+	DitherAtkinson       = "atkinson"        // Atkinson error diffusion, higher contrast
+)
+
+// ImageOptions controls how images are rendered for the panel. The zero value
+// stretches the image and thresholds it without dithering.
+type ImageOptions struct {
+	Fit    string
+	Dither string
+}
+
+// Validate returns an error if the options name an unknown fit or dither mode.
+func (o ImageOptions) Validate() error {
+	switch o.Fit {
+	case "", FitStretch, FitContain, FitCover:
+	default:
+		return fmt.Errorf("unknown fit mode %q", o.Fit)
+	}
+
+	switch o.Dither {
+	case "", DitherNone, DitherFloydSteinberg, DitherAtkinson:
+	default:
+		return fmt.Errorf("unknown dither mode %q", o.Dither)
+	}
+
+	return nil
+}
+
+// TextOptions controls how text is rendered for the panel. The zero value
+// draws the text as large as fits in the regular Go font.
+type TextOptions struct {
+	Font *fonts.Font // nil for the regular Go font
+	Size float64     // in points, 0 to fit the panel
+}
+
+// RenderText wraps text and centers it in black on a white width x height
+// panel.
+func RenderText(text string, width, height int, opts TextOptions) (image.Image, error) {
+	f := opts.Font
+	if f == nil {
+		var err error
+		if f, err = fonts.Load(fonts.DefaultFamily, fonts.Regular, fonts.Normal); err != nil {
+			return nil, err
+		}
+	}
+
+	dc := gg.NewContext(width, height)
+
+	// Set Background Color
+	dc.SetRGB(1, 1, 1)
+	dc.Clear()
+
+	// Set font color
+	dc.SetColor(color.Black)
+	dc.Fill()
+	dc.SetRGB(0, 0, 0)
+
+	var (
+		maxWidth, maxHeight           float64 = float64(width), float64(height)
+		fontSize                      float64 = 300
+		fontSizeReduction             float64 = 0.95
+		fontSizeMinimum               float64 = 10
+		lineSpacing                   float64 = 1
+		measuredWidth, measuredHeight float64
+	)
+	if opts.Size > 0 {
+		fontSize = opts.Size
+	}
+
+	for {
+		dc.SetFontFace(f.Face(fontSize))
+
+		stringLines := dc.WordWrap(text, maxWidth)
+		measuredWidth, measuredHeight = dc.MeasureMultilineString(strings.Join(stringLines, "\n"), lineSpacing)
+
+		if measuredWidth < maxWidth && measuredHeight <= maxHeight {
+			break
+		} else if opts.Size > 0 {
+			return nil, fmt.Errorf("unable to fit text on screen at size %g: \n %s", opts.Size, text)
+		} else {
+			fontSize = fontSize * fontSizeReduction
+		}
+
+		if fontSize < fontSizeMinimum {
+			return nil, fmt.Errorf("unable to fit text on screen: \n %s", text)
+		}
+	}
+
+	dc.DrawStringWrapped(text, 0, (maxHeight-measuredHeight)/2-(fontSize/4), 0, 0, maxWidth, lineSpacing, gg.AlignCenter)
+	return dc.Image(), nil
+}
+
+// RenderImage fits img to a width x height panel and reduces it to the black
+// and white frame the EPD will show.
+func RenderImage(img image.Image, width, height int, opts ImageOptions) *image.Gray {
+	nrgba := processImageNRGBA(imaging.Clone(img), width, height, opts.Fit)
+
+	switch opts.Dither {
+	case DitherFloydSteinberg:
+		return diffuse(nrgba, floydSteinberg)
+	case DitherAtkinson:
+		return diffuse(nrgba, atkinson)
+	default:
+		return threshold(nrgba)
+	}
+}
+
+// fitImage scales img to width x height according to the fit mode.
+func fitImage(img *image.NRGBA, width, height int, fit string) *image.NRGBA {
+	switch fit {
+	case FitContain:
+		srcW, srcH := float64(img.Bounds().Dx()), float64(img.Bounds().Dy())
+		scale := math.Min(float64(width)/srcW, float64(height)/srcH)
+		w, h := max(1, int(math.Round(srcW*scale))), max(1, int(math.Round(srcH*scale)))
+
+		bg := imaging.New(width, height, color.White)
+		return imaging.PasteCenter(bg, imaging.Resize(img, w, h, imaging.Lanczos))
+	case FitCover:
+		return imaging.Fill(img, width, height, imaging.Center, imaging.Lanczos)
+	default:
+		return imaging.Resize(img, width, height, imaging.Lanczos)
+	}
+}
+
+// threshold maps each pixel to black or white, whichever is nearer.
+func threshold(img image.Image) *image.Gray {
+	b := img.Bounds()
+	out := image.NewGray(image.Rect(0, 0, b.Dx(), b.Dy()))
+
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			g := color.GrayModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.Gray)
+			if g.Y >= 0x80 {
+				out.SetGray(x, y, color.Gray{Y: 0xff})
+			}
+		}
+	}
+
+	return out
+}
+
+// diffusionWeight spreads part of a pixel's quantization error to a neighbour.
+type diffusionWeight struct {
+	dx, dy int
+	weight float64
+}
+
+var (
+	floydSteinberg = []diffusionWeight{
+		{1, 0, 7.0 / 16}, {-1, 1, 3.0 / 16}, {0, 1, 5.0 / 16}, {1, 1, 1.0 / 16},
+	}
+	atkinson = []diffusionWeight{
+		{1, 0, 1.0 / 8}, {2, 0, 1.0 / 8}, {-1, 1, 1.0 / 8}, {0, 1, 1.0 / 8}, {1, 1, 1.0 / 8}, {0, 2, 1.0 / 8},
+	}
+)
+
+// diffuse dithers img to black and white using the given error diffusion kernel.
+func diffuse(img image.Image, kernel []diffusionWeight) *image.Gray {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	lum := make([]float64, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			lum[y*w+x] = float64(color.GrayModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.Gray).Y)
+		}
+	}
+
+	out := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			old := lum[y*w+x]
+			quantized := 0.0
+			if old >= 0x80 {
+				quantized = 0xff
+				out.SetGray(x, y, color.Gray{Y: 0xff})
+			}
+
+			diff := old - quantized
+			for _, k := range kernel {
+				nx, ny := x+k.dx, y+k.dy
+				if nx >= 0 && nx < w && ny < h {
+					lum[ny*w+nx] += diff * k.weight
+				}
+			}
+		}
+	}
+
+	return out
+}
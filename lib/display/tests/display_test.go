@@ -0,0 +1,76 @@
+package display_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/justmiles/epd/lib/display"
+	"github.com/justmiles/epd/lib/fonts"
+)
+
+func TestIsRemote(t *testing.T) {
+	tests := []struct {
+		device string
+		want   bool
+	}{
+		{"epd7in5v2", false},
+		{"pi.local:50051", true},
+		{"10.0.0.5:50051", true},
+		{"[::1]:50051", true},
+		{"unix:///run/epd.sock", true},
+		{"unix:epd.sock", true},
+		{"lobby.local", true},
+		{"Lobby.LOCAL", true},
+		{"pi.local:50051/left", true},
+		{"lobby.local/left", true},
+		{"lobby", false},
+		{"lobby/left", false},
+		{"epd7in5", false},
+		{".local", false},
+	}
+	for _, tt := range tests {
+		if got := display.IsRemote(tt.device); got != tt.want {
+			t.Errorf("IsRemote(%q) = %t, want %t", tt.device, got, tt.want)
+		}
+	}
+}
+
+func TestSplitDisplay(t *testing.T) {
+	tests := []struct {
+		device, address, name string
+	}{
+		{"pi.local:50051", "pi.local:50051", ""},
+		{"pi.local:50051/left", "pi.local:50051", "left"},
+		{"[::1]:50051/right", "[::1]:50051", "right"},
+		{"lobby.local/left", "lobby.local", "left"},
+		{"unix:///run/epd.sock", "unix:///run/epd.sock", ""},
+		{"unix:///run/epd.sock#left", "unix:///run/epd.sock", "left"},
+	}
+	for _, tt := range tests {
+		address, name := display.SplitDisplay(tt.device)
+		if address != tt.address || name != tt.name {
+			t.Errorf("SplitDisplay(%q) = %q, %q, want %q, %q", tt.device, address, name, tt.address, tt.name)
+		}
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	fit, err := display.RenderText("Hello", 200, 100, display.TextOptions{})
+	if err != nil {
+		t.Fatalf("RenderText failed: %v", err)
+	}
+	if fit.Bounds().Dx() != 200 || fit.Bounds().Dy() != 100 {
+		t.Errorf("Expected a 200x100 image, got %v", fit.Bounds())
+	}
+
+	mono, err := fonts.Load("go-mono", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := display.RenderText("Hello", 200, 100, display.TextOptions{Font: mono, Size: 20}); err != nil {
+		t.Errorf("RenderText with a font failed: %v", err)
+	}
+	if _, err := display.RenderText("Hello", 200, 100, display.TextOptions{Size: 200}); err == nil || !strings.Contains(err.Error(), "at size 200") {
+		t.Errorf("Expected text too large to fit to fail, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package display_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/display"
+)
+
+// fakeDriver is a panel that counts the updates sent to it.
+type fakeDriver struct{ displays int }
+
+func (f *fakeDriver) HardwareInit() error { return nil }
+func (f *fakeDriver) Display([]byte)      { f.displays++ }
+func (f *fakeDriver) Clear()              {}
+func (f *fakeDriver) Sleep()              {}
+
+// newLocalDisplay returns a 16x8 display on a fakeDriver.
+func newLocalDisplay(t *testing.T, opts ...display.LocalOption) (*display.LocalDisplay, *fakeDriver) {
+	t.Helper()
+	drv := &fakeDriver{}
+	l, err := display.NewLocalDisplayWithDriver("epd7in5v2", drv, 16, 8, opts...)
+	if err != nil {
+		t.Fatalf("NewLocalDisplayWithDriver failed: %v", err)
+	}
+	return l, drv
+}
+
+// blackPNG returns a 16x8 black PNG.
+func blackPNG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, 16, 8))
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// frameColor decodes a frame and returns the color of its top left pixel.
+func frameColor(t *testing.T, frame []byte) color.Gray {
+	t.Helper()
+	img, err := png.Decode(bytes.NewReader(frame))
+	if err != nil {
+		t.Fatalf("Frame is not a PNG: %v", err)
+	}
+	if img.Bounds().Dx() != 16 || img.Bounds().Dy() != 8 {
+		t.Errorf("Expected a 16x8 frame, got %v", img.Bounds())
+	}
+	return color.GrayModel.Convert(img.At(0, 0)).(color.Gray)
+}
+
+func TestCurrentFrame(t *testing.T) {
+	l, _ := newLocalDisplay(t)
+
+	if _, _, err := l.CurrentFrame(); !errors.Is(err, display.ErrNoFrame) {
+		t.Fatalf("Expected ErrNoFrame before anything is displayed, got %v", err)
+	}
+
+	before := time.Now()
+	if err := l.DisplayImage(blackPNG(t)); err != nil {
+		t.Fatalf("DisplayImage failed: %v", err)
+	}
+	frame, updated, err := l.CurrentFrame()
+	if err != nil {
+		t.Fatalf("CurrentFrame failed: %v", err)
+	}
+	if c := frameColor(t, frame); c.Y != 0 {
+		t.Errorf("Expected the black image as the frame, got %v", c)
+	}
+	if updated.Before(before) {
+		t.Errorf("Expected the frame's time to be the update's, got %v", updated)
+	}
+
+	if err := l.Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	frame, _, err = l.CurrentFrame()
+	if err != nil {
+		t.Fatalf("CurrentFrame failed: %v", err)
+	}
+	if c := frameColor(t, frame); c.Y != 0xff {
+		t.Errorf("Expected a white frame after Clear, got %v", c)
+	}
+}
+
+func TestFrameFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frame.png")
+	l, _ := newLocalDisplay(t, display.WithFrameFile(path))
+	if err := l.DisplayImage(blackPNG(t)); err != nil {
+		t.Fatalf("DisplayImage failed: %v", err)
+	}
+
+	persisted, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the frame to be persisted: %v", err)
+	}
+	if c := frameColor(t, persisted); c.Y != 0 {
+		t.Errorf("Expected the black image to be persisted, got %v", c)
+	}
+
+	// A new display, as after a restart, restores the frame and its time
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restarted, drv := newLocalDisplay(t, display.WithFrameFile(path))
+	frame, updated, err := restarted.CurrentFrame()
+	if err != nil {
+		t.Fatalf("CurrentFrame after restart failed: %v", err)
+	}
+	if c := frameColor(t, frame); c.Y != 0 {
+		t.Errorf("Expected the restored frame to be black, got %v", c)
+	}
+	if !updated.Equal(info.ModTime()) {
+		t.Errorf("Expected the restored frame's time to be %v, got %v", info.ModTime(), updated)
+	}
+	if drv.displays != 0 {
+		t.Errorf("Expected restoring the frame not to update the panel, got %d updates", drv.displays)
+	}
+}
+
+func TestFrameFile_PersistFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "frame.png")
+	l, drv := newLocalDisplay(t, display.WithFrameFile(path))
+
+	if err := l.DisplayImage(blackPNG(t)); err != nil {
+		t.Fatalf("Expected the update to succeed although the frame can't be persisted, got %v", err)
+	}
+	if drv.displays != 1 {
+		t.Errorf("Expected 1 panel update, got %d", drv.displays)
+	}
+	if _, _, err := l.CurrentFrame(); err != nil {
+		t.Errorf("Expected the frame in memory, got %v", err)
+	}
+}
+
+func TestFrameFile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frame.png")
+	if err := os.WriteFile(path, []byte("not a png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := display.NewLocalDisplayWithDriver("epd7in5v2", &fakeDriver{}, 16, 8, display.WithFrameFile(path)); err == nil {
+		t.Error("Expected a corrupt frame file to fail")
+	}
+}
@@ -3,7 +3,9 @@ package epd7in5v2
 // ported from https://github.com/waveshare/e-Paper/blob/master/RaspberryPi%26JetsonNano/c/lib/e-Paper/EPD_7in5_V2.c
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	rpio "github.com/stianeikeland/go-rpio/v4"
@@ -63,8 +65,14 @@ type EPD struct {
 	csPin    uint8
 	busyPin  uint8
 
+	// chipSelect is the SPI chip select, CE0 or CE1, wired to csPin.
+	chipSelect uint8
+
 	Height int
 	Width  int
+
+	// busyHook, when set, is called after every wait for the panel
+	busyHook func(wait time.Duration, timedOut bool)
 }
 
 // NewRaspberryPiHat intialized the EDP for Raspberry PI
@@ -77,30 +85,40 @@ func NewRaspberryPiHat() (*EPD, error) {
 	)
 }
 
-// New EPD7in5_V2 str
+// rpioOpen maps the GPIO registers once for every panel.
+var rpioOpen = sync.OnceValue(rpio.Open)
+
+// New EPD7in5_V2 str. Panels sharing the SPI bus are told apart by csPin:
+// GPIO_8 is chip select CE0 and GPIO_7 is CE1.
 func New(resetPin, dcPin, csPin, busyPin uint8) (*EPD, error) {
 
 	// rpio.Mode()
-	err := rpio.Open()
+	err := rpioOpen()
 	if err != nil {
 		return nil, err
 	}
 
+	var chipSelect uint8
+	if csPin == 7 {
+		chipSelect = 1
+	}
+
 	rpio.PinMode(rpio.Pin(resetPin), rpio.Mode(rpio.Output))
 	rpio.PinMode(rpio.Pin(dcPin), rpio.Mode(rpio.Output))
 	rpio.PinMode(rpio.Pin(csPin), rpio.Mode(rpio.Output))
 	rpio.PinMode(rpio.Pin(busyPin), rpio.Mode(rpio.Input))
 
 	rpio.SpiSpeed(4000000)
-	rpio.SpiChipSelect(0)
+	rpio.SpiChipSelect(chipSelect)
 
 	return &EPD{
-		resetPin: resetPin,
-		dcPin:    dcPin,
-		csPin:    csPin,
-		busyPin:  busyPin,
-		Height:   epdHeight,
-		Width:    epdWidth,
+		resetPin:   resetPin,
+		dcPin:      dcPin,
+		csPin:      csPin,
+		busyPin:    busyPin,
+		chipSelect: chipSelect,
+		Height:     epdHeight,
+		Width:      epdWidth,
 	}, nil
 }
 
@@ -155,7 +173,7 @@ func (epd EPD) SendCommand(command ...byte) {
 	debug("epd -> SendCommand %v", command)
 	digitalWrite(epd.dcPin, rpio.Low)
 	digitalWrite(epd.csPin, rpio.Low)
-	spiWrite(command...)
+	spiWrite(epd.chipSelect, command...)
 	digitalWrite(epd.csPin, rpio.High)
 }
 
@@ -164,12 +182,17 @@ func (epd EPD) SendData(command ...byte) {
 	debug("epd -> SendData %v", command)
 	digitalWrite(epd.dcPin, rpio.High)
 	digitalWrite(epd.csPin, rpio.Low)
-	spiWrite(command...)
+	spiWrite(epd.chipSelect, command...)
 	digitalWrite(epd.csPin, rpio.High)
 }
 
+// ErrBusyTimeout is returned when the panel stays busy for longer than
+// ReadBusy waits, e.g. because it is not connected or not powered.
+var ErrBusyTimeout = errors.New("timeout waiting for EPD busy status")
+
 // HardwareInit used to initialize e-Paper or wakeup e-Paper from sleep mode.
-func (epd EPD) HardwareInit() {
+// It fails if the panel does not power on.
+func (epd EPD) HardwareInit() error {
 	debug("epd -> HardwareInit")
 
 	epd.HardwareReset()
@@ -183,7 +206,9 @@ func (epd EPD) HardwareInit() {
 
 	epd.SendCommand(powerOn)
 	delayMS(100)
-	epd.ReadBusy()
+	if err := epd.ReadBusy(); err != nil {
+		return fmt.Errorf("hardware init failed: %w", err)
+	}
 	delayMS(200)
 
 	epd.SendCommand(panelSetting)
@@ -208,11 +233,20 @@ func (epd EPD) HardwareInit() {
 
 	epd.SendCommand(tconSetting)
 	epd.SendData(b2WLut)
+	return nil
+}
+
+// SetBusyHook registers fn to be called with the duration of every wait for
+// the panel, and whether that wait timed out.
+func (epd *EPD) SetBusyHook(fn func(wait time.Duration, timedOut bool)) {
+	epd.busyHook = fn
 }
 
-// ReadBusy waits until the EPD is no longer busy, with a 60 second timeout.
-func (epd EPD) ReadBusy() {
+// ReadBusy waits until the EPD is no longer busy, with a 60 second timeout,
+// returning ErrBusyTimeout if it is still busy by then.
+func (epd EPD) ReadBusy() error {
 	done := make(chan struct{})
+	start := time.Now()
 
 	// Wait for busy
 	go func() {
@@ -229,12 +263,21 @@ func (epd EPD) ReadBusy() {
 		}
 	}()
 
+	timedOut := false
 	select {
 	case <-done:
-		return
 	case <-time.After(60 * time.Second):
 		fmt.Println("Timeout waiting for EPD busy status. Did you initialize (wake) the device?")
+		timedOut = true
+	}
+
+	if epd.busyHook != nil {
+		epd.busyHook(time.Since(start), timedOut)
+	}
+	if timedOut {
+		return ErrBusyTimeout
 	}
+	return nil
 }
 
 // Sleep is used to set the device to sleep mode
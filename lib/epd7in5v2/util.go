@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sync"
 	"time"
 
 	rpio "github.com/stianeikeland/go-rpio/v4"
@@ -26,12 +27,20 @@ func delayMS(ms int64) {
 	time.Sleep(time.Duration(ms) * time.Millisecond)
 }
 
-func spiWrite(command ...byte) {
-	debug("util -> spiWrite(%v)", command)
+// spiMu serializes transfers on the SPI bus, which panels on different chip
+// selects share.
+var spiMu sync.Mutex
+
+func spiWrite(chip uint8, command ...byte) {
+	debug("util -> spiWrite(%v,%v)", chip, command)
+	spiMu.Lock()
+	defer spiMu.Unlock()
+
 	err := rpio.SpiBegin(rpio.Spi0)
 	if err != nil {
 		panic(err)
 	}
+	rpio.SpiChipSelect(chip)
 
 	rpio.SpiTransmit(command...)
 	rpio.SpiEnd(rpio.Spi0)
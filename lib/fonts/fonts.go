@@ -0,0 +1,205 @@
+// Package fonts loads the typefaces text is drawn with: the Go fonts built
+// into epd, or TrueType and OpenType files. Fonts are parsed once and cached.
+//
+// Usage:
+//
+//	f, err := fonts.Load("go-mono", "bold", "")
+//	dc.SetFontFace(f.Face(24))
+//
+//	f, err = fonts.Load("/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf", "", "")
+package fonts
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/gomedium"
+	"golang.org/x/image/font/gofont/gomediumitalic"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+	"golang.org/x/image/font/gofont/gomonobolditalic"
+	"golang.org/x/image/font/gofont/gomonoitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/gofont/gosmallcaps"
+	"golang.org/x/image/font/gofont/gosmallcapsitalic"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+)
+
+// DefaultFamily is the family used when none is given.
+const DefaultFamily = "go"
+
+// Weights and styles of the built-in families.
+const (
+	Regular = "regular"
+	Medium  = "medium"
+	Bold    = "bold"
+
+	Normal = "normal"
+	Italic = "italic"
+)
+
+// variant is a weight and style of a family.
+type variant struct {
+	weight, style string
+}
+
+// builtin are the Go fonts by family, weight and style.
+var builtin = map[string]map[variant][]byte{
+	"go": {
+		{Regular, Normal}: goregular.TTF,
+		{Regular, Italic}: goitalic.TTF,
+		{Medium, Normal}:  gomedium.TTF,
+		{Medium, Italic}:  gomediumitalic.TTF,
+		{Bold, Normal}:    gobold.TTF,
+		{Bold, Italic}:    gobolditalic.TTF,
+	},
+	"go-mono": {
+		{Regular, Normal}: gomono.TTF,
+		{Regular, Italic}: gomonoitalic.TTF,
+		{Bold, Normal}:    gomonobold.TTF,
+		{Bold, Italic}:    gomonobolditalic.TTF,
+	},
+	"go-smallcaps": {
+		{Regular, Normal}: gosmallcaps.TTF,
+		{Regular, Italic}: gosmallcapsitalic.TTF,
+	},
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[string]*Font{}
+)
+
+// Font is a parsed typeface. It is safe to share between goroutines; the
+// faces it makes are not.
+type Font struct {
+	// Name is the built-in family, weight and style, e.g. "go bold italic",
+	// or the path of the file the font was loaded from.
+	Name string
+
+	// TrueType outlines are drawn with freetype, as they always have been,
+	// and the CFF outlines of other OpenType fonts with x/image.
+	tt  *truetype.Font
+	otf *sfnt.Font
+}
+
+// Load returns the font of a built-in family, "go", "go-mono" or
+// "go-smallcaps", in the given weight and style, or the font in the .ttf or
+// .otf file at the path family. An empty family is DefaultFamily, an empty
+// weight Regular and an empty style Normal. Files hold a single weight and
+// style, so weight and style are ignored for them.
+func Load(family, weight, style string) (*Font, error) {
+	if family == "" {
+		family = DefaultFamily
+	}
+	if weight == "" {
+		weight = Regular
+	}
+	if style == "" {
+		style = Normal
+	}
+
+	variants, isBuiltin := builtin[family]
+	key := family
+	if isBuiltin {
+		key = strings.Join([]string{family, weight, style}, " ")
+	}
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if f, ok := cache[key]; ok {
+		return f, nil
+	}
+
+	var data []byte
+	if isBuiltin {
+		var weights, styles []string
+		for v := range variants {
+			weights = append(weights, v.weight)
+			styles = append(styles, v.style)
+		}
+		slices.Sort(weights)
+		slices.Sort(styles)
+		weights, styles = slices.Compact(weights), slices.Compact(styles)
+		switch {
+		case !slices.Contains(weights, weight):
+			return nil, fmt.Errorf("unknown weight %q for font %s, expected one of %s", weight, family, strings.Join(weights, ", "))
+		case !slices.Contains(styles, style):
+			return nil, fmt.Errorf("unknown style %q for font %s, expected one of %s", style, family, strings.Join(styles, ", "))
+		}
+		if data = variants[variant{weight, style}]; data == nil {
+			return nil, fmt.Errorf("font %s has no %s %s variant", family, weight, style)
+		}
+	} else {
+		var err error
+		if data, err = os.ReadFile(family); err != nil {
+			return nil, fmt.Errorf("could not read font: %w", err)
+		}
+	}
+
+	f, err := parse(key, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid font %s: %w", family, err)
+	}
+	cache[key] = f
+	return f, nil
+}
+
+// parse parses TrueType or OpenType data.
+func parse(name string, data []byte) (*Font, error) {
+	if tt, err := truetype.Parse(data); err == nil {
+		return &Font{Name: name, tt: tt}, nil
+	}
+	otf, err := opentype.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Font{Name: name, otf: otf}, nil
+}
+
+// Families returns the names of the built-in families, sorted.
+func Families() []string {
+	families := make([]string, 0, len(builtin))
+	for family := range builtin {
+		families = append(families, family)
+	}
+	sort.Strings(families)
+	return families
+}
+
+// IsBuiltin reports whether family names a built-in family rather than a
+// file.
+func IsBuiltin(family string) bool {
+	_, ok := builtin[family]
+	return family == "" || ok
+}
+
+// Face returns a face for drawing the font size points tall at 72 DPI, so a
+// point is a pixel.
+func (f *Font) Face(size float64) font.Face {
+	if f.tt != nil {
+		return truetype.NewFace(f.tt, &truetype.Options{Size: size})
+	}
+	// NewFace only fails for invalid options, and these are valid
+	face, _ := opentype.NewFace(f.otf, &opentype.FaceOptions{Size: size, DPI: 72})
+	return face
+}
+
+// HasGlyph reports whether the font can draw r.
+func (f *Font) HasGlyph(r rune) bool {
+	if f.tt != nil {
+		return f.tt.Index(r) != 0
+	}
+	i, err := f.otf.GlyphIndex(&sfnt.Buffer{}, r)
+	return err == nil && i != 0
+}
@@ -0,0 +1,78 @@
+package fonts_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/justmiles/epd/lib/fonts"
+	"golang.org/x/image/font/gofont/gomono"
+)
+
+func TestLoad(t *testing.T) {
+	for _, family := range fonts.Families() {
+		f, err := fonts.Load(family, "", "italic")
+		if err != nil {
+			t.Fatalf("Load %s failed: %v", family, err)
+		}
+		if !f.HasGlyph('A') || f.HasGlyph('\U0001F600') {
+			t.Errorf("%s: unexpected glyph coverage", family)
+		}
+		if face := f.Face(20); face.Metrics().Height <= 0 {
+			t.Errorf("%s: expected a face with a height", family)
+		}
+	}
+
+	// Fonts are parsed once
+	a, _ := fonts.Load("", "", "")
+	b, _ := fonts.Load("go", "regular", "normal")
+	if a == nil || a != b {
+		t.Error("Expected the default font to be cached")
+	}
+	if bold, _ := fonts.Load("go", "bold", ""); bold == a {
+		t.Error("Expected the bold font to differ from the regular one")
+	}
+}
+
+func TestLoad_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mono.ttf")
+	if err := os.WriteFile(path, gomono.TTF, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := fonts.Load(path, "", "")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if f.Name != path || !f.HasGlyph('x') {
+		t.Errorf("Expected the font from %s, got %s", path, f.Name)
+	}
+	if again, _ := fonts.Load(path, "", ""); again != f {
+		t.Error("Expected the file to be parsed once")
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	notAFont := filepath.Join(t.TempDir(), "font.ttf")
+	if err := os.WriteFile(notAFont, []byte("not a font"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		family, weight, style, err string
+	}{
+		{"go", "heavy", "", "expected one of bold, medium, regular"},
+		{"go-mono", "medium", "", "expected one of bold, regular"},
+		{"go", "", "oblique", "expected one of italic, normal"},
+		{"go-smallcaps", "bold", "", "unknown weight"},
+		{"/nonexistent/font.ttf", "", "", "could not read font"},
+		{notAFont, "", "", "invalid font"},
+	}
+	for _, tt := range tests {
+		_, err := fonts.Load(tt.family, tt.weight, tt.style)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s %s %s: expected an error containing %q, got %v", tt.family, tt.weight, tt.style, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,412 @@
+// Package ical reads events from iCalendar (.ics) files, such as those
+// exported by Google Calendar or Outlook, expanding recurring events.
+//
+// Usage:
+//
+//	cal, err := ical.Parse(file)
+//	// the events of the next week, in Chicago time
+//	loc, _ := time.LoadLocation("America/Chicago")
+//	events := cal.Events(time.Now(), time.Now().AddDate(0, 0, 7), loc)
+//
+// Recurring events are expanded with their RRULE, RDATE and EXDATE
+// properties, and single occurrences moved or cancelled with a RECURRENCE-ID
+// replace the ones they override. Times with a TZID are read in that time
+// zone; floating times, and times in zones Go does not know, are read in the
+// location passed to Events.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// Event is an occurrence of a calendar event.
+type Event struct {
+	UID      string
+	Summary  string
+	Location string
+
+	// Start and End are in the location passed to Events. End is exclusive,
+	// so an all-day event ends at midnight the next day.
+	Start time.Time
+	End   time.Time
+
+	// AllDay events span whole days rather than times of day.
+	AllDay bool
+}
+
+// Calendar holds the events of one or more iCalendar files.
+type Calendar struct {
+	events []*vevent
+}
+
+// vevent is a VEVENT component, with its times kept as written until Events
+// reads them in a location.
+type vevent struct {
+	uid, summary, location, status string
+
+	start, end   dateTime
+	duration     *duration
+	rrule        string
+	rdates       []dateTime
+	exdates      []dateTime
+	recurrenceID *dateTime
+}
+
+// dateTime is a DATE or DATE-TIME value and its TZID.
+type dateTime struct {
+	value string
+	tzid  string
+}
+
+// isDate reports whether d is a date without a time, e.g. 20240601.
+func (d dateTime) isDate() bool {
+	return len(d.value) == len("20060102")
+}
+
+// in reads d in its own time zone: UTC, or its TZID if Go knows it, and
+// otherwise loc. Recurring events repeat at the same time of day in that zone.
+func (d dateTime) in(loc *time.Location) (time.Time, error) {
+	switch {
+	case d.isDate():
+		return time.ParseInLocation("20060102", d.value, loc)
+	case strings.HasSuffix(d.value, "Z"):
+		return time.Parse("20060102T150405Z", d.value)
+	}
+
+	zone := loc
+	if d.tzid != "" {
+		if l, err := time.LoadLocation(d.tzid); err == nil {
+			zone = l
+		}
+	}
+	return time.ParseInLocation("20060102T150405", d.value, zone)
+}
+
+// Parse reads an iCalendar file.
+func Parse(r io.Reader) (*Calendar, error) {
+	c := &Calendar{}
+	if err := c.Add(r); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Add reads the events of another iCalendar file into c.
+func (c *Calendar) Add(r io.Reader) error {
+	lines, err := unfold(r)
+	if err != nil {
+		return err
+	}
+
+	var (
+		calendar bool
+		event    *vevent
+		nested   int // depth of components within the event, e.g. VALARM
+	)
+	for i, line := range lines {
+		name, params, value, ok := parseLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			calendar = true
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT") && event == nil:
+			event = &vevent{}
+		case name == "BEGIN" && event != nil:
+			nested++
+		case name == "END" && event != nil && nested > 0:
+			nested--
+		case name == "END" && strings.EqualFold(value, "VEVENT") && event != nil:
+			if event.start.value == "" {
+				return fmt.Errorf("line %d: event %q has no DTSTART", i+1, event.summary)
+			}
+			c.events = append(c.events, event)
+			event = nil
+		case event != nil && nested == 0:
+			if err := event.set(name, params, value); err != nil {
+				return fmt.Errorf("line %d: %w", i+1, err)
+			}
+		}
+	}
+
+	if !calendar {
+		return errors.New("not an iCalendar file")
+	}
+	return nil
+}
+
+// set sets the event property name.
+func (e *vevent) set(name string, params map[string]string, value string) error {
+	switch name {
+	case "UID":
+		e.uid = value
+	case "SUMMARY":
+		e.summary = unescape(value)
+	case "LOCATION":
+		e.location = unescape(value)
+	case "STATUS":
+		e.status = strings.ToUpper(value)
+	case "DTSTART":
+		e.start = dateTime{value, params["TZID"]}
+	case "DTEND":
+		e.end = dateTime{value, params["TZID"]}
+	case "DURATION":
+		d, err := parseDuration(value)
+		if err != nil {
+			return err
+		}
+		e.duration = d
+	case "RRULE":
+		e.rrule = value
+	case "RDATE", "EXDATE":
+		for _, v := range strings.Split(value, ",") {
+			d := dateTime{v, params["TZID"]}
+			if name == "RDATE" {
+				e.rdates = append(e.rdates, d)
+			} else {
+				e.exdates = append(e.exdates, d)
+			}
+		}
+	case "RECURRENCE-ID":
+		e.recurrenceID = &dateTime{value, params["TZID"]}
+	}
+	return nil
+}
+
+// Events returns the occurrences of the events overlapping from to to, in
+// loc, sorted by start with all-day events first. Cancelled events are left
+// out, as are events whose times cannot be read.
+func (c *Calendar) Events(from, to time.Time, loc *time.Location) []Event {
+	// Occurrences moved or cancelled by another VEVENT with a RECURRENCE-ID
+	overridden := map[string]bool{}
+	for _, e := range c.events {
+		if e.recurrenceID == nil {
+			continue
+		}
+		if t, err := e.recurrenceID.in(loc); err == nil {
+			overridden[occurrenceKey(e.uid, t)] = true
+		}
+	}
+
+	var events []Event
+	for _, e := range c.events {
+		if e.status == "CANCELLED" {
+			continue
+		}
+		starts, length, err := e.occurrences(from, to, loc)
+		if err != nil {
+			continue
+		}
+		for _, start := range starts {
+			if e.recurrenceID == nil && overridden[occurrenceKey(e.uid, start)] {
+				continue
+			}
+			end := length.after(start)
+			overlaps := start.Before(to) && (end.After(from) || end.Equal(start) && !start.Before(from))
+			if !overlaps {
+				continue
+			}
+			events = append(events, Event{
+				UID:      e.uid,
+				Summary:  e.summary,
+				Location: e.location,
+				Start:    start.In(loc),
+				End:      end.In(loc),
+				AllDay:   e.start.isDate(),
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if !a.Start.Equal(b.Start) {
+			return a.Start.Before(b.Start)
+		}
+		return a.AllDay && !b.AllDay
+	})
+	return events
+}
+
+// occurrenceKey identifies an occurrence of a recurring event.
+func occurrenceKey(uid string, start time.Time) string {
+	return uid + "@" + strconv.FormatInt(start.Unix(), 10)
+}
+
+// occurrences returns the starts of the event's occurrences that may overlap
+// from to to, and how long each lasts.
+func (e *vevent) occurrences(from, to time.Time, loc *time.Location) ([]time.Time, duration, error) {
+	start, err := e.start.in(loc)
+	if err != nil {
+		return nil, duration{}, err
+	}
+
+	var length duration
+	switch {
+	case e.end.value != "":
+		end, err := e.end.in(loc)
+		if err != nil {
+			return nil, duration{}, err
+		}
+		if e.start.isDate() {
+			length.days = int(end.Sub(start).Round(24*time.Hour) / (24 * time.Hour))
+		} else {
+			length.clock = end.Sub(start)
+		}
+	case e.duration != nil:
+		length = *e.duration
+	case e.start.isDate():
+		length.days = 1
+	}
+
+	if e.rrule == "" && len(e.rdates) == 0 {
+		return []time.Time{start}, length, nil
+	}
+
+	var set rrule.Set
+	if e.rrule != "" {
+		opt, err := rrule.StrToROptionInLocation(e.rrule, start.Location())
+		if err != nil {
+			return nil, duration{}, fmt.Errorf("invalid RRULE: %w", err)
+		}
+		opt.Dtstart = start
+		r, err := rrule.NewRRule(*opt)
+		if err != nil {
+			return nil, duration{}, fmt.Errorf("invalid RRULE: %w", err)
+		}
+		set.RRule(r)
+	}
+	// DTSTART is always the first occurrence, even if the rule skips it
+	set.RDate(start)
+	for _, d := range e.rdates {
+		if t, err := d.in(loc); err == nil {
+			set.RDate(t)
+		}
+	}
+	for _, d := range e.exdates {
+		if t, err := d.in(loc); err == nil {
+			set.ExDate(t)
+		}
+	}
+
+	// Look back far enough to catch occurrences still going at from, with an
+	// hour to spare for daylight saving time changes
+	span := time.Duration(length.days)*24*time.Hour + length.clock + time.Hour
+	return set.Between(from.Add(-span), to, true), length, nil
+}
+
+// duration is an iCalendar DURATION: nominal days, which follow daylight
+// saving time changes, plus a clock duration.
+type duration struct {
+	days  int
+	clock time.Duration
+}
+
+// after returns the time d after t.
+func (d duration) after(t time.Time) time.Time {
+	return t.AddDate(0, 0, d.days).Add(d.clock)
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration parses a DURATION such as PT1H30M or P2D.
+func parseDuration(s string) (*duration, error) {
+	m := durationPattern.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return nil, fmt.Errorf("invalid DURATION %q", s)
+	}
+	n := func(i int) int {
+		v, _ := strconv.Atoi(m[i])
+		return v
+	}
+
+	d := &duration{
+		days:  n(2)*7 + n(3),
+		clock: time.Duration(n(4))*time.Hour + time.Duration(n(5))*time.Minute + time.Duration(n(6))*time.Second,
+	}
+	if m[1] == "-" {
+		d.days, d.clock = -d.days, -d.clock
+	}
+	return d, nil
+}
+
+// unfold reads the content lines of r, joining lines folded onto the next
+// with a leading space or tab.
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// parseLine splits a content line such as
+// DTSTART;TZID=America/Chicago:20240601T090000 into its upper-cased name,
+// parameters and value.
+func parseLine(line string) (name string, params map[string]string, value string, ok bool) {
+	// The value starts at the first colon outside quoted parameter values
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+// unescape decodes the backslash escapes of a TEXT value.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
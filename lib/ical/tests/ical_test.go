@@ -0,0 +1,154 @@
+package ical_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/ical"
+)
+
+var chicago = mustLoadLocation("America/Chicago")
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// load parses the fixtures into one calendar.
+func load(t *testing.T, names ...string) *ical.Calendar {
+	t.Helper()
+	cal := &ical.Calendar{}
+	for _, name := range names {
+		f, err := os.Open(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if err := cal.Add(f); err != nil {
+			t.Fatalf("Add %s failed: %v", name, err)
+		}
+	}
+	return cal
+}
+
+// day returns midnight on the day in Chicago.
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, chicago)
+}
+
+// summarize lists the events as "Mon 15:04 Summary", or "Mon all day
+// Summary".
+func summarize(events []ical.Event) []string {
+	var s []string
+	for _, e := range events {
+		when := e.Start.Format("Mon 15:04")
+		if e.AllDay {
+			when = e.Start.Format("Mon") + " all day"
+		}
+		s = append(s, when+" "+e.Summary)
+	}
+	return s
+}
+
+// withUID returns the occurrences of one event.
+func withUID(events []ical.Event, uid string) []ical.Event {
+	var matching []ical.Event
+	for _, e := range events {
+		if e.UID == uid {
+			matching = append(matching, e)
+		}
+	}
+	return matching
+}
+
+func expectEvents(t *testing.T, got []ical.Event, want ...string) {
+	t.Helper()
+	if g := strings.Join(summarize(got), "\n"); g != strings.Join(want, "\n") {
+		t.Errorf("Expected events:\n%s\ngot:\n%s", strings.Join(want, "\n"), g)
+	}
+}
+
+func TestEvents_Recurring(t *testing.T) {
+	cal := load(t, "team.ics")
+	standups := withUID(cal.Events(day(2024, 6, 3), day(2024, 6, 15), chicago), "standup@example.com")
+
+	// Wednesday is excluded, Friday moved to 11:00 New York time and the
+	// next Monday cancelled
+	expectEvents(t, standups,
+		"Mon 08:30 Standup",
+		"Fri 10:00 Standup (moved)",
+		"Wed 08:30 Standup",
+		"Fri 08:30 Standup",
+	)
+	if e := standups[0]; e.Location != "Room 4, 2nd floor" || e.End.Sub(e.Start) != 15*time.Minute {
+		t.Errorf("Unexpected standup %+v", e)
+	}
+}
+
+func TestEvents_Day(t *testing.T) {
+	cal := load(t, "team.ics", "personal.ics")
+
+	// All-day events first, then by time; the cancelled concert is left out
+	expectEvents(t, cal.Events(day(2024, 6, 4), day(2024, 6, 5), chicago),
+		"Tue all day Dentist",
+		"Tue all day Sam's birthday",
+		"Tue 09:00 Quarterly planning, Q3 review with the whole team and our friends from sales",
+		"Tue 18:00 Dinner",
+	)
+
+	// A multi-day event overlaps each of its days, ending at midnight after
+	// the last
+	events := cal.Events(day(2024, 6, 8), day(2024, 6, 9), chicago)
+	expectEvents(t, events, "Thu all day Vacation")
+	if !events[0].End.Equal(day(2024, 6, 9)) {
+		t.Errorf("Expected the vacation to end at midnight on Sunday, got %v", events[0].End)
+	}
+
+	// Events from an hour ago still count until they end
+	from := time.Date(2024, 6, 4, 19, 0, 0, 0, chicago)
+	expectEvents(t, cal.Events(from, day(2024, 6, 5), chicago),
+		"Tue all day Dentist",
+		"Tue all day Sam's birthday",
+		"Tue 18:00 Dinner",
+	)
+}
+
+func TestEvents_TimeZones(t *testing.T) {
+	cal := load(t, "team.ics")
+
+	// London's clocks change a week before Chicago's, so the 09:00 London
+	// meeting moves an hour later in Chicago for a week
+	events := cal.Events(day(2024, 10, 22), day(2024, 11, 6), chicago)
+	expectEvents(t, withUID(events, "london@example.com"),
+		"Tue 03:00 London sync",
+		"Tue 04:00 London sync",
+		"Tue 03:00 London sync",
+	)
+
+	// The same events read in another location
+	tokyo := mustLoadLocation("Asia/Tokyo")
+	events = cal.Events(events[0].Start, events[0].End, tokyo)
+	expectEvents(t, events, "Tue 17:00 London sync")
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name, ics, err string
+	}{
+		{"html", "<html><body>Sign in</body></html>", "not an iCalendar file"},
+		{"no start", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Lunch\nEND:VEVENT\nEND:VCALENDAR", `"Lunch" has no DTSTART`},
+		{"bad duration", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20240601T120000\nDURATION:1 hour\nEND:VEVENT\nEND:VCALENDAR", "line 4: invalid DURATION"},
+	}
+	for _, tt := range tests {
+		_, err := ical.Parse(strings.NewReader(tt.ics))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected an error containing %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
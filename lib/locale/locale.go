@@ -0,0 +1,218 @@
+// Package locale formats dates, times and numbers, and translates the few
+// labels drawn on dashboards, in the languages bundled with epd.
+//
+// Usage:
+//
+//	l, err := locale.Lookup("de")
+//	l.LongDate(t)          // "Sonntag, 18. Oktober"
+//	l.MonthYear(t)         // "Oktober 2026"
+//	l.Percent(60)          // "60 %"
+//	l.Translate("Today")   // "Heute"
+//
+// Every bundled language is written in the Latin, Cyrillic or Greek script,
+// which the Go fonts used by the dashboard cover.
+package locale
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Locale is how a language writes dates, times and numbers.
+type Locale struct {
+	// Tag identifies the locale, e.g. "de" or "en-GB".
+	Tag string
+
+	// Days, ShortDays and MinDays name the weekdays from Sunday: in full,
+	// abbreviated, e.g. for forecasts, and in two letters where possible, for
+	// the month grid.
+	Days      [7]string
+	ShortDays [7]string
+	MinDays   [7]string
+
+	// Months and ShortMonths name the months from January. GenitiveMonths, if
+	// set, are the forms used after a day, e.g. Russian "18 октября".
+	Months         [12]string
+	ShortMonths    [12]string
+	GenitiveMonths [12]string
+
+	// DateLayout, LongDateLayout and MonthYearLayout arrange dates with the
+	// placeholders {weekday}, {wd} (short weekday), {d}, {dd}, {m}, {mm},
+	// {yyyy}, {month} and {mon} (short month), e.g. "{dd}.{mm}.{yyyy}".
+	DateLayout      string
+	LongDateLayout  string
+	MonthYearLayout string
+
+	// TimeLayout and HourLayout are Go time layouts for times of day and
+	// hours, e.g. "15:04" and "15h".
+	TimeLayout string
+	HourLayout string
+
+	// Decimal and Thousands separate the digits of numbers, and
+	// PercentLayout places a percentage's number, {n}.
+	Decimal       string
+	Thousands     string
+	PercentLayout string
+
+	// FirstDay starts the week.
+	FirstDay time.Weekday
+
+	// Labels translate the labels drawn on dashboards, keyed by their English
+	// text, e.g. "Today" or "+{n} more".
+	Labels map[string]string
+}
+
+// Lookup returns the bundled locale for tag, e.g. "fr", "en-GB" or "pt_BR",
+// falling back to the tag's language when the region isn't bundled.
+func Lookup(tag string) (*Locale, error) {
+	key := strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	if l, ok := locales[key]; ok {
+		return l, nil
+	}
+	lang, _, _ := strings.Cut(key, "-")
+	if l, ok := locales[aliases[lang]]; ok {
+		return l, nil
+	}
+	if l, ok := locales[lang]; ok {
+		return l, nil
+	}
+	return nil, fmt.Errorf("unknown locale %q, expected one of %s", tag, strings.Join(Tags(), ", "))
+}
+
+// Tags returns the tags of the bundled locales, sorted.
+func Tags() []string {
+	tags := make([]string, 0, len(locales))
+	for _, l := range locales {
+		tags = append(tags, l.Tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Language returns the locale's language, e.g. "en" for "en-GB".
+func (l *Locale) Language() string {
+	lang, _, _ := strings.Cut(l.Tag, "-")
+	return lang
+}
+
+// Weekday returns the name of d, e.g. "Montag".
+func (l *Locale) Weekday(d time.Weekday) string {
+	return l.Days[d]
+}
+
+// ShortWeekday returns the abbreviated name of d, e.g. "Mo".
+func (l *Locale) ShortWeekday(d time.Weekday) string {
+	return l.ShortDays[d]
+}
+
+// MinWeekday returns the shortest name of d, e.g. "Mo".
+func (l *Locale) MinWeekday(d time.Weekday) string {
+	return l.MinDays[d]
+}
+
+// Month returns the name of m, e.g. "Oktober".
+func (l *Locale) Month(m time.Month) string {
+	return l.Months[m-1]
+}
+
+// ShortMonth returns the abbreviated name of m, e.g. "Okt".
+func (l *Locale) ShortMonth(m time.Month) string {
+	return l.ShortMonths[m-1]
+}
+
+// Date formats t's date in numbers, e.g. "18.10.2026".
+func (l *Locale) Date(t time.Time) string {
+	return l.format(l.DateLayout, t, l.Months)
+}
+
+// LongDate formats t's weekday, day and month, e.g. "Sonntag, 18. Oktober".
+func (l *Locale) LongDate(t time.Time) string {
+	months := l.Months
+	if l.GenitiveMonths[0] != "" {
+		months = l.GenitiveMonths
+	}
+	return l.format(l.LongDateLayout, t, months)
+}
+
+// MonthYear formats t's month and year, e.g. "Oktober 2026".
+func (l *Locale) MonthYear(t time.Time) string {
+	return l.format(l.MonthYearLayout, t, l.Months)
+}
+
+// Time formats t's time of day, e.g. "15:04" or "3:04 PM".
+func (l *Locale) Time(t time.Time) string {
+	return t.Format(l.TimeLayout)
+}
+
+// Hour formats t's hour, e.g. "15 Uhr" or "3PM".
+func (l *Locale) Hour(t time.Time) string {
+	return t.Format(l.HourLayout)
+}
+
+// format fills in the placeholders of layout with t, naming months with
+// months.
+func (l *Locale) format(layout string, t time.Time, months [12]string) string {
+	return strings.NewReplacer(
+		"{weekday}", l.Days[t.Weekday()],
+		"{wd}", l.ShortDays[t.Weekday()],
+		"{dd}", fmt.Sprintf("%02d", t.Day()),
+		"{d}", strconv.Itoa(t.Day()),
+		"{mm}", fmt.Sprintf("%02d", t.Month()),
+		"{m}", strconv.Itoa(int(t.Month())),
+		"{yyyy}", strconv.Itoa(t.Year()),
+		"{month}", months[t.Month()-1],
+		"{mon}", l.ShortMonths[t.Month()-1],
+	).Replace(layout)
+}
+
+// Number formats f with the given number of decimals, e.g. "1.234,5".
+func (l *Locale) Number(f float64, decimals int) string {
+	s := strconv.FormatFloat(math.Abs(f), 'f', decimals, 64)
+	whole, fraction, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	if f < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteString("-")
+	}
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(l.Thousands)
+		}
+		b.WriteRune(r)
+	}
+	if fraction != "" {
+		b.WriteString(l.Decimal + fraction)
+	}
+	return b.String()
+}
+
+// Percent formats a percentage, from 0 to 100, in whole numbers, e.g. "60 %".
+func (l *Locale) Percent(f float64) string {
+	return strings.ReplaceAll(l.PercentLayout, "{n}", l.Number(f, 0))
+}
+
+// Heading capitalizes s to stand on its own, e.g. "octobre 2026" as a title.
+func Heading(s string) string {
+	if s == "" {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// Translate returns the locale's translation of an English label, or the
+// label itself without one. Labels with a number take it as {n}, e.g.
+//
+//	strings.ReplaceAll(l.Translate("+{n} more"), "{n}", "3")
+func (l *Locale) Translate(label string) string {
+	if s, ok := l.Labels[label]; ok {
+		return s
+	}
+	return label
+}
@@ -0,0 +1,509 @@
+package locale
+
+import (
+	"strings"
+	"time"
+)
+
+// aliases maps other codes for a language to its bundled locale.
+var aliases = map[string]string{
+	"no": "nb",
+}
+
+// locales are the bundled locales, keyed by lower-cased tag.
+var locales = map[string]*Locale{}
+
+func init() {
+	for _, l := range []*Locale{
+		{
+			Tag:             "en",
+			Days:            [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+			ShortDays:       [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+			MinDays:         [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+			Months:          [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+			ShortMonths:     [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+			DateLayout:      "{mm}/{dd}/{yyyy}",
+			LongDateLayout:  "{weekday}, {month} {d}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "3:04 PM",
+			HourLayout:      "3PM",
+			Decimal:         ".",
+			Thousands:       ",",
+			PercentLayout:   "{n}%",
+			FirstDay:        time.Sunday,
+		},
+		{
+			Tag:             "en-GB",
+			Days:            [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+			ShortDays:       [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+			MinDays:         [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+			Months:          [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+			ShortMonths:     [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+			DateLayout:      "{dd}/{mm}/{yyyy}",
+			LongDateLayout:  "{weekday} {d} {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15:00",
+			Decimal:         ".",
+			Thousands:       ",",
+			PercentLayout:   "{n}%",
+			FirstDay:        time.Monday,
+		},
+		{
+			Tag:             "de",
+			Days:            [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+			ShortDays:       [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+			MinDays:         [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+			Months:          [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+			ShortMonths:     [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+			DateLayout:      "{dd}.{mm}.{yyyy}",
+			LongDateLayout:  "{weekday}, {d}. {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15 Uhr",
+			Decimal:         ",",
+			Thousands:       ".",
+			PercentLayout:   "{n}\u00a0%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "Heute",
+				"Tomorrow":             "Morgen",
+				"All day":              "Ganztägig",
+				"No events":            "Keine Termine",
+				"+{n} more":            "+{n} weitere",
+				"calendar unavailable": "Kalender nicht verfügbar",
+				"stale since {time}":   "veraltet seit {time}",
+				"weather unavailable":  "Wetter nicht verfügbar",
+				"Wk":                   "KW",
+			},
+		},
+		{
+			Tag:             "fr",
+			Days:            [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+			ShortDays:       [7]string{"dim", "lun", "mar", "mer", "jeu", "ven", "sam"},
+			MinDays:         [7]string{"di", "lu", "ma", "me", "je", "ve", "sa"},
+			Months:          [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+			ShortMonths:     [12]string{"janv", "févr", "mars", "avr", "mai", "juin", "juil", "août", "sept", "oct", "nov", "déc"},
+			DateLayout:      "{dd}/{mm}/{yyyy}",
+			LongDateLayout:  "{weekday} {d} {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15h",
+			Decimal:         ",",
+			Thousands:       "\u00a0",
+			PercentLayout:   "{n}\u00a0%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "Aujourd’hui",
+				"Tomorrow":             "Demain",
+				"All day":              "Journée",
+				"No events":            "Aucun événement",
+				"+{n} more":            "+{n} autres",
+				"calendar unavailable": "calendrier indisponible",
+				"stale since {time}":   "non actualisé depuis {time}",
+				"weather unavailable":  "météo indisponible",
+				"Wk":                   "Sem",
+			},
+		},
+		{
+			Tag:             "es",
+			Days:            [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+			ShortDays:       [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+			MinDays:         [7]string{"do", "lu", "ma", "mi", "ju", "vi", "sá"},
+			Months:          [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+			ShortMonths:     [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+			DateLayout:      "{dd}/{mm}/{yyyy}",
+			LongDateLayout:  "{weekday}, {d} de {month}",
+			MonthYearLayout: "{month} de {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15:00",
+			Decimal:         ",",
+			Thousands:       ".",
+			PercentLayout:   "{n}\u00a0%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "Hoy",
+				"Tomorrow":             "Mañana",
+				"All day":              "Todo el día",
+				"No events":            "Sin eventos",
+				"+{n} more":            "+{n} más",
+				"calendar unavailable": "calendario no disponible",
+				"stale since {time}":   "sin actualizar desde las {time}",
+				"weather unavailable":  "tiempo no disponible",
+				"Wk":                   "Sem",
+			},
+		},
+		{
+			Tag:             "it",
+			Days:            [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+			ShortDays:       [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+			MinDays:         [7]string{"do", "lu", "ma", "me", "gi", "ve", "sa"},
+			Months:          [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+			ShortMonths:     [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+			DateLayout:      "{dd}/{mm}/{yyyy}",
+			LongDateLayout:  "{weekday} {d} {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15:00",
+			Decimal:         ",",
+			Thousands:       ".",
+			PercentLayout:   "{n}%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "Oggi",
+				"Tomorrow":             "Domani",
+				"All day":              "Tutto il giorno",
+				"No events":            "Nessun evento",
+				"+{n} more":            "+{n} altri",
+				"calendar unavailable": "calendario non disponibile",
+				"stale since {time}":   "non aggiornato dalle {time}",
+				"weather unavailable":  "meteo non disponibile",
+				"Wk":                   "Sett",
+			},
+		},
+		{
+			Tag:             "pt",
+			Days:            [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+			ShortDays:       [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+			MinDays:         [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+			Months:          [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+			ShortMonths:     [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+			DateLayout:      "{dd}/{mm}/{yyyy}",
+			LongDateLayout:  "{weekday}, {d} de {month}",
+			MonthYearLayout: "{month} de {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15h",
+			Decimal:         ",",
+			Thousands:       ".",
+			PercentLayout:   "{n}%",
+			FirstDay:        time.Sunday,
+			Labels: map[string]string{
+				"Today":                "Hoje",
+				"Tomorrow":             "Amanhã",
+				"All day":              "Dia inteiro",
+				"No events":            "Sem eventos",
+				"+{n} more":            "+{n} mais",
+				"calendar unavailable": "calendário indisponível",
+				"stale since {time}":   "desatualizado desde {time}",
+				"weather unavailable":  "tempo indisponível",
+				"Wk":                   "Sem",
+			},
+		},
+		{
+			Tag:             "nl",
+			Days:            [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+			ShortDays:       [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+			MinDays:         [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+			Months:          [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+			ShortMonths:     [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+			DateLayout:      "{dd}-{mm}-{yyyy}",
+			LongDateLayout:  "{weekday} {d} {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15:00",
+			Decimal:         ",",
+			Thousands:       ".",
+			PercentLayout:   "{n}%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "Vandaag",
+				"Tomorrow":             "Morgen",
+				"All day":              "Hele dag",
+				"No events":            "Geen afspraken",
+				"+{n} more":            "+{n} meer",
+				"calendar unavailable": "agenda niet beschikbaar",
+				"stale since {time}":   "verouderd sinds {time}",
+				"weather unavailable":  "weer niet beschikbaar",
+				"Wk":                   "Wk",
+			},
+		},
+		{
+			Tag:             "sv",
+			Days:            [7]string{"söndag", "måndag", "tisdag", "onsdag", "torsdag", "fredag", "lördag"},
+			ShortDays:       [7]string{"sön", "mån", "tis", "ons", "tors", "fre", "lör"},
+			MinDays:         [7]string{"sö", "må", "ti", "on", "to", "fr", "lö"},
+			Months:          [12]string{"januari", "februari", "mars", "april", "maj", "juni", "juli", "augusti", "september", "oktober", "november", "december"},
+			ShortMonths:     [12]string{"jan", "feb", "mars", "apr", "maj", "juni", "juli", "aug", "sep", "okt", "nov", "dec"},
+			DateLayout:      "{yyyy}-{mm}-{dd}",
+			LongDateLayout:  "{weekday} {d} {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15:00",
+			Decimal:         ",",
+			Thousands:       "\u00a0",
+			PercentLayout:   "{n}\u00a0%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "Idag",
+				"Tomorrow":             "Imorgon",
+				"All day":              "Heldag",
+				"No events":            "Inga händelser",
+				"+{n} more":            "+{n} till",
+				"calendar unavailable": "kalender otillgänglig",
+				"stale since {time}":   "inaktuell sedan {time}",
+				"weather unavailable":  "väder otillgängligt",
+				"Wk":                   "V",
+			},
+		},
+		{
+			Tag:             "da",
+			Days:            [7]string{"søndag", "mandag", "tirsdag", "onsdag", "torsdag", "fredag", "lørdag"},
+			ShortDays:       [7]string{"søn", "man", "tir", "ons", "tor", "fre", "lør"},
+			MinDays:         [7]string{"sø", "ma", "ti", "on", "to", "fr", "lø"},
+			Months:          [12]string{"januar", "februar", "marts", "april", "maj", "juni", "juli", "august", "september", "oktober", "november", "december"},
+			ShortMonths:     [12]string{"jan", "feb", "mar", "apr", "maj", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+			DateLayout:      "{dd}.{mm}.{yyyy}",
+			LongDateLayout:  "{weekday} {d}. {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15.04",
+			HourLayout:      "15.00",
+			Decimal:         ",",
+			Thousands:       ".",
+			PercentLayout:   "{n}\u00a0%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "I dag",
+				"Tomorrow":             "I morgen",
+				"All day":              "Hele dagen",
+				"No events":            "Ingen begivenheder",
+				"+{n} more":            "+{n} mere",
+				"calendar unavailable": "kalender utilgængelig",
+				"stale since {time}":   "forældet siden {time}",
+				"weather unavailable":  "vejr utilgængeligt",
+				"Wk":                   "Uge",
+			},
+		},
+		{
+			Tag:             "nb",
+			Days:            [7]string{"søndag", "mandag", "tirsdag", "onsdag", "torsdag", "fredag", "lørdag"},
+			ShortDays:       [7]string{"søn", "man", "tir", "ons", "tor", "fre", "lør"},
+			MinDays:         [7]string{"sø", "ma", "ti", "on", "to", "fr", "lø"},
+			Months:          [12]string{"januar", "februar", "mars", "april", "mai", "juni", "juli", "august", "september", "oktober", "november", "desember"},
+			ShortMonths:     [12]string{"jan", "feb", "mar", "apr", "mai", "jun", "jul", "aug", "sep", "okt", "nov", "des"},
+			DateLayout:      "{dd}.{mm}.{yyyy}",
+			LongDateLayout:  "{weekday} {d}. {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15:00",
+			Decimal:         ",",
+			Thousands:       "\u00a0",
+			PercentLayout:   "{n}\u00a0%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "I dag",
+				"Tomorrow":             "I morgen",
+				"All day":              "Hele dagen",
+				"No events":            "Ingen hendelser",
+				"+{n} more":            "+{n} til",
+				"calendar unavailable": "kalender utilgjengelig",
+				"stale since {time}":   "utdatert siden {time}",
+				"weather unavailable":  "vær utilgjengelig",
+				"Wk":                   "Uke",
+			},
+		},
+		{
+			Tag:             "fi",
+			Days:            [7]string{"sunnuntai", "maanantai", "tiistai", "keskiviikko", "torstai", "perjantai", "lauantai"},
+			ShortDays:       [7]string{"su", "ma", "ti", "ke", "to", "pe", "la"},
+			MinDays:         [7]string{"su", "ma", "ti", "ke", "to", "pe", "la"},
+			Months:          [12]string{"tammikuu", "helmikuu", "maaliskuu", "huhtikuu", "toukokuu", "kesäkuu", "heinäkuu", "elokuu", "syyskuu", "lokakuu", "marraskuu", "joulukuu"},
+			ShortMonths:     [12]string{"tammi", "helmi", "maalis", "huhti", "touko", "kesä", "heinä", "elo", "syys", "loka", "marras", "joulu"},
+			GenitiveMonths:  [12]string{"tammikuuta", "helmikuuta", "maaliskuuta", "huhtikuuta", "toukokuuta", "kesäkuuta", "heinäkuuta", "elokuuta", "syyskuuta", "lokakuuta", "marraskuuta", "joulukuuta"},
+			DateLayout:      "{d}.{m}.{yyyy}",
+			LongDateLayout:  "{weekday} {d}. {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15.04",
+			HourLayout:      "15.00",
+			Decimal:         ",",
+			Thousands:       "\u00a0",
+			PercentLayout:   "{n}\u00a0%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "Tänään",
+				"Tomorrow":             "Huomenna",
+				"All day":              "Koko päivän",
+				"No events":            "Ei tapahtumia",
+				"+{n} more":            "+{n} lisää",
+				"calendar unavailable": "kalenteri ei saatavilla",
+				"stale since {time}":   "vanhentunut klo {time} alkaen",
+				"weather unavailable":  "sää ei saatavilla",
+				"Wk":                   "Vk",
+			},
+		},
+		{
+			Tag:             "pl",
+			Days:            [7]string{"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+			ShortDays:       [7]string{"niedz", "pon", "wt", "śr", "czw", "pt", "sob"},
+			MinDays:         [7]string{"nd", "pn", "wt", "śr", "cz", "pt", "sb"},
+			Months:          [12]string{"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec", "lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"},
+			ShortMonths:     [12]string{"sty", "lut", "mar", "kwi", "maj", "cze", "lip", "sie", "wrz", "paź", "lis", "gru"},
+			GenitiveMonths:  [12]string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca", "lipca", "sierpnia", "września", "października", "listopada", "grudnia"},
+			DateLayout:      "{dd}.{mm}.{yyyy}",
+			LongDateLayout:  "{weekday}, {d} {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15:00",
+			Decimal:         ",",
+			Thousands:       "\u00a0",
+			PercentLayout:   "{n}%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "Dzisiaj",
+				"Tomorrow":             "Jutro",
+				"All day":              "Cały dzień",
+				"No events":            "Brak wydarzeń",
+				"+{n} more":            "+{n} więcej",
+				"calendar unavailable": "kalendarz niedostępny",
+				"stale since {time}":   "nieaktualne od {time}",
+				"weather unavailable":  "pogoda niedostępna",
+				"Wk":                   "Tydz",
+			},
+		},
+		{
+			Tag:             "cs",
+			Days:            [7]string{"neděle", "pondělí", "úterý", "středa", "čtvrtek", "pátek", "sobota"},
+			ShortDays:       [7]string{"ne", "po", "út", "st", "čt", "pá", "so"},
+			MinDays:         [7]string{"ne", "po", "út", "st", "čt", "pá", "so"},
+			Months:          [12]string{"leden", "únor", "březen", "duben", "květen", "červen", "červenec", "srpen", "září", "říjen", "listopad", "prosinec"},
+			ShortMonths:     [12]string{"led", "úno", "bře", "dub", "kvě", "čvn", "čvc", "srp", "zář", "říj", "lis", "pro"},
+			GenitiveMonths:  [12]string{"ledna", "února", "března", "dubna", "května", "června", "července", "srpna", "září", "října", "listopadu", "prosince"},
+			DateLayout:      "{d}. {m}. {yyyy}",
+			LongDateLayout:  "{weekday} {d}. {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15:00",
+			Decimal:         ",",
+			Thousands:       "\u00a0",
+			PercentLayout:   "{n}\u00a0%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "Dnes",
+				"Tomorrow":             "Zítra",
+				"All day":              "Celý den",
+				"No events":            "Žádné události",
+				"+{n} more":            "+{n} další",
+				"calendar unavailable": "kalendář nedostupný",
+				"stale since {time}":   "neaktuální od {time}",
+				"weather unavailable":  "počasí nedostupné",
+				"Wk":                   "Týd",
+			},
+		},
+		{
+			Tag:             "tr",
+			Days:            [7]string{"Pazar", "Pazartesi", "Salı", "Çarşamba", "Perşembe", "Cuma", "Cumartesi"},
+			ShortDays:       [7]string{"Paz", "Pzt", "Sal", "Çar", "Per", "Cum", "Cmt"},
+			MinDays:         [7]string{"Pa", "Pt", "Sa", "Ça", "Pe", "Cu", "Ct"},
+			Months:          [12]string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"},
+			ShortMonths:     [12]string{"Oca", "Şub", "Mar", "Nis", "May", "Haz", "Tem", "Ağu", "Eyl", "Eki", "Kas", "Ara"},
+			DateLayout:      "{dd}.{mm}.{yyyy}",
+			LongDateLayout:  "{d} {month} {weekday}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15:00",
+			Decimal:         ",",
+			Thousands:       ".",
+			PercentLayout:   "%{n}",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "Bugün",
+				"Tomorrow":             "Yarın",
+				"All day":              "Tüm gün",
+				"No events":            "Etkinlik yok",
+				"+{n} more":            "+{n} daha",
+				"calendar unavailable": "takvim kullanılamıyor",
+				"stale since {time}":   "{time} itibarıyla güncel değil",
+				"weather unavailable":  "hava durumu kullanılamıyor",
+				"Wk":                   "Hf",
+			},
+		},
+		{
+			Tag:             "ru",
+			Days:            [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+			ShortDays:       [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+			MinDays:         [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+			Months:          [12]string{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
+			ShortMonths:     [12]string{"янв", "фев", "мар", "апр", "май", "июн", "июл", "авг", "сен", "окт", "ноя", "дек"},
+			GenitiveMonths:  [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+			DateLayout:      "{dd}.{mm}.{yyyy}",
+			LongDateLayout:  "{weekday}, {d} {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15:00",
+			Decimal:         ",",
+			Thousands:       "\u00a0",
+			PercentLayout:   "{n}\u00a0%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "Сегодня",
+				"Tomorrow":             "Завтра",
+				"All day":              "Весь день",
+				"No events":            "Нет событий",
+				"+{n} more":            "ещё {n}",
+				"calendar unavailable": "календарь недоступен",
+				"stale since {time}":   "устарело с {time}",
+				"weather unavailable":  "погода недоступна",
+				"Wk":                   "Нед",
+			},
+		},
+		{
+			Tag:             "uk",
+			Days:            [7]string{"неділя", "понеділок", "вівторок", "середа", "четвер", "п’ятниця", "субота"},
+			ShortDays:       [7]string{"нд", "пн", "вт", "ср", "чт", "пт", "сб"},
+			MinDays:         [7]string{"нд", "пн", "вт", "ср", "чт", "пт", "сб"},
+			Months:          [12]string{"січень", "лютий", "березень", "квітень", "травень", "червень", "липень", "серпень", "вересень", "жовтень", "листопад", "грудень"},
+			ShortMonths:     [12]string{"січ", "лют", "бер", "кві", "тра", "чер", "лип", "сер", "вер", "жов", "лис", "гру"},
+			GenitiveMonths:  [12]string{"січня", "лютого", "березня", "квітня", "травня", "червня", "липня", "серпня", "вересня", "жовтня", "листопада", "грудня"},
+			DateLayout:      "{dd}.{mm}.{yyyy}",
+			LongDateLayout:  "{weekday}, {d} {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15:00",
+			Decimal:         ",",
+			Thousands:       "\u00a0",
+			PercentLayout:   "{n}%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "Сьогодні",
+				"Tomorrow":             "Завтра",
+				"All day":              "Весь день",
+				"No events":            "Немає подій",
+				"+{n} more":            "ще {n}",
+				"calendar unavailable": "календар недоступний",
+				"stale since {time}":   "застаріло з {time}",
+				"weather unavailable":  "погода недоступна",
+				"Wk":                   "Тиж",
+			},
+		},
+		{
+			Tag:             "el",
+			Days:            [7]string{"Κυριακή", "Δευτέρα", "Τρίτη", "Τετάρτη", "Πέμπτη", "Παρασκευή", "Σάββατο"},
+			ShortDays:       [7]string{"Κυρ", "Δευ", "Τρί", "Τετ", "Πέμ", "Παρ", "Σάβ"},
+			MinDays:         [7]string{"Κυ", "Δε", "Τρ", "Τε", "Πε", "Πα", "Σα"},
+			Months:          [12]string{"Ιανουάριος", "Φεβρουάριος", "Μάρτιος", "Απρίλιος", "Μάιος", "Ιούνιος", "Ιούλιος", "Αύγουστος", "Σεπτέμβριος", "Οκτώβριος", "Νοέμβριος", "Δεκέμβριος"},
+			ShortMonths:     [12]string{"Ιαν", "Φεβ", "Μαρ", "Απρ", "Μαΐ", "Ιουν", "Ιουλ", "Αυγ", "Σεπ", "Οκτ", "Νοε", "Δεκ"},
+			GenitiveMonths:  [12]string{"Ιανουαρίου", "Φεβρουαρίου", "Μαρτίου", "Απριλίου", "Μαΐου", "Ιουνίου", "Ιουλίου", "Αυγούστου", "Σεπτεμβρίου", "Οκτωβρίου", "Νοεμβρίου", "Δεκεμβρίου"},
+			DateLayout:      "{d}/{m}/{yyyy}",
+			LongDateLayout:  "{weekday} {d} {month}",
+			MonthYearLayout: "{month} {yyyy}",
+			TimeLayout:      "15:04",
+			HourLayout:      "15:00",
+			Decimal:         ",",
+			Thousands:       ".",
+			PercentLayout:   "{n}%",
+			FirstDay:        time.Monday,
+			Labels: map[string]string{
+				"Today":                "Σήμερα",
+				"Tomorrow":             "Αύριο",
+				"All day":              "Ολοήμερο",
+				"No events":            "Καμία εκδήλωση",
+				"+{n} more":            "+{n} ακόμη",
+				"calendar unavailable": "ημερολόγιο μη διαθέσιμο",
+				"stale since {time}":   "χωρίς ενημέρωση από {time}",
+				"weather unavailable":  "καιρός μη διαθέσιμος",
+				"Wk":                   "Εβδ",
+			},
+		},
+	} {
+		locales[strings.ToLower(l.Tag)] = l
+	}
+}
@@ -0,0 +1,138 @@
+package locale_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/freetype/truetype"
+	"github.com/justmiles/epd/lib/locale"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// sunday is a Sunday in October.
+var sunday = time.Date(2026, 10, 18, 15, 4, 0, 0, time.UTC)
+
+func mustLookup(t *testing.T, tag string) *locale.Locale {
+	t.Helper()
+	l, err := locale.Lookup(tag)
+	if err != nil {
+		t.Fatalf("Lookup %s failed: %v", tag, err)
+	}
+	return l
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		tag, want string
+	}{
+		{"de", "de"},
+		{"EN", "en"},
+		{"en_GB", "en-GB"},
+		{"en-US", "en"},
+		{"pt-BR", "pt"},
+		{"no", "nb"},
+	}
+	for _, tt := range tests {
+		if l := mustLookup(t, tt.tag); l.Tag != tt.want {
+			t.Errorf("%s: expected locale %s, got %s", tt.tag, tt.want, l.Tag)
+		}
+	}
+
+	if _, err := locale.Lookup("xx"); err == nil || !strings.Contains(err.Error(), "en-GB") {
+		t.Errorf("Expected an error listing the locales, got %v", err)
+	}
+	if n := len(locale.Tags()); n < 12 {
+		t.Errorf("Expected at least 12 locales, got %d", n)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		tag  string
+		got  func(l *locale.Locale) string
+		want string
+	}{
+		{"en", func(l *locale.Locale) string { return l.Date(sunday) }, "10/18/2026"},
+		{"en-GB", func(l *locale.Locale) string { return l.Date(sunday) }, "18/10/2026"},
+		{"sv", func(l *locale.Locale) string { return l.Date(sunday) }, "2026-10-18"},
+		{"en", func(l *locale.Locale) string { return l.LongDate(sunday) }, "Sunday, October 18"},
+		{"de", func(l *locale.Locale) string { return l.LongDate(sunday) }, "Sonntag, 18. Oktober"},
+		{"ru", func(l *locale.Locale) string { return l.LongDate(sunday) }, "воскресенье, 18 октября"},
+		{"ru", func(l *locale.Locale) string { return l.MonthYear(sunday) }, "октябрь 2026"},
+		{"es", func(l *locale.Locale) string { return l.MonthYear(sunday) }, "octubre de 2026"},
+		{"en", func(l *locale.Locale) string { return l.Time(sunday) }, "3:04 PM"},
+		{"fr", func(l *locale.Locale) string { return l.Hour(sunday) }, "15h"},
+		{"en", func(l *locale.Locale) string { return l.Number(1234567.891, 2) }, "1,234,567.89"},
+		{"de", func(l *locale.Locale) string { return l.Number(1234567.891, 2) }, "1.234.567,89"},
+		{"fr", func(l *locale.Locale) string { return l.Number(-1234, 0) }, "-1\u00a0234"},
+		{"de", func(l *locale.Locale) string { return l.Number(-0.4, 0) }, "0"},
+		{"tr", func(l *locale.Locale) string { return l.Percent(60) }, "%60"},
+		{"de", func(l *locale.Locale) string { return l.Translate("Today") }, "Heute"},
+		{"en", func(l *locale.Locale) string { return l.Translate("Today") }, "Today"},
+		{"ru", func(l *locale.Locale) string { return locale.Heading(l.Weekday(time.Sunday)) }, "Воскресенье"},
+	}
+	for _, tt := range tests {
+		if got := tt.got(mustLookup(t, tt.tag)); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.tag, tt.want, got)
+		}
+	}
+}
+
+// TestLocales checks every bundled locale is complete and can be drawn with
+// the dashboard's fonts.
+func TestLocales(t *testing.T) {
+	var fonts []*truetype.Font
+	for _, ttf := range [][]byte{goregular.TTF, gobold.TTF} {
+		f, err := truetype.Parse(ttf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fonts = append(fonts, f)
+	}
+	labels := mustLookup(t, "de").Labels
+
+	for _, tag := range locale.Tags() {
+		l := mustLookup(t, tag)
+
+		text := []string{l.Date(sunday), l.LongDate(sunday), l.MonthYear(sunday), l.Time(sunday), l.Hour(sunday), l.Percent(1234)}
+		for m := time.January; m <= time.December; m++ {
+			text = append(text, l.Month(m), l.ShortMonth(m), l.LongDate(sunday.AddDate(0, int(m), 0)))
+		}
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if l.Weekday(d) == "" || l.ShortWeekday(d) == "" || l.MinWeekday(d) == "" {
+				t.Errorf("%s: %s is missing a name", tag, d)
+			}
+			text = append(text, l.Weekday(d), l.ShortWeekday(d), l.MinWeekday(d))
+		}
+		for _, s := range text {
+			if strings.Contains(s, "{") {
+				t.Errorf("%s: %q has a placeholder left", tag, s)
+			}
+		}
+
+		for label := range labels {
+			s := l.Translate(label)
+			if l.Language() != "en" && s == label && label != "Wk" {
+				t.Errorf("%s: %q is not translated", tag, label)
+			}
+			for _, p := range []string{"{n}", "{time}"} {
+				if strings.Contains(label, p) != strings.Contains(s, p) {
+					t.Errorf("%s: %q does not keep %s", tag, s, p)
+				}
+			}
+			text = append(text, s)
+		}
+
+		for _, s := range text {
+			for _, r := range s {
+				for _, f := range fonts {
+					if f.Index(r) == 0 {
+						t.Errorf("%s: the Go fonts have no glyph for %q in %q", tag, r, s)
+					}
+				}
+			}
+		}
+	}
+}
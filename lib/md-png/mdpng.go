@@ -19,6 +19,7 @@ package mdpng
 import (
 	"io"
 
+	"github.com/justmiles/epd/lib/fonts"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
@@ -33,6 +34,7 @@ type config struct {
 	Height   int // 0 means auto-size to content
 	Padding  int
 	FontSize float64
+	Font     string // "" for the system's fonts
 	DarkMode bool
 }
 
@@ -65,6 +67,13 @@ func WithFontSize(pt float64) Option {
 	return func(c *config) { c.FontSize = pt }
 }
 
+// WithFont sets text in a built-in Go font family, e.g. "go", or the .ttf or
+// .otf file at a path, instead of the system's fonts. Code is set in the
+// monospaced Go font. Default: "" (system fonts).
+func WithFont(family string) Option {
+	return func(c *config) { c.Font = family }
+}
+
 // WithDarkMode enables dark mode (light text on dark background). Default: false.
 func WithDarkMode(on bool) Option {
 	return func(c *config) { c.DarkMode = on }
@@ -77,6 +86,12 @@ func Convert(markdown []byte, w io.Writer, opts ...Option) error {
 		opt(cfg)
 	}
 
+	if cfg.Font != "" {
+		if _, err := fonts.Load(cfg.Font, "", ""); err != nil {
+			return err
+		}
+	}
+
 	// Parse markdown into AST using goldmark.
 	md := goldmark.New(
 		goldmark.WithExtensions(
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/fogleman/gg"
+	"github.com/justmiles/epd/lib/fonts"
 	"github.com/yuin/goldmark/ast"
 	east "github.com/yuin/goldmark/extension/ast"
 )
@@ -191,6 +192,11 @@ func (r *renderer) loadFont(dc *gg.Context, bold, italic bool) {
 		bold = true
 	}
 
+	if r.cfg.Font != "" {
+		dc.SetFontFace(r.font(bold, italic).Face(size))
+		return
+	}
+
 	// fogleman/gg needs a TTF font loaded. We'll use the built-in
 	// basic font as a fallback and try to load system fonts.
 	// For simplicity, use LoadFontFace with common system font paths.
@@ -208,6 +214,29 @@ func (r *renderer) loadFont(dc *gg.Context, bold, italic bool) {
 	}
 }
 
+// font returns the configured font in the desired style, or the monospaced
+// Go font for code. Files and families without the style use their regular
+// font.
+func (r *renderer) font(bold, italic bool) *fonts.Font {
+	family := r.cfg.Font
+	if r.codeInline > 0 || r.inCodeBlock {
+		family = "go-mono"
+	}
+	weight, style := fonts.Regular, fonts.Normal
+	if bold {
+		weight = fonts.Bold
+	}
+	if italic {
+		style = fonts.Italic
+	}
+	if f, err := fonts.Load(family, weight, style); err == nil {
+		return f
+	}
+	// Convert has checked the family's regular font loads
+	f, _ := fonts.Load(family, "", "")
+	return f
+}
+
 // findFont returns a system TTF font path matching the desired style.
 func findFont(bold, italic, mono bool) string {
 	if mono {
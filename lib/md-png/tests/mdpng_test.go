@@ -136,6 +136,7 @@ func TestConvert_Options(t *testing.T) {
 		{"custom width", []mdpng.Option{mdpng.WithWidth(1024)}},
 		{"custom padding", []mdpng.Option{mdpng.WithPadding(40)}},
 		{"custom font size", []mdpng.Option{mdpng.WithFontSize(18)}},
+		{"go font", []mdpng.Option{mdpng.WithFont("go")}},
 		{"all options", []mdpng.Option{
 			mdpng.WithWidth(600),
 			mdpng.WithPadding(30),
@@ -157,6 +158,13 @@ func TestConvert_Options(t *testing.T) {
 	}
 }
 
+func TestConvert_InvalidFont(t *testing.T) {
+	var buf bytes.Buffer
+	if err := mdpng.Convert([]byte("text"), &buf, mdpng.WithFont("/nonexistent/font.ttf")); err == nil {
+		t.Fatal("Expected an error for a missing font file")
+	}
+}
+
 func TestConvert_LongContent(t *testing.T) {
 	var md bytes.Buffer
 	md.WriteString("# Long Document\n\n")
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authorize checks the bearer token carried in the incoming request metadata.
+// Every request is allowed when no token is configured.
+func (s *EPDServer) authorize(ctx context.Context) error {
+	if s.authToken == "" {
+		return nil
+	}
+
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("authorization"); len(v) > 0 {
+			token = strings.TrimPrefix(v[0], "Bearer ")
+		}
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.authToken)) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid or missing token")
+	}
+	return nil
+}
+
+// publicServicePrefix is the method prefix of the health service, which load
+// balancers and probes must be able to call without a token.
+const publicServicePrefix = "/grpc.health.v1.Health/"
+
+// UnaryInterceptor rejects unary RPCs that fail authorization.
+func (s *EPDServer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if strings.HasPrefix(info.FullMethod, publicServicePrefix) {
+			return handler(ctx, req)
+		}
+		if err := s.authorize(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor rejects streaming RPCs that fail authorization.
+func (s *EPDServer) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, publicServicePrefix) {
+			return handler(srv, ss)
+		}
+		if err := s.authorize(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
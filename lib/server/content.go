@@ -0,0 +1,99 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/justmiles/epd/lib/display"
+	pb "github.com/justmiles/epd/proto/epdpb"
+)
+
+// showImage fetches an image from a URL or path on the daemon and displays it.
+func (s *EPDServer) showImage(ctx context.Context, source string) error {
+	pngData, err := display.ReadImageFile(source, s.display.Width(), s.display.Height())
+	if err != nil {
+		return err
+	}
+	_, err = s.DisplayImage(ctx, &pb.DisplayImageRequest{ImageData: pngData})
+	return err
+}
+
+// showMarkdownFile reads a markdown file on the daemon and displays it.
+func (s *EPDServer) showMarkdownFile(ctx context.Context, path string) error {
+	markdown, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	_, err = s.DisplayMarkdown(ctx, &pb.DisplayMarkdownRequest{Markdown: string(markdown)})
+	return err
+}
+
+// showDashboard renders the dashboard, reading its header and body from
+// template files on the daemon when they name one. Only files in the daemon's
+// template directory are read and executed as templates; any other text,
+// including the paths of other files, is shown as is.
+func (s *EPDServer) showDashboard(ctx context.Context, c *pb.DashboardContent) error {
+	header, err := s.dashboardText(ctx, "header", c.GetHeaderText())
+	if err != nil {
+		return err
+	}
+	body, err := s.dashboardText(ctx, "body", c.GetBodyText())
+	if err != nil {
+		return err
+	}
+	_, err = s.RenderDashboard(ctx, &pb.RenderDashboardRequest{
+		HeaderText: header,
+		BodyText:   body,
+	})
+	return err
+}
+
+// dashboardText returns the dashboard's header or body for text: the contents
+// of the template file it names, executed, or text itself.
+func (s *EPDServer) dashboardText(ctx context.Context, name, text string) (string, error) {
+	path, ok := s.templateFile(text)
+	if !ok {
+		return text, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return s.dashboard.ExecuteTemplate(ctx, name, string(bytes.TrimRight(data, "\n")))
+}
+
+// templateFile returns the path of the file name refers to, and whether it is
+// an existing file in the template directory. Symbolic links are followed, so
+// a link in the directory can't reach outside it.
+func (s *EPDServer) templateFile(name string) (string, bool) {
+	if s.templateDir == "" || name == "" {
+		return "", false
+	}
+	dir, err := filepath.EvalSymlinks(s.templateDir)
+	if err != nil {
+		return "", false
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	path, err := filepath.EvalSymlinks(name)
+	if err != nil {
+		return "", false
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return "", false
+	}
+	return path, true
+}
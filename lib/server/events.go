@@ -0,0 +1,184 @@
+package server
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/justmiles/epd/lib/display"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// eventBufferSize is the number of events buffered per subscriber before
+// further events are dropped for that subscriber.
+const eventBufferSize = 64
+
+// eventHub fans out display events to any number of subscribers.
+type eventHub struct {
+	mu   sync.Mutex
+	subs map[chan *pb.Event]struct{}
+}
+
+// subscribe registers a new subscriber. The returned function unsubscribes it.
+func (h *eventHub) subscribe() (<-chan *pb.Event, func()) {
+	ch := make(chan *pb.Event, eventBufferSize)
+
+	h.mu.Lock()
+	if h.subs == nil {
+		h.subs = make(map[chan *pb.Event]struct{})
+	}
+	h.subs[ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subs, ch)
+		h.mu.Unlock()
+	}
+}
+
+// publish delivers an event to every subscriber without blocking. Slow
+// subscribers miss events rather than stall the display.
+func (h *eventHub) publish(e *pb.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subs {
+		select {
+		case ch <- e:
+		default:
+			log.Printf("Dropping %s event for slow subscriber", e.Type)
+		}
+	}
+}
+
+// caller identifies the client behind an RPC.
+type caller struct {
+	id      string
+	address string
+}
+
+// callerFromContext extracts the client identity from an incoming RPC context.
+func callerFromContext(ctx context.Context) caller {
+	var c caller
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		c.address = p.Addr.String()
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(display.ClientIDHeader); len(v) > 0 {
+			c.id = v[0]
+		}
+	}
+	return c
+}
+
+// newEvent builds an event of type t attributed to c.
+func newEvent(t pb.Event_Type, op string, c caller) *pb.Event {
+	return &pb.Event{
+		Type:          t,
+		Time:          timestamppb.Now(),
+		Operation:     op,
+		ClientId:      c.id,
+		ClientAddress: c.address,
+	}
+}
+
+// WatchEvents streams display activity events until the client disconnects.
+func (s *EPDServer) WatchEvents(req *pb.WatchEventsRequest, stream pb.EPDService_WatchEventsServer) error {
+	c := callerFromContext(stream.Context())
+	log.Printf("Received WatchEvents request from %s", c.address)
+
+	events, unsubscribe := s.events.subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e := <-events:
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// enqueue runs fn with exclusive access to the display, publishing queue
+// changes as operations wait and finish.
+func (s *EPDServer) enqueue(ctx context.Context, op string, fn func() error) error {
+	c := callerFromContext(ctx)
+
+	s.queueChanged(op, c, 1)
+	defer s.queueChanged(op, c, -1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return fn()
+}
+
+// refresh runs fn as a queued operation that updates the panel, publishing
+// started, completed and failed events around it.
+func (s *EPDServer) refresh(ctx context.Context, op string, fn func() error) error {
+	c := callerFromContext(ctx)
+
+	return s.enqueue(ctx, op, func() error {
+		if s.isSleeping() {
+			if err := s.wake(c); err != nil {
+				return err
+			}
+		}
+		defer s.touch()
+
+		s.stateMu.Lock()
+		timeouts := s.busyTimeouts
+		s.stateMu.Unlock()
+
+		started := timestamppb.Now()
+		e := newEvent(pb.Event_REFRESH_STARTED, op, c)
+		e.StartedAt = started
+		s.events.publish(e)
+
+		err := fn()
+		s.metrics.observeRefresh(op, time.Since(started.AsTime()), err)
+
+		e = newEvent(pb.Event_REFRESH_COMPLETED, op, c)
+		if err != nil {
+			e.Type = pb.Event_REFRESH_FAILED
+			e.Error = err.Error()
+		}
+		e.StartedAt = started
+		s.events.publish(e)
+
+		s.stateMu.Lock()
+		if err != nil {
+			s.lastError = err.Error()
+		} else {
+			s.lastRefresh = e.Time.AsTime()
+			s.lastError = ""
+		}
+		healthy := err == nil && s.busyTimeouts == timeouts
+		s.stateMu.Unlock()
+
+		// Only a refresh the panel finished in time shows it has recovered
+		if healthy {
+			setServing(s.health, true)
+		}
+
+		return err
+	})
+}
+
+// queueChanged adjusts the queue depth by delta and publishes the new depth.
+func (s *EPDServer) queueChanged(op string, c caller, delta int32) {
+	s.stateMu.Lock()
+	s.queueDepth += delta
+	e := newEvent(pb.Event_QUEUE_CHANGED, op, c)
+	e.QueueDepth = s.queueDepth
+	s.events.publish(e)
+	s.stateMu.Unlock()
+}
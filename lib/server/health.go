@@ -0,0 +1,49 @@
+package server
+
+import (
+	"log"
+	"time"
+
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// newHealthServer creates the standard gRPC health service for s. Both the
+// server as a whole ("") and the EPD service report NOT_SERVING once the
+// hardware init fails or a busy wait times out, until a hardware init or a
+// refresh without a busy timeout succeeds.
+func newHealthServer(s *EPDServer) *health.Server {
+	h := health.NewServer()
+	h.SetServingStatus(pb.EPDService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	s.display.OnBusyWait(func(_ time.Duration, timedOut bool) {
+		if !timedOut {
+			return
+		}
+		log.Println("Panel timed out busy, reporting NOT_SERVING")
+		s.stateMu.Lock()
+		s.busyTimeouts++
+		s.stateMu.Unlock()
+		setServing(h, false)
+	})
+
+	return h
+}
+
+// setServing reports the server and the EPD service as SERVING or
+// NOT_SERVING.
+func setServing(h *health.Server, serving bool) {
+	st := healthpb.HealthCheckResponse_SERVING
+	if !serving {
+		st = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	h.SetServingStatus("", st)
+	h.SetServingStatus(pb.EPDService_ServiceDesc.ServiceName, st)
+}
+
+// HealthServer returns the gRPC health service reporting the state of the
+// panel, for registration alongside the EPD service.
+func (s *EPDServer) HealthServer() *health.Server {
+	return s.health
+}
@@ -0,0 +1,402 @@
+package server
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/justmiles/epd/lib/display"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// HTTPClientIDHeader is the HTTP header clients use to identify themselves.
+const HTTPClientIDHeader = "X-EPD-Client-ID"
+
+// webFiles holds the browser UI served at the root of the HTTP listener.
+//
+//go:embed web
+var webFiles embed.FS
+
+// HTTPHandler returns an http.Handler exposing the EPD as an HTTP/JSON API.
+// Every endpoint maps onto the same logic and authorization as the gRPC service:
+//
+//	POST /v1/image              display an image (multipart "image" field or raw body)
+//	POST /v1/text               display text (JSON {"text": ...} or raw body)
+//	POST /v1/markdown           display markdown (JSON {"markdown": ...} or raw body)
+//	POST /v1/dashboard          render the dashboard (JSON {"header_text": ..., "body_text": ...})
+//	POST /v1/clear              clear the display to white
+//	POST /v1/sleep              put the display into sleep mode
+//	GET  /v1/status             report display and queue status
+//	GET  /v1/screenshot.png     download the frame currently shown
+//	POST /v1/preview/image      render an image as the panel would show it
+//	POST /v1/preview/markdown   render markdown as the panel would show it
+//	GET  /metrics               Prometheus metrics
+//
+// The image endpoints accept "fit" and "dither" query parameters. Metrics are
+// served without authorization, like the embedded web UI that serves
+// everything else. The UI prompts for the token when the API asks for one.
+func (s *EPDServer) HTTPHandler() http.Handler {
+	api := http.NewServeMux()
+	api.HandleFunc("POST /v1/image", s.handleImage)
+	api.HandleFunc("POST /v1/text", s.handleText)
+	api.HandleFunc("POST /v1/markdown", s.handleMarkdown)
+	api.HandleFunc("POST /v1/dashboard", s.handleDashboard)
+	api.HandleFunc("POST /v1/clear", s.handleClear)
+	api.HandleFunc("POST /v1/sleep", s.handleSleep)
+	api.HandleFunc("GET /v1/status", s.handleStatus)
+	api.HandleFunc("GET /v1/screenshot.png", s.handleScreenshot)
+	api.HandleFunc("POST /v1/preview/image", s.handlePreviewImage)
+	api.HandleFunc("POST /v1/preview/markdown", s.handlePreviewMarkdown)
+
+	web, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		panic(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServerFS(web))
+	mux.Handle("GET /metrics", s.MetricsHandler())
+	mux.Handle("/v1/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := httpContext(r)
+		if err := s.authorize(ctx); err != nil {
+			writeError(w, err)
+			return
+		}
+		api.ServeHTTP(w, r.WithContext(ctx))
+	}))
+	return mux
+}
+
+func (s *EPDServer) handleImage(w http.ResponseWriter, r *http.Request) {
+	data, err := imageBody(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp, err := s.DisplayImage(r.Context(), &pb.DisplayImageRequest{
+		ImageData: data,
+		Fit:       r.URL.Query().Get("fit"),
+		Dither:    r.URL.Query().Get("dither"),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeMessage(w, resp.Message)
+}
+
+func (s *EPDServer) handlePreviewImage(w http.ResponseWriter, r *http.Request) {
+	data, err := imageBody(w, r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	opts, err := imageOptions(r.URL.Query().Get("fit"), r.URL.Query().Get("dither"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	preview, err := s.previewImage(data, opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePNG(w, preview)
+}
+
+func (s *EPDServer) handlePreviewMarkdown(w http.ResponseWriter, r *http.Request) {
+	markdown, err := textBody(w, r, "markdown")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	rendered, err := s.renderMarkdown(markdown)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	preview, err := s.previewImage(rendered, display.ImageOptions{})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePNG(w, preview)
+}
+
+func (s *EPDServer) handleText(w http.ResponseWriter, r *http.Request) {
+	text, err := textBody(w, r, "text")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp, err := s.DisplayText(r.Context(), &pb.DisplayTextRequest{Text: text})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeMessage(w, resp.Message)
+}
+
+func (s *EPDServer) handleMarkdown(w http.ResponseWriter, r *http.Request) {
+	markdown, err := textBody(w, r, "markdown")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp, err := s.DisplayMarkdown(r.Context(), &pb.DisplayMarkdownRequest{Markdown: markdown})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeMessage(w, resp.Message)
+}
+
+func (s *EPDServer) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		HeaderText string `json:"header_text"`
+		BodyText   string `json:"body_text"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+		writeError(w, badRequest("invalid JSON body: %s", err))
+		return
+	}
+
+	resp, err := s.RenderDashboard(r.Context(), &pb.RenderDashboardRequest{
+		HeaderText: req.HeaderText,
+		BodyText:   req.BodyText,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeMessage(w, resp.Message)
+}
+
+func (s *EPDServer) handleClear(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.Clear(r.Context(), &pb.ClearRequest{})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeMessage(w, resp.Message)
+}
+
+func (s *EPDServer) handleSleep(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.Sleep(r.Context(), &pb.SleepRequest{})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeMessage(w, resp.Message)
+}
+
+func (s *EPDServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.GetStatus(r.Context(), &pb.GetStatusRequest{})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, statusJSON(resp))
+}
+
+// statusJSON converts a status response to the JSON object served by the HTTP
+// API and published over MQTT.
+func statusJSON(resp *pb.GetStatusResponse) map[string]any {
+	out := map[string]any{
+		"device":      resp.Device,
+		"width":       resp.Width,
+		"height":      resp.Height,
+		"queue_depth": resp.QueueDepth,
+		"sleeping":    resp.Sleeping,
+	}
+	if resp.LastRefresh != nil {
+		out["last_refresh"] = resp.LastRefresh.AsTime().Format(time.RFC3339)
+	}
+	if resp.LastError != "" {
+		out["last_error"] = resp.LastError
+	}
+	if resp.Display != "" {
+		out["display"] = resp.Display
+	}
+	return out
+}
+
+func (s *EPDServer) handleScreenshot(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.GetCurrentFrame(r.Context(), &pb.GetCurrentFrameRequest{})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Last-Modified", resp.UpdatedAt.AsTime().UTC().Format(http.TimeFormat))
+	writePNG(w, resp.ImageData)
+}
+
+// httpContext converts an HTTP request into the context the gRPC handlers
+// expect, carrying the client's address, identity and credentials.
+func httpContext(r *http.Request) context.Context {
+	ctx := r.Context()
+
+	if addrPort, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: net.TCPAddrFromAddrPort(addrPort)})
+	}
+
+	md := metadata.MD{}
+	if id := r.Header.Get(HTTPClientIDHeader); id != "" {
+		md.Set(display.ClientIDHeader, id)
+	}
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		md.Set("authorization", auth)
+	}
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// imageBody reads the uploaded image from a multipart "image" field or, for
+// any other content type, the raw request body.
+func imageBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+
+	body := io.ReadCloser(r.Body)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		f, _, err := r.FormFile("image")
+		if err != nil {
+			return nil, badRequest("could not read image field: %s", err)
+		}
+		body = f
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, badRequest("could not read image: %s", err)
+	}
+	return data, nil
+}
+
+// textBody reads a text payload from a JSON object field or, for any other
+// content type, the raw request body.
+func textBody(w http.ResponseWriter, r *http.Request, field string) (string, error) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err != nil {
+		return "", badRequest("could not read request body: %s", err)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/json" {
+		return string(data), nil
+	}
+
+	var payload map[string]string
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid JSON body: %s", err)
+	}
+	return payload[field], nil
+}
+
+// badRequest wraps err as an InvalidArgument status, leaving errors caused by
+// an oversized body intact so they map to 413.
+func badRequest(format string, err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return err
+	}
+	return status.Errorf(codes.InvalidArgument, format, err)
+}
+
+func writePNG(w http.ResponseWriter, data []byte) {
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(data)
+}
+
+func writeMessage(w http.ResponseWriter, msg string) {
+	writeJSON(w, http.StatusOK, map[string]string{"message": msg})
+}
+
+// writeError maps a gRPC status error onto the closest HTTP status code.
+func writeError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.ResourceExhausted:
+		code = http.StatusRequestEntityTooLarge
+	case codes.Unavailable:
+		code = http.StatusServiceUnavailable
+	case codes.Unimplemented:
+		code = http.StatusNotImplemented
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		code = http.StatusRequestEntityTooLarge
+	}
+
+	msg := err.Error()
+	if st, ok := status.FromError(err); ok {
+		msg = st.Message()
+	}
+	writeJSON(w, code, map[string]string{"error": msg})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write HTTP response: %v", err)
+	}
+}
+
+// ListenAndServeHTTP serves the HTTP API on addr until ctx is cancelled.
+func (s *EPDServer) ListenAndServeHTTP(ctx context.Context, addr string) error {
+	return listenAndServeHTTP(ctx, addr, s.HTTPHandler())
+}
+
+// listenAndServeHTTP serves handler on addr until ctx is cancelled.
+func listenAndServeHTTP(ctx context.Context, addr string, handler http.Handler) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("HTTP server error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// refreshBuckets covers e-paper refreshes, which take seconds rather than
+// milliseconds.
+var refreshBuckets = []float64{0.1, 0.25, 0.5, 1, 2, 3, 5, 8, 13, 21, 34, 60}
+
+// metrics holds the Prometheus collectors for an EPDServer. Each server has its
+// own registry so several can coexist in one process.
+type metrics struct {
+	registry *prometheus.Registry
+
+	refreshes       *prometheus.CounterVec
+	refreshDuration *prometheus.HistogramVec
+	busyWait        prometheus.Histogram
+	busyTimeouts    prometheus.Counter
+	receivedBytes   *prometheus.CounterVec
+}
+
+// newMetrics registers the collectors for s, which must already have a display.
+func newMetrics(s *EPDServer) *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		refreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "epd_refreshes_total",
+			Help: "Display refreshes by operation and outcome (success or failure).",
+		}, []string{"operation", "outcome"}),
+		refreshDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "epd_refresh_duration_seconds",
+			Help:    "Time taken to refresh the display, by operation.",
+			Buckets: refreshBuckets,
+		}, []string{"operation"}),
+		busyWait: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "epd_busy_wait_seconds",
+			Help:    "Time spent waiting for the panel to finish an operation.",
+			Buckets: refreshBuckets,
+		}),
+		busyTimeouts: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "epd_busy_timeouts_total",
+			Help: "Waits for the panel that timed out.",
+		}),
+		receivedBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "epd_received_bytes_total",
+			Help: "Payload bytes received, by RPC.",
+		}, []string{"rpc"}),
+	}
+
+	m.registry.MustRegister(
+		m.refreshes,
+		m.refreshDuration,
+		m.busyWait,
+		m.busyTimeouts,
+		m.receivedBytes,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "epd_queue_depth",
+			Help: "Display operations running or waiting to run.",
+		}, func() float64 {
+			s.stateMu.Lock()
+			defer s.stateMu.Unlock()
+			return float64(s.queueDepth)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "epd_last_refresh_timestamp_seconds",
+			Help: "Unix time of the last successful refresh, or 0 if there has been none.",
+		}, func() float64 {
+			s.stateMu.Lock()
+			defer s.stateMu.Unlock()
+			if s.lastRefresh.IsZero() {
+				return 0
+			}
+			return float64(s.lastRefresh.UnixNano()) / 1e9
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "epd_panel_sleeping",
+			Help: "1 while the panel is in sleep mode, 0 while it is awake.",
+		}, func() float64 {
+			if s.isSleeping() {
+				return 1
+			}
+			return 0
+		}),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	s.display.OnBusyWait(func(wait time.Duration, timedOut bool) {
+		m.busyWait.Observe(wait.Seconds())
+		if timedOut {
+			m.busyTimeouts.Inc()
+		}
+	})
+
+	return m
+}
+
+// observeRefresh records the outcome and duration of a refresh.
+func (m *metrics) observeRefresh(op string, took time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	m.refreshes.WithLabelValues(op, outcome).Inc()
+	m.refreshDuration.WithLabelValues(op).Observe(took.Seconds())
+}
+
+// received records payload bytes received by an RPC.
+func (m *metrics) received(rpc string, n int) {
+	m.receivedBytes.WithLabelValues(rpc).Add(float64(n))
+}
+
+// MetricsHandler returns an http.Handler serving the server's metrics in the
+// Prometheus exposition format.
+func (s *EPDServer) MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(s.metrics.registry, promhttp.HandlerOpts{})
+}
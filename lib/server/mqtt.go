@@ -0,0 +1,210 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/justmiles/epd/lib/display"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"google.golang.org/grpc/metadata"
+)
+
+// mqttQueueSize is the number of received messages buffered while the display
+// is busy before the subscriber stops reading from the broker.
+const mqttQueueSize = 16
+
+// mqttRetryInterval is how long the subscriber waits between attempts to
+// connect to a broker it can't reach, unless configured.
+const mqttRetryInterval = 10 * time.Second
+
+// MQTTConfig configures the MQTT subscriber.
+type MQTTConfig struct {
+	// Broker is the broker URL, e.g. tcp://broker.local:1883
+	Broker   string
+	ClientID string
+	Username string
+	Password string
+
+	// Topic is the prefix for every topic the subscriber uses:
+	//
+	//	<topic>/text          display the payload as text
+	//	<topic>/markdown      display the payload as markdown
+	//	<topic>/image         display the payload as an image
+	//	<topic>/command       run a JSON command (see mqttCommand)
+	//	<topic>/state         retained JSON status, published by the daemon
+	//	<topic>/availability  retained "online" or "offline"
+	Topic string
+
+	// RetryInterval is how long to wait between attempts to connect to the
+	// broker while it can't be reached, 10 seconds when zero.
+	RetryInterval time.Duration
+}
+
+// mqttCommand is the JSON payload accepted on the command topic, e.g.
+//
+//	{"command": "text", "text": "Hello"}
+//	{"command": "image", "image": "<base64>", "fit": "contain", "dither": "atkinson"}
+//	{"command": "clear"}
+type mqttCommand struct {
+	Command  string `json:"command"`
+	Text     string `json:"text"`
+	Markdown string `json:"markdown"`
+	Image    []byte `json:"image"`
+	Fit      string `json:"fit"`
+	Dither   string `json:"dither"`
+}
+
+// RunMQTT connects to an MQTT broker and displays messages published to the
+// configured topics until ctx is cancelled. Status is published to the state
+// topic whenever the display changes. A broker that can't be reached is retried
+// until ctx is cancelled, so an outage never stops the rest of the daemon.
+func (s *EPDServer) RunMQTT(ctx context.Context, cfg MQTTConfig) error {
+	topic := strings.TrimSuffix(cfg.Topic, "/")
+	availabilityTopic := topic + "/availability"
+	messages := make(chan mqtt.Message, mqttQueueSize)
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(cfg.Broker).
+		SetClientID(cfg.ClientID).
+		SetUsername(cfg.Username).
+		SetPassword(cfg.Password).
+		SetAutoReconnect(true).
+		SetWill(availabilityTopic, "offline", 1, true)
+
+	// Subscribe on every connect so subscriptions survive reconnects.
+	opts.SetOnConnectHandler(func(c mqtt.Client) {
+		log.Printf("Connected to MQTT broker %s", cfg.Broker)
+
+		filters := map[string]byte{}
+		for _, sub := range []string{"text", "markdown", "image", "command"} {
+			filters[topic+"/"+sub] = 1
+		}
+		token := c.SubscribeMultiple(filters, func(_ mqtt.Client, m mqtt.Message) {
+			select {
+			case messages <- m:
+			case <-ctx.Done():
+			}
+		})
+		if token.Wait(); token.Error() != nil {
+			log.Printf("MQTT subscribe error: %v", token.Error())
+		}
+
+		c.Publish(availabilityTopic, 1, true, "online")
+		s.publishMQTTState(c, topic, "")
+	})
+	opts.SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+		log.Printf("Lost connection to MQTT broker: %v", err)
+	})
+
+	events, unsubscribe := s.events.subscribe()
+	defer unsubscribe()
+
+	retry := cfg.RetryInterval
+	if retry <= 0 {
+		retry = mqttRetryInterval
+	}
+	client := mqtt.NewClient(opts)
+	for {
+		token := client.Connect()
+		if token.Wait(); token.Error() == nil {
+			break
+		}
+		log.Printf("Failed to connect to MQTT broker %s, retrying in %s: %v", cfg.Broker, retry, token.Error())
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(retry):
+		}
+	}
+	defer func() {
+		client.Publish(availabilityTopic, 1, true, "offline").WaitTimeout(time.Second)
+		client.Disconnect(250)
+	}()
+
+	// Messages are handled here rather than in the client's callback so a slow
+	// refresh doesn't stall the connection.
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case m := <-messages:
+			s.handleMQTTMessage(ctx, topic, m)
+		case e := <-events:
+			switch e.Type {
+			case pb.Event_REFRESH_COMPLETED, pb.Event_REFRESH_FAILED, pb.Event_SLEEP, pb.Event_WAKE:
+				s.publishMQTTState(client, topic, e.Type.String())
+			}
+		}
+	}
+}
+
+// handleMQTTMessage maps a message onto the matching display operation.
+func (s *EPDServer) handleMQTTMessage(ctx context.Context, topic string, m mqtt.Message) {
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(display.ClientIDHeader, "mqtt:"+m.Topic()))
+
+	var err error
+	switch strings.TrimPrefix(m.Topic(), topic+"/") {
+	case "text":
+		_, err = s.DisplayText(ctx, &pb.DisplayTextRequest{Text: string(m.Payload())})
+	case "markdown":
+		_, err = s.DisplayMarkdown(ctx, &pb.DisplayMarkdownRequest{Markdown: string(m.Payload())})
+	case "image":
+		_, err = s.DisplayImage(ctx, &pb.DisplayImageRequest{ImageData: m.Payload()})
+	case "command":
+		err = s.runMQTTCommand(ctx, m.Payload())
+	}
+	if err != nil {
+		log.Printf("MQTT %s error: %v", m.Topic(), err)
+	}
+}
+
+// runMQTTCommand runs a JSON command from the command topic.
+func (s *EPDServer) runMQTTCommand(ctx context.Context, payload []byte) error {
+	var cmd mqttCommand
+	if err := json.Unmarshal(payload, &cmd); err != nil {
+		return fmt.Errorf("invalid command: %w", err)
+	}
+
+	var err error
+	switch cmd.Command {
+	case "text":
+		_, err = s.DisplayText(ctx, &pb.DisplayTextRequest{Text: cmd.Text})
+	case "markdown":
+		_, err = s.DisplayMarkdown(ctx, &pb.DisplayMarkdownRequest{Markdown: cmd.Markdown})
+	case "image":
+		_, err = s.DisplayImage(ctx, &pb.DisplayImageRequest{ImageData: cmd.Image, Fit: cmd.Fit, Dither: cmd.Dither})
+	case "clear":
+		_, err = s.Clear(ctx, &pb.ClearRequest{})
+	case "sleep":
+		_, err = s.Sleep(ctx, &pb.SleepRequest{})
+	default:
+		err = fmt.Errorf("unknown command %q", cmd.Command)
+	}
+	return err
+}
+
+// publishMQTTState publishes the display status, and the event that prompted
+// it, as retained JSON on the state topic.
+func (s *EPDServer) publishMQTTState(c mqtt.Client, topic, event string) {
+	resp, err := s.GetStatus(context.Background(), &pb.GetStatusRequest{})
+	if err != nil {
+		log.Printf("MQTT state error: %v", err)
+		return
+	}
+
+	state := statusJSON(resp)
+	if event != "" {
+		state["event"] = event
+	}
+	payload, err := json.Marshal(state)
+	if err != nil {
+		log.Printf("MQTT state error: %v", err)
+		return
+	}
+	c.Publish(topic+"/state", 1, true, payload)
+}
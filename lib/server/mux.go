@@ -0,0 +1,348 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/justmiles/epd/lib/display"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Mux serves several displays from one daemon. Each request goes to the
+// display named by its display.DisplayHeader metadata, or to the default
+// display, the first one added, when it names none. Every display keeps its
+// own queue, state and configuration, including its auth token.
+type Mux struct {
+	pb.UnimplementedEPDServiceServer
+	names   []string
+	servers map[string]*EPDServer
+}
+
+// NewMux creates a Mux serving the given displays, each named with WithName.
+// The first is the default display.
+func NewMux(servers ...*EPDServer) (*Mux, error) {
+	if len(servers) == 0 {
+		return nil, errors.New("a mux needs at least one display")
+	}
+
+	m := &Mux{servers: map[string]*EPDServer{}}
+	for _, s := range servers {
+		if strings.ContainsAny(s.name, "/ ") {
+			return nil, fmt.Errorf("invalid display name %q", s.name)
+		}
+		if _, ok := m.servers[s.name]; ok {
+			return nil, fmt.Errorf("duplicate display name %q", s.name)
+		}
+		m.names = append(m.names, s.name)
+		m.servers[s.name] = s
+	}
+	return m, nil
+}
+
+// Displays returns the displays in the order they were added, the default
+// display first.
+func (m *Mux) Displays() []*EPDServer {
+	servers := make([]*EPDServer, len(m.names))
+	for i, name := range m.names {
+		servers[i] = m.servers[name]
+	}
+	return servers
+}
+
+// server returns the display a request addresses.
+func (m *Mux) server(ctx context.Context) (*EPDServer, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(display.DisplayHeader); len(v) > 0 && v[0] != "" {
+			s, ok := m.servers[v[0]]
+			if !ok {
+				return nil, status.Errorf(codes.NotFound, "no display named %q", v[0])
+			}
+			return s, nil
+		}
+	}
+	return m.servers[m.names[0]], nil
+}
+
+// UnaryInterceptor rejects unary RPCs that fail authorization with the
+// addressed display.
+func (m *Mux) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if strings.HasPrefix(info.FullMethod, publicServicePrefix) {
+			return handler(ctx, req)
+		}
+		s, err := m.server(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.authorize(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor rejects streaming RPCs that fail authorization with the
+// addressed display.
+func (m *Mux) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, publicServicePrefix) {
+			return handler(srv, ss)
+		}
+		s, err := m.server(ss.Context())
+		if err != nil {
+			return err
+		}
+		if err := s.authorize(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// ListDisplays reports the state of every display, the default display first.
+func (m *Mux) ListDisplays(ctx context.Context, req *pb.ListDisplaysRequest) (*pb.ListDisplaysResponse, error) {
+	resp := &pb.ListDisplaysResponse{}
+	for _, s := range m.Displays() {
+		st, err := s.GetStatus(ctx, &pb.GetStatusRequest{})
+		if err != nil {
+			return nil, err
+		}
+		resp.Displays = append(resp.Displays, st)
+	}
+	return resp, nil
+}
+
+// Shutdown shuts down every display.
+func (m *Mux) Shutdown() {
+	for _, s := range m.Displays() {
+		s.Shutdown()
+	}
+}
+
+// HTTPHandler serves the default display's HTTP API at the root and every
+// display's under /displays/<name>/.
+func (m *Mux) HTTPHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/", m.servers[m.names[0]].HTTPHandler())
+	for _, s := range m.Displays() {
+		if s.name == "" {
+			continue
+		}
+		prefix := "/displays/" + s.name
+		mux.Handle(prefix+"/", http.StripPrefix(prefix, s.HTTPHandler()))
+	}
+	return mux
+}
+
+// ListenAndServeHTTP serves the HTTP API for every display on addr until ctx
+// is cancelled.
+func (m *Mux) ListenAndServeHTTP(ctx context.Context, addr string) error {
+	return listenAndServeHTTP(ctx, addr, m.HTTPHandler())
+}
+
+// HealthServer returns a gRPC health service for every display. The daemon
+// ("") and the EPD service are SERVING only while every display is; append
+// "/<name>" to the EPD service name to check one display.
+func (m *Mux) HealthServer() healthpb.HealthServer {
+	return &muxHealth{m: m}
+}
+
+// muxHealth answers health checks from the displays' own health services.
+type muxHealth struct {
+	healthpb.UnimplementedHealthServer
+	m *Mux
+}
+
+func (h *muxHealth) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	check := func(s *EPDServer) (*healthpb.HealthCheckResponse, error) {
+		return s.health.Check(ctx, &healthpb.HealthCheckRequest{Service: pb.EPDService_ServiceDesc.ServiceName})
+	}
+
+	service := pb.EPDService_ServiceDesc.ServiceName
+	if name, ok := strings.CutPrefix(req.Service, service+"/"); ok {
+		s, ok := h.m.servers[name]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+		}
+		return check(s)
+	}
+	if req.Service != "" && req.Service != service {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+	}
+
+	for _, s := range h.m.Displays() {
+		resp, err := check(s)
+		if err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+			return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+		}
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func (m *Mux) DisplayImage(ctx context.Context, req *pb.DisplayImageRequest) (*pb.DisplayImageResponse, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.DisplayImage(ctx, req)
+}
+
+func (m *Mux) UploadImage(stream pb.EPDService_UploadImageServer) error {
+	s, err := m.server(stream.Context())
+	if err != nil {
+		return err
+	}
+	return s.UploadImage(stream)
+}
+
+func (m *Mux) DisplayText(ctx context.Context, req *pb.DisplayTextRequest) (*pb.DisplayTextResponse, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.DisplayText(ctx, req)
+}
+
+func (m *Mux) DisplayMarkdown(ctx context.Context, req *pb.DisplayMarkdownRequest) (*pb.DisplayMarkdownResponse, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.DisplayMarkdown(ctx, req)
+}
+
+func (m *Mux) RenderDashboard(ctx context.Context, req *pb.RenderDashboardRequest) (*pb.RenderDashboardResponse, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.RenderDashboard(ctx, req)
+}
+
+func (m *Mux) Clear(ctx context.Context, req *pb.ClearRequest) (*pb.ClearResponse, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.Clear(ctx, req)
+}
+
+func (m *Mux) Sleep(ctx context.Context, req *pb.SleepRequest) (*pb.SleepResponse, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.Sleep(ctx, req)
+}
+
+func (m *Mux) GetCurrentFrame(ctx context.Context, req *pb.GetCurrentFrameRequest) (*pb.GetCurrentFrameResponse, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetCurrentFrame(ctx, req)
+}
+
+func (m *Mux) GetStatus(ctx context.Context, req *pb.GetStatusRequest) (*pb.GetStatusResponse, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetStatus(ctx, req)
+}
+
+func (m *Mux) WatchEvents(req *pb.WatchEventsRequest, stream pb.EPDService_WatchEventsServer) error {
+	s, err := m.server(stream.Context())
+	if err != nil {
+		return err
+	}
+	return s.WatchEvents(req, stream)
+}
+
+func (m *Mux) AddSchedule(ctx context.Context, req *pb.AddScheduleRequest) (*pb.Schedule, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddSchedule(ctx, req)
+}
+
+func (m *Mux) RemoveSchedule(ctx context.Context, req *pb.RemoveScheduleRequest) (*pb.RemoveScheduleResponse, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.RemoveSchedule(ctx, req)
+}
+
+func (m *Mux) ListSchedules(ctx context.Context, req *pb.ListSchedulesRequest) (*pb.ListSchedulesResponse, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.ListSchedules(ctx, req)
+}
+
+func (m *Mux) SetQuietHours(ctx context.Context, req *pb.QuietHours) (*pb.QuietHours, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.SetQuietHours(ctx, req)
+}
+
+func (m *Mux) AddPlaylistItem(ctx context.Context, req *pb.AddPlaylistItemRequest) (*pb.PlaylistItem, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddPlaylistItem(ctx, req)
+}
+
+func (m *Mux) RemovePlaylistItem(ctx context.Context, req *pb.RemovePlaylistItemRequest) (*pb.RemovePlaylistItemResponse, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.RemovePlaylistItem(ctx, req)
+}
+
+func (m *Mux) GetPlaylist(ctx context.Context, req *pb.GetPlaylistRequest) (*pb.Playlist, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetPlaylist(ctx, req)
+}
+
+func (m *Mux) SkipPlaylistItem(ctx context.Context, req *pb.SkipPlaylistItemRequest) (*pb.Playlist, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.SkipPlaylistItem(ctx, req)
+}
+
+func (m *Mux) PausePlaylist(ctx context.Context, req *pb.PausePlaylistRequest) (*pb.Playlist, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.PausePlaylist(ctx, req)
+}
+
+func (m *Mux) ShufflePlaylist(ctx context.Context, req *pb.ShufflePlaylistRequest) (*pb.Playlist, error) {
+	s, err := m.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.ShufflePlaylist(ctx, req)
+}
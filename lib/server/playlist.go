@@ -0,0 +1,427 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/justmiles/epd/lib/display"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultDwell is how long a playlist item is shown when it doesn't say.
+const defaultDwell = time.Minute
+
+// playlistClientID identifies playlist updates in events and logs.
+const playlistClientID = "playlist"
+
+// playlist cycles through items, showing each for its dwell time, and persists
+// them, when a path is set, so they survive restarts.
+type playlist struct {
+	path string
+
+	// state is the persisted playlist; its items, shuffle and paused fields
+	state *pb.Playlist
+
+	// current is the ID of the item shown; order holds the IDs still to play
+	// this cycle
+	current string
+	order   []string
+
+	// timer shows the next item; gen invalidates timers that fired after
+	// being replaced
+	timer *time.Timer
+	next  time.Time
+	gen   int
+
+	// show hands the latest item to the goroutine that displays it, so a
+	// burst of skips shows only the last item, and in order
+	show    chan *pb.PlaylistItem
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// startPlaylist loads the persisted playlist, if any, and starts cycling
+// through it.
+func (s *EPDServer) startPlaylist() {
+	s.playlist = &playlist{
+		state:   &pb.Playlist{},
+		show:    make(chan *pb.PlaylistItem, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if s.stateDir != "" {
+		s.playlist.path = filepath.Join(s.stateDir, "playlist.json")
+	}
+
+	if err := s.loadPlaylist(); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	go s.runPlaylist()
+
+	s.playlistMu.Lock()
+	defer s.playlistMu.Unlock()
+	switch {
+	case s.playlist.state.Paused:
+	case s.isQuiet():
+		// Show nothing until quiet hours are over
+		s.startPlaylistTimer(&pb.PlaylistItem{})
+	default:
+		s.advancePlaylist()
+	}
+}
+
+// loadPlaylist reads the persisted playlist.
+func (s *EPDServer) loadPlaylist() error {
+	if s.playlist.path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.playlist.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read playlist: %w", err)
+	}
+
+	state := &pb.Playlist{}
+	if err := protojson.Unmarshal(data, state); err != nil {
+		return fmt.Errorf("failed to parse playlist %s: %w", s.playlist.path, err)
+	}
+	s.playlist.state = state
+	return nil
+}
+
+// runPlaylist displays playlist items until the playlist is stopped.
+func (s *EPDServer) runPlaylist() {
+	defer close(s.playlist.stopped)
+	for {
+		select {
+		case item := <-s.playlist.show:
+			s.showPlaylistItem(item)
+		case <-s.playlist.done:
+			return
+		}
+	}
+}
+
+// stopPlaylist stops cycling through the playlist and waits for an item being
+// shown.
+func (s *EPDServer) stopPlaylist() {
+	s.playlistMu.Lock()
+	s.stopPlaylistTimer()
+	close(s.playlist.done)
+	s.playlistMu.Unlock()
+
+	<-s.playlist.stopped
+}
+
+// advancePlaylist moves to the next item, shows it in the background and,
+// unless paused, schedules the one after. Callers must hold playlistMu.
+func (s *EPDServer) advancePlaylist() {
+	s.stopPlaylistTimer()
+
+	p := s.playlist
+	items := p.state.Items
+	if len(items) == 0 {
+		p.current = ""
+		return
+	}
+
+	if len(p.order) == 0 {
+		p.order = s.playlistOrder()
+	}
+	p.current, p.order = p.order[0], p.order[1:]
+
+	item := s.playlistItem(p.current)
+	select {
+	case <-p.show:
+	default:
+	}
+	p.show <- proto.Clone(item).(*pb.PlaylistItem)
+
+	if !p.state.Paused {
+		s.startPlaylistTimer(item)
+	}
+}
+
+// playlistOrder returns the item IDs in the order to play them next cycle,
+// starting after the current item when playing in order. Callers must hold
+// playlistMu.
+func (s *EPDServer) playlistOrder() []string {
+	p := s.playlist
+	ids := make([]string, len(p.state.Items))
+	for i, item := range p.state.Items {
+		ids[i] = item.Id
+	}
+
+	if p.state.Shuffle {
+		rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+		// Don't show the same item twice in a row across cycles.
+		if len(ids) > 1 && ids[0] == p.current {
+			ids[0], ids[len(ids)-1] = ids[len(ids)-1], ids[0]
+		}
+		return ids
+	}
+	for i, id := range ids {
+		if id == p.current {
+			return append(ids[i+1:], ids[:i+1]...)
+		}
+	}
+	return ids
+}
+
+// startPlaylistTimer moves to the next item after item's dwell time, or waits
+// another dwell time during quiet hours. Callers must hold playlistMu.
+func (s *EPDServer) startPlaylistTimer(item *pb.PlaylistItem) {
+	p := s.playlist
+	dwell := defaultDwell
+	if item.Dwell != nil && item.Dwell.AsDuration() > 0 {
+		dwell = item.Dwell.AsDuration()
+	}
+
+	p.gen++
+	gen := p.gen
+	p.next = time.Now().Add(dwell)
+	p.timer = time.AfterFunc(dwell, func() {
+		s.playlistMu.Lock()
+		defer s.playlistMu.Unlock()
+		if p.gen != gen {
+			return
+		}
+		if s.isQuiet() {
+			// Stay on the current item, checking again after its dwell time
+			log.Printf("Holding the playlist during quiet hours")
+			s.startPlaylistTimer(item)
+			return
+		}
+		s.advancePlaylist()
+	})
+}
+
+// stopPlaylistTimer cancels moving to the next item. Callers must hold
+// playlistMu.
+func (s *EPDServer) stopPlaylistTimer() {
+	p := s.playlist
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	p.gen++
+	p.next = time.Time{}
+}
+
+// showPlaylistItem displays a playlist item's content.
+func (s *EPDServer) showPlaylistItem(item *pb.PlaylistItem) {
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(display.ClientIDHeader, playlistClientID+":"+item.Id))
+
+	var err error
+	switch c := item.Content.(type) {
+	case *pb.PlaylistItem_Image:
+		err = s.showImage(ctx, c.Image)
+	case *pb.PlaylistItem_MarkdownFile:
+		err = s.showMarkdownFile(ctx, c.MarkdownFile)
+	case *pb.PlaylistItem_Dashboard:
+		err = s.showDashboard(ctx, c.Dashboard)
+	}
+	if err != nil {
+		log.Printf("Playlist item %s error: %v", item.Id, err)
+	}
+}
+
+// playlistItem returns the item with the given ID, or nil. Callers must hold
+// playlistMu.
+func (s *EPDServer) playlistItem(id string) *pb.PlaylistItem {
+	for _, item := range s.playlist.state.Items {
+		if item.Id == id {
+			return item
+		}
+	}
+	return nil
+}
+
+// AddPlaylistItem validates and appends an item to the playlist, assigning its
+// ID. The first item added starts the playlist.
+func (s *EPDServer) AddPlaylistItem(ctx context.Context, req *pb.AddPlaylistItemRequest) (*pb.PlaylistItem, error) {
+	item := req.Item
+	if item == nil || item.Content == nil {
+		return nil, status.Error(codes.InvalidArgument, "playlist item must set content")
+	}
+	if item.Dwell != nil && (item.Dwell.CheckValid() != nil || item.Dwell.AsDuration() < 0) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid dwell time %v", item.Dwell.AsDuration())
+	}
+
+	item = proto.Clone(item).(*pb.PlaylistItem)
+	item.Id = newID()
+
+	s.playlistMu.Lock()
+	defer s.playlistMu.Unlock()
+
+	p := s.playlist
+	state := proto.Clone(p.state).(*pb.Playlist)
+	state.Items = append(state.Items, item)
+	if err := s.savePlaylist(state); err != nil {
+		return nil, err
+	}
+	p.state = state
+	if p.state.Shuffle {
+		p.order = append(slices.Clone(p.order), item.Id)
+		rand.Shuffle(len(p.order), func(i, j int) { p.order[i], p.order[j] = p.order[j], p.order[i] })
+	} else {
+		p.order = nil
+	}
+
+	log.Printf("Added playlist item %s", item.Id)
+	if p.current == "" && !p.state.Paused {
+		s.advancePlaylist()
+	}
+	return proto.Clone(item).(*pb.PlaylistItem), nil
+}
+
+// RemovePlaylistItem removes an item from the playlist, moving on if it is
+// being shown.
+func (s *EPDServer) RemovePlaylistItem(ctx context.Context, req *pb.RemovePlaylistItemRequest) (*pb.RemovePlaylistItemResponse, error) {
+	s.playlistMu.Lock()
+	defer s.playlistMu.Unlock()
+
+	p := s.playlist
+	if s.playlistItem(req.Id) == nil {
+		return nil, status.Errorf(codes.NotFound, "no playlist item with ID %q", req.Id)
+	}
+
+	state := proto.Clone(p.state).(*pb.Playlist)
+	state.Items = slices.DeleteFunc(state.Items, func(item *pb.PlaylistItem) bool { return item.Id == req.Id })
+	if err := s.savePlaylist(state); err != nil {
+		return nil, err
+	}
+	p.state = state
+	p.order = slices.DeleteFunc(slices.Clone(p.order), func(id string) bool { return id == req.Id })
+
+	log.Printf("Removed playlist item %s", req.Id)
+	if p.current == req.Id {
+		if p.state.Paused {
+			p.current = ""
+		} else {
+			s.advancePlaylist()
+		}
+	}
+	return &pb.RemovePlaylistItemResponse{Message: "Playlist item removed"}, nil
+}
+
+// GetPlaylist returns the playlist and what it is currently showing.
+func (s *EPDServer) GetPlaylist(ctx context.Context, req *pb.GetPlaylistRequest) (*pb.Playlist, error) {
+	s.playlistMu.Lock()
+	defer s.playlistMu.Unlock()
+	return s.playlistStatus(), nil
+}
+
+// SkipPlaylistItem shows the next item immediately. A paused playlist stays
+// paused on the new item.
+func (s *EPDServer) SkipPlaylistItem(ctx context.Context, req *pb.SkipPlaylistItemRequest) (*pb.Playlist, error) {
+	s.playlistMu.Lock()
+	defer s.playlistMu.Unlock()
+
+	if len(s.playlist.state.Items) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "the playlist is empty")
+	}
+	s.advancePlaylist()
+	return s.playlistStatus(), nil
+}
+
+// PausePlaylist pauses or resumes the playlist. Resuming shows the current
+// item for its full dwell time again.
+func (s *EPDServer) PausePlaylist(ctx context.Context, req *pb.PausePlaylistRequest) (*pb.Playlist, error) {
+	s.playlistMu.Lock()
+	defer s.playlistMu.Unlock()
+
+	p := s.playlist
+	if p.state.Paused != req.Paused {
+		state := proto.Clone(p.state).(*pb.Playlist)
+		state.Paused = req.Paused
+		if err := s.savePlaylist(state); err != nil {
+			return nil, err
+		}
+		p.state = state
+
+		switch item := s.playlistItem(p.current); {
+		case req.Paused:
+			s.stopPlaylistTimer()
+		case item != nil:
+			s.startPlaylistTimer(item)
+		default:
+			s.advancePlaylist()
+		}
+		log.Printf("Playlist paused: %t", req.Paused)
+	}
+	return s.playlistStatus(), nil
+}
+
+// ShufflePlaylist switches between playing items in order and shuffled, from
+// the next item on.
+func (s *EPDServer) ShufflePlaylist(ctx context.Context, req *pb.ShufflePlaylistRequest) (*pb.Playlist, error) {
+	s.playlistMu.Lock()
+	defer s.playlistMu.Unlock()
+
+	p := s.playlist
+	if p.state.Shuffle != req.Shuffle {
+		state := proto.Clone(p.state).(*pb.Playlist)
+		state.Shuffle = req.Shuffle
+		if err := s.savePlaylist(state); err != nil {
+			return nil, err
+		}
+		p.state = state
+		p.order = nil
+		log.Printf("Playlist shuffle: %t", req.Shuffle)
+	}
+	return s.playlistStatus(), nil
+}
+
+// playlistStatus returns a copy of the playlist with what it is currently
+// showing. Callers must hold playlistMu.
+func (s *EPDServer) playlistStatus() *pb.Playlist {
+	p := s.playlist
+	resp := proto.Clone(p.state).(*pb.Playlist)
+	resp.CurrentId = p.current
+	if !p.next.IsZero() {
+		resp.NextChange = timestamppb.New(p.next)
+	}
+	return resp
+}
+
+// savePlaylist persists state, the playlist about to replace the current one,
+// writing to a temporary file first so a crash never leaves a partial file.
+// Changes are only applied once saved, so a failed save changes nothing.
+// Callers must hold playlistMu.
+func (s *EPDServer) savePlaylist(state *pb.Playlist) error {
+	if s.playlist.path == "" {
+		return nil
+	}
+
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode playlist: %w", err)
+	}
+
+	tmp := s.playlist.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to save playlist: %w", err)
+	}
+	if err := os.Rename(tmp, s.playlist.path); err != nil {
+		return fmt.Errorf("failed to save playlist: %w", err)
+	}
+	return nil
+}
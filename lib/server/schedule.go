@@ -0,0 +1,317 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/justmiles/epd/lib/display"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"github.com/robfig/cron/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// schedulerClientID identifies scheduled runs in events and logs.
+const schedulerClientID = "scheduler"
+
+// scheduler runs scheduled content on cron expressions and persists the
+// schedule, when a path is set, so it survives restarts.
+type scheduler struct {
+	cron *cron.Cron
+	path string
+
+	// state is the persisted schedule; entries maps schedule IDs to cron entries
+	state   *pb.ListSchedulesResponse
+	entries map[string]cron.EntryID
+}
+
+// startScheduler loads the persisted schedule, if any, and starts running it.
+func (s *EPDServer) startScheduler() {
+	s.scheduler = &scheduler{
+		cron:    cron.New(cron.WithLocation(s.location)),
+		state:   &pb.ListSchedulesResponse{QuietHours: &pb.QuietHours{}},
+		entries: map[string]cron.EntryID{},
+	}
+	if s.stateDir != "" {
+		s.scheduler.path = filepath.Join(s.stateDir, "schedules.json")
+	}
+
+	if err := s.loadSchedules(); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	s.scheduler.cron.Start()
+}
+
+// loadSchedules reads the persisted schedule and registers its entries.
+func (s *EPDServer) loadSchedules() error {
+	if s.scheduler.path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.scheduler.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read schedules: %w", err)
+	}
+
+	state := &pb.ListSchedulesResponse{}
+	if err := protojson.Unmarshal(data, state); err != nil {
+		return fmt.Errorf("failed to parse schedules %s: %w", s.scheduler.path, err)
+	}
+	if state.QuietHours != nil {
+		s.scheduler.state.QuietHours = state.QuietHours
+	}
+
+	for _, entry := range state.Schedules {
+		if err := s.scheduleEntry(entry); err != nil {
+			log.Printf("Warning: skipping schedule %s: %v", entry.Id, err)
+			continue
+		}
+		s.scheduler.state.Schedules = append(s.scheduler.state.Schedules, entry)
+	}
+	return nil
+}
+
+// stopScheduler stops running scheduled entries and waits for any in progress.
+func (s *EPDServer) stopScheduler() {
+	<-s.scheduler.cron.Stop().Done()
+}
+
+// scheduleEntry registers entry with cron. Callers must hold scheduleMu.
+func (s *EPDServer) scheduleEntry(entry *pb.Schedule) error {
+	entry = proto.Clone(entry).(*pb.Schedule)
+	id, err := s.scheduler.cron.AddFunc(entry.Cron, func() {
+		s.runSchedule(entry)
+	})
+	if err != nil {
+		return err
+	}
+	s.scheduler.entries[entry.Id] = id
+	return nil
+}
+
+// unscheduleEntry removes the entry with ID id from cron. Callers must hold
+// scheduleMu.
+func (s *EPDServer) unscheduleEntry(id string) {
+	s.scheduler.cron.Remove(s.scheduler.entries[id])
+	delete(s.scheduler.entries, id)
+}
+
+// isQuiet reports whether it is quiet hours now, in the server's location.
+func (s *EPDServer) isQuiet() bool {
+	s.scheduleMu.Lock()
+	quiet := proto.Clone(s.scheduler.state.QuietHours).(*pb.QuietHours)
+	s.scheduleMu.Unlock()
+
+	return inQuietHours(quiet, time.Now().In(s.location))
+}
+
+// runSchedule displays a schedule entry's content unless it is quiet hours.
+func (s *EPDServer) runSchedule(entry *pb.Schedule) {
+	if s.isQuiet() {
+		log.Printf("Skipping schedule %s during quiet hours", entry.Id)
+		return
+	}
+
+	log.Printf("Running schedule %s (%s)", entry.Id, entry.Cron)
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(display.ClientIDHeader, schedulerClientID+":"+entry.Id))
+
+	var err error
+	switch c := entry.Content.(type) {
+	case *pb.Schedule_Dashboard:
+		err = s.showDashboard(ctx, c.Dashboard)
+	case *pb.Schedule_MarkdownFile:
+		err = s.showMarkdownFile(ctx, c.MarkdownFile)
+	case *pb.Schedule_ImageUrl:
+		err = s.showImage(ctx, c.ImageUrl)
+	case *pb.Schedule_Text:
+		_, err = s.DisplayText(ctx, &pb.DisplayTextRequest{Text: c.Text})
+	}
+	if err != nil {
+		log.Printf("Schedule %s error: %v", entry.Id, err)
+	}
+}
+
+// AddSchedule validates and adds a scheduled entry, assigning its ID.
+func (s *EPDServer) AddSchedule(ctx context.Context, req *pb.AddScheduleRequest) (*pb.Schedule, error) {
+	entry := req.Schedule
+	if entry == nil || entry.Content == nil {
+		return nil, status.Error(codes.InvalidArgument, "schedule must set content")
+	}
+	if _, err := cron.ParseStandard(entry.Cron); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid cron expression %q: %s", entry.Cron, err)
+	}
+
+	entry = proto.Clone(entry).(*pb.Schedule)
+	entry.Id = newID()
+	entry.NextRun = nil
+
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+
+	if err := s.scheduleEntry(entry); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid schedule: %s", err)
+	}
+	state := &pb.ListSchedulesResponse{
+		Schedules:  append(slices.Clone(s.scheduler.state.Schedules), entry),
+		QuietHours: s.scheduler.state.QuietHours,
+	}
+	if err := s.saveSchedules(state); err != nil {
+		s.unscheduleEntry(entry.Id)
+		return nil, err
+	}
+	s.scheduler.state = state
+
+	log.Printf("Added schedule %s (%s)", entry.Id, entry.Cron)
+	return s.withNextRun(entry), nil
+}
+
+// RemoveSchedule removes a scheduled entry.
+func (s *EPDServer) RemoveSchedule(ctx context.Context, req *pb.RemoveScheduleRequest) (*pb.RemoveScheduleResponse, error) {
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+
+	if _, ok := s.scheduler.entries[req.Id]; !ok {
+		return nil, status.Errorf(codes.NotFound, "no schedule with ID %q", req.Id)
+	}
+
+	state := &pb.ListSchedulesResponse{QuietHours: s.scheduler.state.QuietHours}
+	for _, entry := range s.scheduler.state.Schedules {
+		if entry.Id != req.Id {
+			state.Schedules = append(state.Schedules, entry)
+		}
+	}
+	if err := s.saveSchedules(state); err != nil {
+		return nil, err
+	}
+	s.scheduler.state = state
+	s.unscheduleEntry(req.Id)
+
+	log.Printf("Removed schedule %s", req.Id)
+	return &pb.RemoveScheduleResponse{Message: "Schedule removed"}, nil
+}
+
+// ListSchedules returns every scheduled entry with its next run, and the quiet hours.
+func (s *EPDServer) ListSchedules(ctx context.Context, req *pb.ListSchedulesRequest) (*pb.ListSchedulesResponse, error) {
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+
+	resp := &pb.ListSchedulesResponse{
+		QuietHours: proto.Clone(s.scheduler.state.QuietHours).(*pb.QuietHours),
+	}
+	for _, entry := range s.scheduler.state.Schedules {
+		resp.Schedules = append(resp.Schedules, s.withNextRun(entry))
+	}
+	return resp, nil
+}
+
+// SetQuietHours sets the daily window in which scheduled entries don't run.
+func (s *EPDServer) SetQuietHours(ctx context.Context, req *pb.QuietHours) (*pb.QuietHours, error) {
+	if (req.Start == "") != (req.End == "") {
+		return nil, status.Error(codes.InvalidArgument, "quiet hours need both a start and an end")
+	}
+	for _, t := range []string{req.Start, req.End} {
+		if _, err := parseClock(t); t != "" && err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid time %q, expected HH:MM", t)
+		}
+	}
+
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+
+	state := &pb.ListSchedulesResponse{
+		Schedules:  s.scheduler.state.Schedules,
+		QuietHours: &pb.QuietHours{Start: req.Start, End: req.End},
+	}
+	if err := s.saveSchedules(state); err != nil {
+		return nil, err
+	}
+	s.scheduler.state = state
+
+	log.Printf("Set quiet hours to %s-%s", req.Start, req.End)
+	return proto.Clone(s.scheduler.state.QuietHours).(*pb.QuietHours), nil
+}
+
+// withNextRun returns a copy of entry with its next run time set. Callers must
+// hold scheduleMu.
+func (s *EPDServer) withNextRun(entry *pb.Schedule) *pb.Schedule {
+	entry = proto.Clone(entry).(*pb.Schedule)
+	if next := s.scheduler.cron.Entry(s.scheduler.entries[entry.Id]).Next; !next.IsZero() {
+		entry.NextRun = timestamppb.New(next)
+	} else if sched, err := cron.ParseStandard(entry.Cron); err == nil {
+		entry.NextRun = timestamppb.New(sched.Next(time.Now().In(s.location)))
+	}
+	return entry
+}
+
+// saveSchedules persists state, the schedule about to replace the current one,
+// writing to a temporary file first so a crash never leaves a partial file.
+// Changes are only applied once saved, so a failed save changes nothing.
+// Callers must hold scheduleMu.
+func (s *EPDServer) saveSchedules(state *pb.ListSchedulesResponse) error {
+	if s.scheduler.path == "" {
+		return nil
+	}
+
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode schedules: %w", err)
+	}
+
+	tmp := s.scheduler.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to save schedules: %w", err)
+	}
+	if err := os.Rename(tmp, s.scheduler.path); err != nil {
+		return fmt.Errorf("failed to save schedules: %w", err)
+	}
+	return nil
+}
+
+// inQuietHours reports whether t falls within the quiet hours. The window
+// includes its start and excludes its end, and wraps past midnight when the
+// end is before the start.
+func inQuietHours(q *pb.QuietHours, t time.Time) bool {
+	start, err1 := parseClock(q.Start)
+	end, err2 := parseClock(q.End)
+	if err1 != nil || err2 != nil || start == end {
+		return false
+	}
+
+	now := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if start < end {
+		return now >= start && now < end
+	}
+	return now >= start || now < end
+}
+
+// parseClock parses an "HH:MM" time of day as the duration since midnight.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// newID returns a short random ID for a schedule entry or playlist item.
+func newID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
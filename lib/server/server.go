@@ -1,54 +1,407 @@
 package server
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"image/png"
+	"io"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/disintegration/imaging"
+	"github.com/justmiles/epd/lib/dashboard"
 	"github.com/justmiles/epd/lib/display"
+	mdpng "github.com/justmiles/epd/lib/md-png"
 	pb "github.com/justmiles/epd/proto/epdpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// maxUploadSize bounds the size of images accepted by UploadImage.
+const maxUploadSize = 64 << 20
+
 // EPDServer implements the gRPC EPDService server interface.
 type EPDServer struct {
 	pb.UnimplementedEPDServiceServer
 	display *display.LocalDisplay
+
+	// name identifies the display on daemons serving several
+	name string
+
+	// pins are the GPIO pins NewEPDServer drives the panel on
+	pins display.Pins
+
+	// textOpts set the font of the panel NewEPDServer drives
+	textOpts display.TextOptions
+
+	// stateDir holds daemon state that should survive restarts
+	stateDir string
+
+	// authToken, when set, must be presented by every client
+	authToken string
+
+	// dashboard renders RenderDashboard requests with the daemon's own
+	// weather configuration
+	dashboard *dashboard.Dashboard
+
+	// templateDir holds the header and body files schedules and playlists
+	// may name; only these are executed as templates on the daemon
+	templateDir string
+
+	// location is the time zone schedules and quiet hours are evaluated in,
+	// the daemon's local one unless set
+	location *time.Location
+
+	// idleTimeout, when set, puts the panel to sleep after that long without
+	// a refresh
+	idleTimeout time.Duration
+	idleTimer   *time.Timer
+
+	// mu serializes access to the display hardware
+	mu sync.Mutex
+
+	// stateMu guards the queue depth, power state and refresh history;
+	// busyTimeouts counts the panel's busy waits that timed out
+	stateMu      sync.Mutex
+	queueDepth   int32
+	sleeping     bool
+	lastActivity time.Time
+	lastRefresh  time.Time
+	lastError    string
+	busyTimeouts int
+
+	// scheduleMu guards the scheduler's entries and quiet hours
+	scheduleMu sync.Mutex
+	scheduler  *scheduler
+
+	// playlistMu guards the playlist and its position
+	playlistMu sync.Mutex
+	playlist   *playlist
+
+	events  eventHub
+	metrics *metrics
+	health  *health.Server
+}
+
+// Option configures an EPDServer.
+type Option func(s *EPDServer)
+
+// WithStateDir persists daemon state (such as the current frame, the schedule
+// and the playlist) in dir.
+func WithStateDir(dir string) Option {
+	return func(s *EPDServer) {
+		s.stateDir = dir
+	}
+}
+
+// WithName names the display, for daemons serving several with a Mux.
+func WithName(name string) Option {
+	return func(s *EPDServer) {
+		s.name = name
+	}
+}
+
+// WithPins sets the GPIO pins of the panel NewEPDServer drives, for a panel
+// that is not on the default HAT pins.
+func WithPins(pins display.Pins) Option {
+	return func(s *EPDServer) {
+		s.pins = pins
+	}
+}
+
+// WithAuthToken requires clients to present token as a bearer token.
+func WithAuthToken(token string) Option {
+	return func(s *EPDServer) {
+		s.authToken = token
+	}
+}
+
+// WithIdleTimeout puts the panel to sleep after d without a refresh. The next
+// operation wakes it again. Zero keeps the panel awake.
+func WithIdleTimeout(d time.Duration) Option {
+	return func(s *EPDServer) {
+		s.idleTimeout = d
+	}
+}
+
+// WithLocation evaluates cron schedules and quiet hours in loc, e.g. the
+// dashboard's --location, instead of the daemon's local time zone.
+func WithLocation(loc *time.Location) Option {
+	return func(s *EPDServer) {
+		s.location = loc
+	}
+}
+
+// WithTemplateDir lets schedules and playlists show dashboards whose header
+// or body is a template file in dir, which only the operator should be able to
+// write. Without it, dashboard text is always shown as is.
+func WithTemplateDir(dir string) Option {
+	return func(s *EPDServer) {
+		s.templateDir = dir
+	}
+}
+
+// WithDashboard renders RenderDashboard requests with d. By default the
+// dashboard has no weather widget.
+func WithDashboard(d *dashboard.Dashboard) Option {
+	return func(s *EPDServer) {
+		s.dashboard = d
+	}
+}
+
+// WithTextOptions sets the font DisplayText requests are drawn in on the panel
+// NewEPDServer drives.
+func WithTextOptions(opts display.TextOptions) Option {
+	return func(s *EPDServer) {
+		s.textOpts = opts
+	}
 }
 
 // NewEPDServer creates a new gRPC server backed by a local display.
-func NewEPDServer(device string) (*EPDServer, error) {
-	d, err := display.NewLocalDisplay(device)
+func NewEPDServer(device string, opts ...Option) (*EPDServer, error) {
+	s := &EPDServer{pins: display.DefaultPins}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	displayOpts := []display.LocalOption{display.WithTextOptions(s.textOpts)}
+	if s.stateDir != "" {
+		if err := os.MkdirAll(s.stateDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create state directory: %w", err)
+		}
+		displayOpts = append(displayOpts, display.WithFrameFile(filepath.Join(s.stateDir, "frame.png")))
+	}
+
+	d, err := display.NewLocalDisplayWithPins(device, s.pins, displayOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create local display: %w", err)
 	}
 
-	// Initialize hardware on startup
-	d.HardwareInit()
-	log.Printf("EPD hardware initialized (device: %s)", device)
+	return s.start(d), nil
+}
 
-	return &EPDServer{
-		display: d,
-	}, nil
+// NewEPDServerWithDisplay creates a new gRPC server backed by an existing local
+// display. WithStateDir does not affect the display; configure its frame file
+// when creating it. The schedule and playlist are still persisted in the state
+// directory.
+func NewEPDServerWithDisplay(d *display.LocalDisplay, opts ...Option) *EPDServer {
+	s := &EPDServer{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s.start(d)
+}
+
+// start attaches the display and initializes its hardware.
+func (s *EPDServer) start(d *display.LocalDisplay) *EPDServer {
+	s.display = d
+	s.metrics = newMetrics(s)
+	if s.location == nil {
+		s.location = time.Local
+	}
+	if s.dashboard == nil {
+		s.dashboard = &dashboard.Dashboard{}
+	}
+	s.health = newHealthServer(s)
+
+	// Initialize hardware on startup. If it fails the daemon still starts,
+	// reporting NOT_SERVING, and retries before the next refresh.
+	if err := s.wake(caller{}); err != nil {
+		log.Printf("EPD hardware init failed (device: %s): %v", d.Device(), err)
+	} else {
+		log.Printf("EPD hardware initialized (device: %s)", d.Device())
+	}
+	s.touch()
+
+	s.startScheduler()
+	s.startPlaylist()
+	return s
+}
+
+// wake initializes the display hardware, marking the server NOT_SERVING if it
+// fails; the panel is then still considered asleep. Callers must hold s.mu or
+// otherwise have exclusive access to the display.
+func (s *EPDServer) wake(c caller) error {
+	if err := s.display.HardwareInit(); err != nil {
+		setServing(s.health, false)
+		s.stateMu.Lock()
+		s.sleeping = true
+		s.lastError = err.Error()
+		s.stateMu.Unlock()
+		return err
+	}
+	setServing(s.health, true)
+
+	s.stateMu.Lock()
+	s.sleeping = false
+	s.stateMu.Unlock()
+
+	s.events.publish(newEvent(pb.Event_WAKE, "HardwareInit", c))
+	return nil
+}
+
+// isSleeping reports whether the panel is in sleep mode.
+func (s *EPDServer) isSleeping() bool {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	return s.sleeping
+}
+
+// touch records display activity and restarts the idle timer. Callers must
+// hold s.mu or otherwise have exclusive access to the display.
+func (s *EPDServer) touch() {
+	s.stateMu.Lock()
+	s.lastActivity = time.Now()
+	s.stateMu.Unlock()
+
+	if s.idleTimeout <= 0 {
+		return
+	}
+	if s.idleTimer == nil {
+		s.idleTimer = time.AfterFunc(s.idleTimeout, s.idleSleep)
+	} else {
+		s.idleTimer.Reset(s.idleTimeout)
+	}
+}
+
+// idleSleep puts the panel to sleep once it has been idle for idleTimeout.
+func (s *EPDServer) idleSleep() {
+	err := s.enqueue(context.Background(), "IdleSleep", func() error {
+		// An operation may have run while this one was queued.
+		s.stateMu.Lock()
+		idle := time.Since(s.lastActivity)
+		s.stateMu.Unlock()
+		if idle < s.idleTimeout {
+			return nil
+		}
+
+		log.Printf("Display idle for %s, entering sleep mode", idle.Round(time.Second))
+		return s.sleepLocked(caller{}, "IdleSleep")
+	})
+	if err != nil {
+		log.Printf("Warning: failed to sleep idle display: %v", err)
+	}
 }
 
 // DisplayImage receives PNG data and displays it on the EPD.
 func (s *EPDServer) DisplayImage(ctx context.Context, req *pb.DisplayImageRequest) (*pb.DisplayImageResponse, error) {
 	log.Printf("Received DisplayImage request (%d bytes)", len(req.ImageData))
+	s.metrics.received("DisplayImage", len(req.ImageData))
 
-	if err := s.display.DisplayImage(req.ImageData); err != nil {
+	opts, err := imageOptions(req.Fit, req.Dither)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.refresh(ctx, "DisplayImage", func() error {
+		return s.display.DisplayImageReader(bytes.NewReader(req.ImageData), opts)
+	})
+	if err != nil {
 		log.Printf("DisplayImage error: %v", err)
-		return nil, fmt.Errorf("failed to display image: %w", err)
+		return nil, displayError("failed to display image", err)
 	}
 
 	log.Println("Image displayed successfully")
 	return &pb.DisplayImageResponse{Message: "Image displayed successfully"}, nil
 }
 
+// UploadImage receives PNG data as a stream of chunks, verifies its checksum and
+// displays it on the EPD. Chunks are spooled to a temporary file rather than
+// held in memory.
+func (s *EPDServer) UploadImage(stream pb.EPDService_UploadImageServer) error {
+	chunk, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "upload contained no data")
+	}
+	if err != nil {
+		return err
+	}
+
+	totalSize, checksum := chunk.TotalSize, strings.ToLower(chunk.Sha256)
+	if totalSize <= 0 || checksum == "" {
+		return status.Error(codes.InvalidArgument, "first chunk must set total_size and sha256")
+	}
+	opts, err := imageOptions(chunk.Fit, chunk.Dither)
+	if err != nil {
+		return err
+	}
+	if totalSize > maxUploadSize {
+		return status.Errorf(codes.ResourceExhausted, "image of %d bytes exceeds the %d byte upload limit", totalSize, maxUploadSize)
+	}
+	log.Printf("Received UploadImage request (%d bytes)", totalSize)
+
+	tmp, err := os.CreateTemp("", "epd-upload-*.png")
+	if err != nil {
+		return fmt.Errorf("failed to create upload file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	w := io.MultiWriter(tmp, hash)
+
+	var received int64
+	for {
+		received += int64(len(chunk.Data))
+		s.metrics.received("UploadImage", len(chunk.Data))
+		if received > totalSize {
+			return status.Errorf(codes.InvalidArgument, "upload exceeds declared size of %d bytes", totalSize)
+		}
+		if _, err := w.Write(chunk.Data); err != nil {
+			return fmt.Errorf("failed to write upload file: %w", err)
+		}
+
+		chunk, err = stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if received != totalSize {
+		return status.Errorf(codes.InvalidArgument, "received %d of %d bytes", received, totalSize)
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != checksum {
+		return status.Errorf(codes.DataLoss, "checksum mismatch: expected %s, got %s", checksum, sum)
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read upload file: %w", err)
+	}
+	err = s.refresh(stream.Context(), "UploadImage", func() error {
+		return s.display.DisplayImageReader(bufio.NewReader(tmp), opts)
+	})
+	if err != nil {
+		log.Printf("UploadImage error: %v", err)
+		return displayError("failed to display image", err)
+	}
+
+	log.Println("Image displayed successfully")
+	return stream.SendAndClose(&pb.DisplayImageResponse{Message: "Image displayed successfully"})
+}
+
 // DisplayText renders text and displays it on the EPD.
 func (s *EPDServer) DisplayText(ctx context.Context, req *pb.DisplayTextRequest) (*pb.DisplayTextResponse, error) {
 	log.Printf("Received DisplayText request: %q", req.Text)
+	s.metrics.received("DisplayText", len(req.Text))
 
-	if err := s.display.DisplayText(req.Text); err != nil {
+	err := s.refresh(ctx, "DisplayText", func() error {
+		return s.display.DisplayText(req.Text)
+	})
+	if err != nil {
 		log.Printf("DisplayText error: %v", err)
 		return nil, fmt.Errorf("failed to display text: %w", err)
 	}
@@ -57,11 +410,54 @@ func (s *EPDServer) DisplayText(ctx context.Context, req *pb.DisplayTextRequest)
 	return &pb.DisplayTextResponse{Message: "Text displayed successfully"}, nil
 }
 
+// DisplayMarkdown renders markdown to fill the panel and displays it on the EPD.
+func (s *EPDServer) DisplayMarkdown(ctx context.Context, req *pb.DisplayMarkdownRequest) (*pb.DisplayMarkdownResponse, error) {
+	log.Printf("Received DisplayMarkdown request (%d bytes)", len(req.Markdown))
+
+	if err := s.displayMarkdown(ctx, req.Markdown); err != nil {
+		log.Printf("DisplayMarkdown error: %v", err)
+		return nil, displayError("failed to display markdown", err)
+	}
+
+	log.Println("Markdown displayed successfully")
+	return &pb.DisplayMarkdownResponse{Message: "Markdown displayed successfully"}, nil
+}
+
+// RenderDashboard renders the dashboard with the given header and body and
+// displays it on the EPD.
+func (s *EPDServer) RenderDashboard(ctx context.Context, req *pb.RenderDashboardRequest) (*pb.RenderDashboardResponse, error) {
+	log.Printf("Received RenderDashboard request: %q", req.HeaderText)
+	s.metrics.received("RenderDashboard", len(req.HeaderText)+len(req.BodyText))
+
+	img, err := s.dashboard.RenderContext(ctx, req.HeaderText, req.BodyText)
+	if err != nil {
+		log.Printf("RenderDashboard error: %v", err)
+		return nil, fmt.Errorf("failed to render dashboard: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode dashboard: %w", err)
+	}
+
+	err = s.refresh(ctx, "RenderDashboard", func() error {
+		return s.display.DisplayImage(buf.Bytes())
+	})
+	if err != nil {
+		log.Printf("RenderDashboard error: %v", err)
+		return nil, fmt.Errorf("failed to display dashboard: %w", err)
+	}
+
+	log.Println("Dashboard displayed successfully")
+	return &pb.RenderDashboardResponse{Message: "Dashboard displayed successfully"}, nil
+}
+
 // Clear clears the EPD to white.
 func (s *EPDServer) Clear(ctx context.Context, req *pb.ClearRequest) (*pb.ClearResponse, error) {
 	log.Println("Received Clear request")
 
-	if err := s.display.Clear(); err != nil {
+	err := s.refresh(ctx, "Clear", s.display.Clear)
+	if err != nil {
 		log.Printf("Clear error: %v", err)
 		return nil, fmt.Errorf("failed to clear display: %w", err)
 	}
@@ -74,7 +470,7 @@ func (s *EPDServer) Clear(ctx context.Context, req *pb.ClearRequest) (*pb.ClearR
 func (s *EPDServer) Sleep(ctx context.Context, req *pb.SleepRequest) (*pb.SleepResponse, error) {
 	log.Println("Received Sleep request")
 
-	if err := s.display.Sleep(); err != nil {
+	if err := s.sleep(ctx, "Sleep"); err != nil {
 		log.Printf("Sleep error: %v", err)
 		return nil, fmt.Errorf("failed to sleep display: %w", err)
 	}
@@ -83,10 +479,162 @@ func (s *EPDServer) Sleep(ctx context.Context, req *pb.SleepRequest) (*pb.SleepR
 	return &pb.SleepResponse{Message: "Display sleeping"}, nil
 }
 
+// GetCurrentFrame returns the image currently shown on the EPD.
+func (s *EPDServer) GetCurrentFrame(ctx context.Context, req *pb.GetCurrentFrameRequest) (*pb.GetCurrentFrameResponse, error) {
+	log.Println("Received GetCurrentFrame request")
+
+	data, updated, err := s.display.CurrentFrame()
+	if errors.Is(err, display.ErrNoFrame) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		log.Printf("GetCurrentFrame error: %v", err)
+		return nil, fmt.Errorf("failed to get current frame: %w", err)
+	}
+
+	return &pb.GetCurrentFrameResponse{
+		ImageData: data,
+		UpdatedAt: timestamppb.New(updated),
+	}, nil
+}
+
+// Name returns the display's name, empty unless set with WithName.
+func (s *EPDServer) Name() string {
+	return s.name
+}
+
+// GetStatus reports the state of the EPD and the daemon's queue.
+func (s *EPDServer) GetStatus(ctx context.Context, req *pb.GetStatusRequest) (*pb.GetStatusResponse, error) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	resp := &pb.GetStatusResponse{
+		Device:     s.display.Device(),
+		Width:      int32(s.display.Width()),
+		Height:     int32(s.display.Height()),
+		QueueDepth: s.queueDepth,
+		LastError:  s.lastError,
+		Sleeping:   s.sleeping,
+		Display:    s.name,
+	}
+	if !s.lastRefresh.IsZero() {
+		resp.LastRefresh = timestamppb.New(s.lastRefresh)
+	}
+	return resp, nil
+}
+
+// ListDisplays reports the state of the display; a Mux reports all of its
+// displays.
+func (s *EPDServer) ListDisplays(ctx context.Context, req *pb.ListDisplaysRequest) (*pb.ListDisplaysResponse, error) {
+	st, err := s.GetStatus(ctx, &pb.GetStatusRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListDisplaysResponse{Displays: []*pb.GetStatusResponse{st}}, nil
+}
+
+// displayMarkdown renders markdown to fill the panel and displays it.
+func (s *EPDServer) displayMarkdown(ctx context.Context, markdown string) error {
+	s.metrics.received("DisplayMarkdown", len(markdown))
+
+	pngData, err := s.renderMarkdown(markdown)
+	if err != nil {
+		return err
+	}
+
+	return s.refresh(ctx, "DisplayMarkdown", func() error {
+		return s.display.DisplayImage(pngData)
+	})
+}
+
+// renderMarkdown renders markdown to a PNG the size of the panel.
+func (s *EPDServer) renderMarkdown(markdown string) ([]byte, error) {
+	var buf bytes.Buffer
+	err := mdpng.Convert([]byte(markdown), &buf,
+		mdpng.WithWidth(s.display.Width()),
+		mdpng.WithHeight(s.display.Height()),
+		mdpng.WithPadding(20),
+		mdpng.WithFontSize(20),
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not render markdown: %s", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// previewImage renders image data exactly as the panel would show it, without
+// touching the display, and returns it as PNG.
+func (s *EPDServer) previewImage(data []byte, opts display.ImageOptions) ([]byte, error) {
+	img, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %s", display.ErrInvalidImage, err)
+	}
+
+	var buf bytes.Buffer
+	frame := display.RenderImage(img, s.display.Width(), s.display.Height(), opts)
+	if err := png.Encode(&buf, frame); err != nil {
+		return nil, fmt.Errorf("failed to encode preview: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// imageOptions validates fit and dither modes from a request.
+func imageOptions(fit, dither string) (display.ImageOptions, error) {
+	opts := display.ImageOptions{Fit: fit, Dither: dither}
+	if err := opts.Validate(); err != nil {
+		return opts, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return opts, nil
+}
+
+// displayError wraps a display failure, reporting undecodable images as
+// invalid arguments rather than server errors.
+func displayError(msg string, err error) error {
+	if errors.Is(err, display.ErrInvalidImage) {
+		return status.Errorf(codes.InvalidArgument, "%s: %s", msg, err)
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// sleep queues a request to put the display into sleep mode.
+func (s *EPDServer) sleep(ctx context.Context, op string) error {
+	return s.enqueue(ctx, op, func() error {
+		return s.sleepLocked(callerFromContext(ctx), op)
+	})
+}
+
+// sleepLocked puts the display into sleep mode unless it is already asleep.
+// Callers must hold s.mu.
+func (s *EPDServer) sleepLocked(c caller, op string) error {
+	if s.isSleeping() {
+		return nil
+	}
+	if err := s.display.Sleep(); err != nil {
+		return err
+	}
+
+	s.stateMu.Lock()
+	s.sleeping = true
+	s.stateMu.Unlock()
+
+	s.events.publish(newEvent(pb.Event_SLEEP, op, c))
+	return nil
+}
+
 // Shutdown gracefully shuts down the server, putting the display to sleep.
 func (s *EPDServer) Shutdown() {
 	log.Println("Shutting down EPD server...")
-	if err := s.display.Sleep(); err != nil {
+	s.health.Shutdown()
+	s.stopScheduler()
+	s.stopPlaylist()
+
+	s.mu.Lock()
+	if s.idleTimer != nil {
+		s.idleTimer.Stop()
+	}
+	s.mu.Unlock()
+
+	if err := s.sleep(context.Background(), "Shutdown"); err != nil {
 		log.Printf("Warning: failed to sleep display on shutdown: %v", err)
 	}
 	s.display.Close()
@@ -0,0 +1,270 @@
+package server_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// MQTT 3.1.1 control packet types used by testBroker.
+const (
+	mqttConnect     = 1
+	mqttConnack     = 2
+	mqttPublish     = 3
+	mqttPuback      = 4
+	mqttSubscribe   = 8
+	mqttSuback      = 9
+	mqttUnsubscribe = 10
+	mqttUnsuback    = 11
+	mqttPingreq     = 12
+	mqttPingresp    = 13
+	mqttDisconnect  = 14
+)
+
+// testBroker is a minimal in-process MQTT 3.1.1 broker. It supports just
+// enough of the protocol for the daemon and a test client to talk to each
+// other: QoS 0 and 1 publishes (delivered at QoS 0), retained messages, wills
+// and exact or wildcard subscriptions.
+type testBroker struct {
+	ln net.Listener
+
+	mu       sync.Mutex
+	subs     map[*brokerConn][]string
+	retained map[string][]byte
+}
+
+type brokerConn struct {
+	net.Conn
+	wmu sync.Mutex
+}
+
+func newTestBroker(t *testing.T) *testBroker {
+	t.Helper()
+	return newTestBrokerOn(t, "127.0.0.1:0")
+}
+
+// newTestBrokerOn starts a test broker listening on addr.
+func newTestBrokerOn(t *testing.T, addr string) *testBroker {
+	t.Helper()
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	b := &testBroker{
+		ln:       ln,
+		subs:     map[*brokerConn][]string{},
+		retained: map[string][]byte{},
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(&brokerConn{Conn: conn})
+		}
+	}()
+	return b
+}
+
+// URL returns the broker URL for MQTT clients.
+func (b *testBroker) URL() string {
+	return "tcp://" + b.ln.Addr().String()
+}
+
+func (b *testBroker) serve(c *brokerConn) {
+	defer c.Close()
+	r := bufio.NewReader(c)
+
+	var will struct {
+		topic   string
+		payload []byte
+		retain  bool
+	}
+	cleanExit := false
+	defer func() {
+		b.mu.Lock()
+		delete(b.subs, c)
+		b.mu.Unlock()
+		if !cleanExit && will.topic != "" {
+			b.publish(will.topic, will.payload, will.retain)
+		}
+	}()
+
+	for {
+		header, body, err := readPacket(r)
+		if err != nil {
+			return
+		}
+
+		switch header >> 4 {
+		case mqttConnect:
+			// Skip protocol name, level and keep alive to reach the flags.
+			p := packetReader{body}
+			p.string()
+			p.next(1)
+			flags := p.next(1)[0]
+			p.next(2)
+			p.string() // client ID
+			if flags&0x04 != 0 {
+				will.topic = p.string()
+				will.payload = []byte(p.string())
+				will.retain = flags&0x20 != 0
+			}
+			c.write(mqttConnack<<4, []byte{0, 0})
+
+		case mqttPublish:
+			p := packetReader{body}
+			topic := p.string()
+			if qos := (header >> 1) & 0x03; qos > 0 {
+				c.write(mqttPuback<<4, p.next(2))
+			}
+			b.publish(topic, p.b, header&0x01 != 0)
+
+		case mqttSubscribe:
+			p := packetReader{body}
+			id := p.next(2)
+			var filters []string
+			granted := []byte{}
+			for len(p.b) > 0 {
+				filters = append(filters, p.string())
+				p.next(1)
+				granted = append(granted, 0)
+			}
+			b.mu.Lock()
+			b.subs[c] = append(b.subs[c], filters...)
+			b.mu.Unlock()
+			c.write(mqttSuback<<4, append(id, granted...))
+
+			b.mu.Lock()
+			for topic, payload := range b.retained {
+				for _, f := range filters {
+					if topicMatches(f, topic) {
+						c.write(mqttPublish<<4|0x01, publishBody(topic, payload))
+						break
+					}
+				}
+			}
+			b.mu.Unlock()
+
+		case mqttUnsubscribe:
+			c.write(mqttUnsuback<<4, body[:2])
+
+		case mqttPingreq:
+			c.write(mqttPingresp<<4, nil)
+
+		case mqttDisconnect:
+			cleanExit = true
+			return
+		}
+	}
+}
+
+// publish delivers a message to every matching subscriber and retains it if
+// asked to.
+func (b *testBroker) publish(topic string, payload []byte, retain bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if retain {
+		b.retained[topic] = payload
+	}
+	for c, filters := range b.subs {
+		for _, f := range filters {
+			if topicMatches(f, topic) {
+				c.write(mqttPublish<<4, publishBody(topic, payload))
+				break
+			}
+		}
+	}
+}
+
+func (c *brokerConn) write(header byte, body []byte) {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	pkt := []byte{header}
+	n := len(body)
+	for {
+		d := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			d |= 0x80
+		}
+		pkt = append(pkt, d)
+		if n == 0 {
+			break
+		}
+	}
+	c.Write(append(pkt, body...))
+}
+
+func readPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length, mult := 0, 1
+	for {
+		d, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(d&0x7f) * mult
+		if d&0x80 == 0 {
+			break
+		}
+		mult *= 128
+		if mult > 128*128*128 {
+			return 0, nil, errors.New("malformed remaining length")
+		}
+	}
+
+	body := make([]byte, length)
+	_, err = io.ReadFull(r, body)
+	return header, body, err
+}
+
+func publishBody(topic string, payload []byte) []byte {
+	body := binary.BigEndian.AppendUint16(nil, uint16(len(topic)))
+	body = append(body, topic...)
+	return append(body, payload...)
+}
+
+// topicMatches reports whether an MQTT topic filter matches topic.
+func topicMatches(filter, topic string) bool {
+	f, t := strings.Split(filter, "/"), strings.Split(topic, "/")
+	for i, part := range f {
+		if part == "#" {
+			return true
+		}
+		if i >= len(t) || (part != "+" && part != t[i]) {
+			return false
+		}
+	}
+	return len(f) == len(t)
+}
+
+// packetReader consumes fields from an MQTT packet body.
+type packetReader struct {
+	b []byte
+}
+
+func (p *packetReader) next(n int) []byte {
+	v := p.b[:n]
+	p.b = p.b[n:]
+	return v
+}
+
+func (p *packetReader) string() string {
+	n := int(binary.BigEndian.Uint16(p.next(2)))
+	return string(p.next(n))
+}
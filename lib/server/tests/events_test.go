@@ -0,0 +1,179 @@
+package server_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/display"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// watch subscribes to the daemon's events and returns them as they arrive,
+// once the subscription is live.
+func watch(t *testing.T, addr string) <-chan *pb.Event {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	events := make(chan *pb.Event, 1024)
+	go dialRemote(t, addr).WatchEvents(ctx, func(e *pb.Event) { events <- e })
+
+	// Events published before the stream subscribes are not delivered, so
+	// refresh until one arrives
+	ping := dialRemote(t, addr, display.WithClientID("ping"))
+	waitFor(t, "the subscription", func() bool {
+		if err := ping.Clear(); err != nil {
+			t.Fatalf("Clear failed: %v", err)
+		}
+		return len(events) > 0
+	})
+	time.Sleep(100 * time.Millisecond)
+	for len(events) > 0 {
+		<-events
+	}
+	return events
+}
+
+// nextEvent returns the next event for op, skipping others.
+func nextEvent(t *testing.T, events <-chan *pb.Event, op string) *pb.Event {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case e := <-events:
+			if e.Operation == op {
+				return e
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for a %s event", op)
+		}
+	}
+}
+
+func TestWatchEvents(t *testing.T) {
+	s, _ := newEPDServer(t)
+	t.Cleanup(s.Shutdown)
+	addr := serveGRPC(t, s)
+
+	// Every subscriber gets every event
+	watchers := []<-chan *pb.Event{watch(t, addr), watch(t, addr)}
+
+	client := dialRemote(t, addr, display.WithClientID("alice@laptop"))
+	if err := client.DisplayText("Hello"); err != nil {
+		t.Fatalf("DisplayText failed: %v", err)
+	}
+
+	want := []struct {
+		typ   pb.Event_Type
+		depth int32
+	}{
+		{pb.Event_QUEUE_CHANGED, 1},
+		{pb.Event_REFRESH_STARTED, 0},
+		{pb.Event_REFRESH_COMPLETED, 0},
+		{pb.Event_QUEUE_CHANGED, 0},
+	}
+	for i, events := range watchers {
+		for _, w := range want {
+			e := nextEvent(t, events, "DisplayText")
+			if e.Type != w.typ || e.QueueDepth != w.depth {
+				t.Errorf("Watcher %d: expected %s depth=%d, got %s depth=%d", i, w.typ, w.depth, e.Type, e.QueueDepth)
+			}
+			if e.ClientId != "alice@laptop" {
+				t.Errorf("Watcher %d: expected the event attributed to alice@laptop, got %q", i, e.ClientId)
+			}
+			if !strings.HasPrefix(e.ClientAddress, "127.0.0.1:") {
+				t.Errorf("Watcher %d: expected the client's address, got %q", i, e.ClientAddress)
+			}
+			if e.Time == nil {
+				t.Errorf("Watcher %d: expected the event's time", i)
+			}
+			if e.Type == pb.Event_REFRESH_COMPLETED && e.StartedAt == nil {
+				t.Errorf("Watcher %d: expected the refresh's start time", i)
+			}
+		}
+	}
+}
+
+func TestWatchEvents_SlowSubscriber(t *testing.T) {
+	s, drv := newEPDServer(t)
+	t.Cleanup(s.Shutdown)
+	addr := serveGRPC(t, s)
+
+	// A subscriber that never reads, with the smallest flow control windows
+	// so its stream backs up quickly
+	conn, err := grpc.Dial(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithInitialWindowSize(64<<10),
+		grpc.WithInitialConnWindowSize(64<<10),
+	)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	slow, err := pb.NewEPDServiceClient(conn).WatchEvents(ctx, &pb.WatchEventsRequest{})
+	if err != nil {
+		t.Fatalf("WatchEvents failed: %v", err)
+	}
+
+	fast := watch(t, addr)
+
+	// Refreshes are never held up by the slow subscriber. A long client ID
+	// makes every event large enough to fill the stream's window quickly.
+	const refreshes = 200
+	client := dialRemote(t, addr, display.WithClientID(strings.Repeat("x", 4<<10)))
+	done := make(chan error)
+	go func() {
+		for i := 0; i < refreshes; i++ {
+			if err := client.Clear(); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Clear failed: %v", err)
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatal("Timed out refreshing with a slow subscriber")
+	}
+	if n := clears(drv); n < refreshes {
+		t.Errorf("Expected %d clears, got %d", refreshes, n)
+	}
+
+	// Other subscribers still get events
+	if err := client.DisplayText("Hello"); err != nil {
+		t.Fatalf("DisplayText failed: %v", err)
+	}
+	nextEvent(t, fast, "DisplayText")
+
+	// The slow subscriber missed events rather than buffering them all
+	received := 0
+	go func() {
+		time.Sleep(time.Second)
+		cancel()
+	}()
+	for {
+		if _, err := slow.Recv(); err != nil {
+			break
+		}
+		received++
+	}
+	if total := 4 * (refreshes + 1); received >= total {
+		t.Errorf("Expected the slow subscriber to miss some of %d events, got %d", total, received)
+	}
+}
+
+func clears(drv *fakeDriver) int {
+	drv.mu.Lock()
+	defer drv.mu.Unlock()
+	return drv.clears
+}
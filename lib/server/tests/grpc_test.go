@@ -0,0 +1,163 @@
+package server_test
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/justmiles/epd/lib/dashboard"
+	"github.com/justmiles/epd/lib/display"
+	"github.com/justmiles/epd/lib/server"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// serveGRPC serves s over gRPC on a loopback port, the way epd serve does, and
+// returns its address. opts add to the server's options, e.g. interceptors.
+func serveGRPC(t *testing.T, s *server.EPDServer, opts ...grpc.ServerOption) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	grpcServer := grpc.NewServer(append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(s.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(s.StreamInterceptor()),
+	}, opts...)...)
+	pb.RegisterEPDServiceServer(grpcServer, s)
+	healthpb.RegisterHealthServer(grpcServer, s.HealthServer())
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	return lis.Addr().String()
+}
+
+func dialRemote(t *testing.T, addr string, opts ...display.RemoteOption) *display.RemoteDisplay {
+	t.Helper()
+	r, err := display.NewRemoteDisplay(addr, opts...)
+	if err != nil {
+		t.Fatalf("NewRemoteDisplay failed: %v", err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestGRPC_DisplayMarkdown(t *testing.T) {
+	s, drv := newEPDServer(t)
+	client := dialRemote(t, serveGRPC(t, s))
+
+	if err := client.DisplayMarkdown("# Hello\n\n- one\n- two"); err != nil {
+		t.Fatalf("DisplayMarkdown failed: %v", err)
+	}
+
+	drv.mu.Lock()
+	defer drv.mu.Unlock()
+	if drv.displays != 1 {
+		t.Errorf("Expected 1 display update, got %d", drv.displays)
+	}
+}
+
+func TestGRPC_RenderDashboard(t *testing.T) {
+	s, drv := newEPDServer(t)
+	client := dialRemote(t, serveGRPC(t, s))
+
+	if err := client.RenderDashboard("Today", "- [ ] Water the plants"); err != nil {
+		t.Fatalf("RenderDashboard failed: %v", err)
+	}
+
+	drv.mu.Lock()
+	if drv.displays != 1 {
+		t.Errorf("Expected 1 display update, got %d", drv.displays)
+	}
+	drv.mu.Unlock()
+
+	frame, _, err := client.CurrentFrame()
+	if err != nil {
+		t.Fatalf("CurrentFrame failed: %v", err)
+	}
+	if len(frame) == 0 {
+		t.Error("Expected the dashboard to be the current frame")
+	}
+}
+
+func TestGRPC_RenderDashboard_NoTemplates(t *testing.T) {
+	t.Setenv("EPD_TEST_SECRET", "s3cret")
+	d, err := dashboard.NewDashboard(dashboard.WithTemplates(true), dashboard.WithTemplateHost(true))
+	if err != nil {
+		t.Fatalf("NewDashboard failed: %v", err)
+	}
+	templateDir := t.TempDir()
+	s, drv := newEPDServer(t, server.WithDashboard(d), server.WithTemplateDir(templateDir))
+	t.Cleanup(s.Shutdown)
+	client := dialRemote(t, serveGRPC(t, s))
+
+	render := func(header string) []byte {
+		t.Helper()
+		if err := client.RenderDashboard(header, ""); err != nil {
+			t.Fatalf("RenderDashboard failed: %v", err)
+		}
+		frame, _, err := client.CurrentFrame()
+		if err != nil {
+			t.Fatalf("CurrentFrame failed: %v", err)
+		}
+		return frame
+	}
+	schedule := func(header string) []byte {
+		t.Helper()
+		before := displays(drv)
+		entry, err := client.AddSchedule(&pb.Schedule{Cron: "@every 1s", Content: &pb.Schedule_Dashboard{Dashboard: &pb.DashboardContent{HeaderText: header}}})
+		if err != nil {
+			t.Fatalf("AddSchedule failed: %v", err)
+		}
+		waitFor(t, "the schedule to run", func() bool { return displays(drv) > before })
+		if err := client.RemoveSchedule(entry.Id); err != nil {
+			t.Fatalf("RemoveSchedule failed: %v", err)
+		}
+		frame, _, err := client.CurrentFrame()
+		if err != nil {
+			t.Fatalf("CurrentFrame failed: %v", err)
+		}
+		return frame
+	}
+
+	// Text sent by clients is never executed on the daemon, even with
+	// templates and host access enabled
+	sent := render(`{{ env "EPD_TEST_SECRET" }}`)
+	secret := render("s3cret")
+	if bytes.Equal(sent, secret) {
+		t.Fatal("Expected the daemon to refuse to execute a client's template")
+	}
+
+	// Nor are files outside the template directory a client names, such as
+	// ones it can write through the daemon
+	outside := filepath.Join(t.TempDir(), "header.txt")
+	if err := os.WriteFile(outside, []byte(`{{ env "EPD_TEST_SECRET" }}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if frame := schedule(outside); bytes.Equal(frame, secret) {
+		t.Error("Expected a file outside the template directory not to be executed")
+	}
+	if frame, path := schedule(outside), render(outside); !bytes.Equal(frame, path) {
+		t.Error("Expected the path of a file outside the template directory to be shown as is")
+	}
+	link := filepath.Join(templateDir, "link.txt")
+	if err := os.Symlink(outside, link); err != nil {
+		t.Fatal(err)
+	}
+	if frame := schedule(link); bytes.Equal(frame, secret) {
+		t.Error("Expected a link out of the template directory not to be executed")
+	}
+
+	// Templates in the template directory are executed
+	header := filepath.Join(templateDir, "header.txt")
+	if err := os.WriteFile(header, []byte(`{{ env "EPD_TEST_SECRET" }}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if frame := schedule(header); !bytes.Equal(frame, secret) {
+		t.Error("Expected the template in the template directory to be executed")
+	}
+}
@@ -0,0 +1,131 @@
+package server_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/display"
+	"github.com/justmiles/epd/lib/server"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// hangingDriver is a fakeDriver whose busy waits time out while hung is set.
+type hangingDriver struct {
+	fakeDriver
+	busyHook func(time.Duration, bool)
+	hung     atomic.Bool
+}
+
+func (d *hangingDriver) SetBusyHook(fn func(time.Duration, bool)) { d.busyHook = fn }
+
+func (d *hangingDriver) Display(buf []byte) {
+	d.fakeDriver.Display(buf)
+	d.busyHook(time.Second, d.hung.Load())
+}
+
+// deadDriver is a fakeDriver whose hardware init fails while dead is set.
+type deadDriver struct {
+	fakeDriver
+	busyHook func(time.Duration, bool)
+	dead     atomic.Bool
+}
+
+func (d *deadDriver) SetBusyHook(fn func(time.Duration, bool)) { d.busyHook = fn }
+
+func (d *deadDriver) HardwareInit() error {
+	if d.dead.Load() {
+		return errors.New("panel did not power on")
+	}
+	return d.fakeDriver.HardwareInit()
+}
+
+func TestHealth(t *testing.T) {
+	drv := &hangingDriver{}
+	d, err := display.NewLocalDisplayWithDriver("epd7in5v2", drv, 800, 480)
+	if err != nil {
+		t.Fatalf("NewLocalDisplayWithDriver failed: %v", err)
+	}
+	s := server.NewEPDServerWithDisplay(d, server.WithAuthToken("s3cret"))
+
+	addr := serveGRPC(t, s)
+
+	// Health checks don't need the token, but everything else does.
+	anonymous := dialRemote(t, addr)
+	client := dialRemote(t, addr, display.WithToken("s3cret"))
+
+	expectHealth := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		got, err := anonymous.Health()
+		if err != nil {
+			t.Fatalf("Health failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	}
+
+	expectHealth(healthpb.HealthCheckResponse_SERVING)
+	if err := anonymous.DisplayText("Hello"); err == nil {
+		t.Error("Expected DisplayText without a token to fail")
+	}
+
+	drv.hung.Store(true)
+	if err := client.DisplayText("Hello"); err != nil {
+		t.Fatalf("DisplayText failed: %v", err)
+	}
+	expectHealth(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	drv.hung.Store(false)
+	if err := client.DisplayText("Hello again"); err != nil {
+		t.Fatalf("DisplayText failed: %v", err)
+	}
+	expectHealth(healthpb.HealthCheckResponse_SERVING)
+
+	s.Shutdown()
+	expectHealth(healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestHealth_HardwareInitFails(t *testing.T) {
+	drv := &deadDriver{}
+	drv.dead.Store(true)
+	d, err := display.NewLocalDisplayWithDriver("epd7in5v2", drv, 800, 480)
+	if err != nil {
+		t.Fatalf("NewLocalDisplayWithDriver failed: %v", err)
+	}
+	s := server.NewEPDServerWithDisplay(d)
+	t.Cleanup(s.Shutdown)
+	client := dialRemote(t, serveGRPC(t, s))
+
+	expectHealth := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		got, err := client.Health()
+		if err != nil {
+			t.Fatalf("Health failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	}
+
+	// The daemon starts anyway, reporting NOT_SERVING
+	expectHealth(healthpb.HealthCheckResponse_NOT_SERVING)
+	if err := client.DisplayText("Hello"); err == nil {
+		t.Error("Expected DisplayText to fail while the hardware init fails")
+	}
+
+	// A busy wait that finishes in time doesn't show the panel has recovered
+	drv.busyHook(time.Millisecond, false)
+	expectHealth(healthpb.HealthCheckResponse_NOT_SERVING)
+	if n := displays(&drv.fakeDriver); n != 0 {
+		t.Errorf("Expected no display updates, got %d", n)
+	}
+
+	// The next refresh retries the init
+	drv.dead.Store(false)
+	if err := client.DisplayText("Hello"); err != nil {
+		t.Fatalf("DisplayText failed: %v", err)
+	}
+	expectHealth(healthpb.HealthCheckResponse_SERVING)
+}
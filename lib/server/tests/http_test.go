@@ -0,0 +1,304 @@
+package server_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/justmiles/epd/lib/display"
+	"github.com/justmiles/epd/lib/server"
+)
+
+// fakeDriver records the calls made to the panel.
+type fakeDriver struct {
+	mu       sync.Mutex
+	inits    int
+	displays int
+	clears   int
+	sleeps   int
+}
+
+func (f *fakeDriver) HardwareInit() error { f.mu.Lock(); f.inits++; f.mu.Unlock(); return nil }
+func (f *fakeDriver) Display([]byte)      { f.mu.Lock(); f.displays++; f.mu.Unlock() }
+func (f *fakeDriver) Clear()              { f.mu.Lock(); f.clears++; f.mu.Unlock() }
+func (f *fakeDriver) Sleep()              { f.mu.Lock(); f.sleeps++; f.mu.Unlock() }
+
+// newEPDServer creates an EPDServer backed by a fake 800x480 panel.
+func newEPDServer(t *testing.T, opts ...server.Option) (*server.EPDServer, *fakeDriver) {
+	t.Helper()
+	drv := &fakeDriver{}
+	d, err := display.NewLocalDisplayWithDriver("epd7in5v2", drv, 800, 480)
+	if err != nil {
+		t.Fatalf("NewLocalDisplayWithDriver failed: %v", err)
+	}
+	return server.NewEPDServerWithDisplay(d, opts...), drv
+}
+
+func newTestServer(t *testing.T, opts ...server.Option) (*httptest.Server, *fakeDriver) {
+	t.Helper()
+	s, drv := newEPDServer(t, opts...)
+	srv := httptest.NewServer(s.HTTPHandler())
+	t.Cleanup(srv.Close)
+	return srv, drv
+}
+
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		img.SetGray(x, h/2, color.Gray{Y: 0})
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func post(t *testing.T, url, contentType string, body []byte) *http.Response {
+	t.Helper()
+	resp, err := http.Post(url, contentType, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST %s failed: %v", url, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestHTTP_Text(t *testing.T) {
+	srv, drv := newTestServer(t)
+
+	resp := post(t, srv.URL+"/v1/text", "text/plain", []byte("Hello World"))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+
+	resp = post(t, srv.URL+"/v1/text", "application/json", []byte(`{"text": "Hello JSON"}`))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+
+	if drv.displays != 2 {
+		t.Errorf("Expected 2 panel updates, got %d", drv.displays)
+	}
+}
+
+func TestHTTP_ImageRawBody(t *testing.T) {
+	srv, drv := newTestServer(t)
+
+	resp := post(t, srv.URL+"/v1/image", "image/png", testPNG(t, 400, 240))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+	if drv.displays != 1 {
+		t.Errorf("Expected 1 panel update, got %d", drv.displays)
+	}
+}
+
+func TestHTTP_ImageMultipart(t *testing.T) {
+	srv, drv := newTestServer(t)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("image", "test.png")
+	if err != nil {
+		t.Fatalf("CreateFormFile failed: %v", err)
+	}
+	fw.Write(testPNG(t, 800, 480))
+	mw.Close()
+
+	resp := post(t, srv.URL+"/v1/image", mw.FormDataContentType(), body.Bytes())
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+	if drv.displays != 1 {
+		t.Errorf("Expected 1 panel update, got %d", drv.displays)
+	}
+}
+
+func TestHTTP_InvalidImage(t *testing.T) {
+	srv, drv := newTestServer(t)
+
+	resp := post(t, srv.URL+"/v1/image", "image/png", []byte("not an image"))
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d", resp.StatusCode)
+	}
+	if drv.displays != 0 {
+		t.Errorf("Expected no panel updates, got %d", drv.displays)
+	}
+}
+
+func TestHTTP_Markdown(t *testing.T) {
+	srv, drv := newTestServer(t)
+
+	resp := post(t, srv.URL+"/v1/markdown", "text/markdown", []byte("# TODOs\n\n- [x] Ship it\n"))
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+	if drv.displays != 1 {
+		t.Errorf("Expected 1 panel update, got %d", drv.displays)
+	}
+}
+
+func TestHTTP_ClearSleepAndStatus(t *testing.T) {
+	srv, drv := newTestServer(t)
+
+	if resp := post(t, srv.URL+"/v1/clear", "", nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200 from clear, got %d", resp.StatusCode)
+	}
+	if resp := post(t, srv.URL+"/v1/sleep", "", nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200 from sleep, got %d", resp.StatusCode)
+	}
+	if drv.clears != 1 || drv.sleeps != 1 {
+		t.Errorf("Expected 1 clear and 1 sleep, got %d and %d", drv.clears, drv.sleeps)
+	}
+
+	resp, err := http.Get(srv.URL + "/v1/status")
+	if err != nil {
+		t.Fatalf("GET status failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var status map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Fatalf("Invalid status JSON: %v", err)
+	}
+	if status["device"] != "epd7in5v2" {
+		t.Errorf("Expected device epd7in5v2, got %v", status["device"])
+	}
+	if _, ok := status["last_refresh"]; !ok {
+		t.Error("Expected last_refresh to be set after clear")
+	}
+}
+
+func TestHTTP_Screenshot(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	resp, err := http.Get(srv.URL + "/v1/screenshot.png")
+	if err != nil {
+		t.Fatalf("GET screenshot failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected 404 before anything is displayed, got %d", resp.StatusCode)
+	}
+
+	post(t, srv.URL+"/v1/clear", "", nil)
+
+	resp, err = http.Get(srv.URL + "/v1/screenshot.png")
+	if err != nil {
+		t.Fatalf("GET screenshot failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	img, err := png.Decode(resp.Body)
+	if err != nil {
+		t.Fatalf("Screenshot is not a valid PNG: %v", err)
+	}
+	if img.Bounds().Dx() != 800 || img.Bounds().Dy() != 480 {
+		t.Errorf("Expected 800x480 screenshot, got %v", img.Bounds())
+	}
+	if r, g, b, _ := img.At(400, 240).RGBA(); r>>8 != 255 || g>>8 != 255 || b>>8 != 255 {
+		t.Errorf("Expected white pixel after clear, got RGB(%d, %d, %d)", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestHTTP_Auth(t *testing.T) {
+	srv, drv := newTestServer(t, server.WithAuthToken("s3cret"))
+
+	resp := post(t, srv.URL+"/v1/text", "text/plain", []byte("Hello"))
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 without token, got %d", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/v1/text", strings.NewReader("Hello"))
+	req.Header.Set("Authorization", "Bearer s3cret")
+	authed, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST text failed: %v", err)
+	}
+	defer authed.Body.Close()
+	if authed.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200 with token, got %d", authed.StatusCode)
+	}
+
+	if drv.displays != 1 {
+		t.Errorf("Expected 1 panel update, got %d", drv.displays)
+	}
+}
+
+func TestHTTP_UnknownRoute(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	resp, err := http.Get(srv.URL + "/v1/text")
+	if err != nil {
+		t.Fatalf("GET text failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET on a POST route, got %d", resp.StatusCode)
+	}
+}
+
+func TestHTTP_PreviewImage(t *testing.T) {
+	srv, drv := newTestServer(t)
+
+	for _, dither := range []string{"none", "floyd-steinberg", "atkinson"} {
+		t.Run(dither, func(t *testing.T) {
+			resp := post(t, srv.URL+"/v1/preview/image?fit=contain&dither="+dither, "image/png", testPNG(t, 200, 200))
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("Expected 200, got %d", resp.StatusCode)
+			}
+
+			img, err := png.Decode(resp.Body)
+			if err != nil {
+				t.Fatalf("Preview is not a valid PNG: %v", err)
+			}
+			if img.Bounds().Dx() != 800 || img.Bounds().Dy() != 480 {
+				t.Errorf("Expected 800x480 preview, got %v", img.Bounds())
+			}
+			// Contain pads the square image with white on either side.
+			if r, _, _, _ := img.At(10, 240).RGBA(); r>>8 != 255 {
+				t.Errorf("Expected white padding, got %d", r>>8)
+			}
+		})
+	}
+
+	if drv.displays != 0 {
+		t.Errorf("Expected previews not to update the panel, got %d updates", drv.displays)
+	}
+}
+
+func TestHTTP_InvalidFit(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	resp := post(t, srv.URL+"/v1/image?fit=sideways", "image/png", testPNG(t, 200, 200))
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestHTTP_WebUI(t *testing.T) {
+	srv, _ := newTestServer(t, server.WithAuthToken("s3cret"))
+
+	resp, err := http.Get(srv.URL + "/")
+	if err != nil {
+		t.Fatalf("GET / failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected the web UI to load without a token, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Expected text/html, got %s", ct)
+	}
+}
@@ -0,0 +1,97 @@
+package server_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/display"
+	"github.com/justmiles/epd/lib/server"
+)
+
+// busyDriver is a fakeDriver that reports busy waits.
+type busyDriver struct {
+	fakeDriver
+	busyHook func(time.Duration, bool)
+}
+
+func (d *busyDriver) SetBusyHook(fn func(time.Duration, bool)) { d.busyHook = fn }
+
+func (d *busyDriver) Display(buf []byte) {
+	d.fakeDriver.Display(buf)
+	d.busyHook(1500*time.Millisecond, false)
+}
+
+func scrape(t *testing.T, url string) string {
+	t.Helper()
+	resp, err := http.Get(url + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func expectMetric(t *testing.T, body, pattern string) {
+	t.Helper()
+	if !regexp.MustCompile(`(?m)^` + pattern + `$`).MatchString(body) {
+		t.Errorf("Expected a metric matching %q", pattern)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	srv, _ := newTestServer(t, server.WithAuthToken("s3cret"))
+
+	req, _ := http.NewRequest("POST", srv.URL+"/v1/text", strings.NewReader("Hello"))
+	req.Header.Set("Authorization", "Bearer s3cret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST /v1/text failed: %v", err)
+	}
+	resp.Body.Close()
+
+	req, _ = http.NewRequest("POST", srv.URL+"/v1/image", strings.NewReader("not an image"))
+	req.Header.Set("Authorization", "Bearer s3cret")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST /v1/image failed: %v", err)
+	}
+	resp.Body.Close()
+
+	// Metrics are served without the token.
+	body := scrape(t, srv.URL)
+	expectMetric(t, body, `epd_refreshes_total\{operation="DisplayText",outcome="success"\} 1`)
+	expectMetric(t, body, `epd_refreshes_total\{operation="DisplayImage",outcome="failure"\} 1`)
+	expectMetric(t, body, `epd_refresh_duration_seconds_count\{operation="DisplayText"\} 1`)
+	expectMetric(t, body, `epd_received_bytes_total\{rpc="DisplayText"\} 5`)
+	expectMetric(t, body, `epd_received_bytes_total\{rpc="DisplayImage"\} 12`)
+	expectMetric(t, body, `epd_queue_depth 0`)
+	expectMetric(t, body, `epd_last_refresh_timestamp_seconds [0-9.e+]+`)
+	expectMetric(t, body, `epd_busy_wait_seconds_count 0`)
+}
+
+func TestMetrics_BusyWait(t *testing.T) {
+	drv := &busyDriver{}
+	d, err := display.NewLocalDisplayWithDriver("epd7in5v2", drv, 800, 480)
+	if err != nil {
+		t.Fatalf("NewLocalDisplayWithDriver failed: %v", err)
+	}
+	srv := httptest.NewServer(server.NewEPDServerWithDisplay(d).HTTPHandler())
+	defer srv.Close()
+
+	post(t, srv.URL+"/v1/text", "text/plain", []byte("Hello"))
+
+	body := scrape(t, srv.URL)
+	expectMetric(t, body, `epd_busy_wait_seconds_count 1`)
+	expectMetric(t, body, `epd_busy_wait_seconds_sum 1.5`)
+	expectMetric(t, body, `epd_busy_timeouts_total 0`)
+}
@@ -0,0 +1,175 @@
+package server_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/justmiles/epd/lib/server"
+)
+
+// mqttTestClient subscribes to the daemon's state and availability topics.
+type mqttTestClient struct {
+	mqtt.Client
+	messages chan mqtt.Message
+}
+
+func newMQTTTestClient(t *testing.T, broker string) *mqttTestClient {
+	t.Helper()
+	c := &mqttTestClient{messages: make(chan mqtt.Message, 64)}
+	c.Client = mqtt.NewClient(mqtt.NewClientOptions().AddBroker(broker).SetClientID("test"))
+	if token := c.Connect(); token.Wait() && token.Error() != nil {
+		t.Fatalf("Test client failed to connect: %v", token.Error())
+	}
+	t.Cleanup(func() { c.Disconnect(0) })
+
+	token := c.Subscribe("epd/#", 0, func(_ mqtt.Client, m mqtt.Message) {
+		c.messages <- m
+	})
+	if token.Wait(); token.Error() != nil {
+		t.Fatalf("Test client failed to subscribe: %v", token.Error())
+	}
+	return c
+}
+
+func (c *mqttTestClient) publish(t *testing.T, topic string, payload any) {
+	t.Helper()
+	if token := c.Publish(topic, 1, false, payload); token.Wait() && token.Error() != nil {
+		t.Fatalf("Failed to publish to %s: %v", topic, token.Error())
+	}
+}
+
+// waitFor returns the first message on topic whose payload satisfies match.
+func (c *mqttTestClient) waitFor(t *testing.T, topic string, match func(payload []byte) bool) []byte {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case m := <-c.messages:
+			if m.Topic() == topic && match(m.Payload()) {
+				return m.Payload()
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for a message on %s", topic)
+		}
+	}
+}
+
+// waitForEvent waits for a state update prompted by event.
+func (c *mqttTestClient) waitForEvent(t *testing.T, event string) map[string]any {
+	t.Helper()
+	var state map[string]any
+	c.waitFor(t, "epd/state", func(payload []byte) bool {
+		state = nil
+		json.Unmarshal(payload, &state)
+		return state["event"] == event
+	})
+	return state
+}
+
+func TestMQTT(t *testing.T) {
+	broker := newTestBroker(t)
+	client := newMQTTTestClient(t, broker.URL())
+	s, drv := newEPDServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.RunMQTT(ctx, server.MQTTConfig{Broker: broker.URL(), ClientID: "epd", Topic: "epd"})
+	}()
+
+	client.waitFor(t, "epd/availability", func(p []byte) bool { return string(p) == "online" })
+
+	client.publish(t, "epd/text", "Hello from MQTT")
+	state := client.waitForEvent(t, "REFRESH_COMPLETED")
+	if state["device"] != "epd7in5v2" || state["width"] != 800.0 {
+		t.Errorf("Unexpected state: %v", state)
+	}
+
+	client.publish(t, "epd/markdown", "# Hello")
+	client.waitForEvent(t, "REFRESH_COMPLETED")
+
+	client.publish(t, "epd/image", testPNG(t, 200, 100))
+	client.waitForEvent(t, "REFRESH_COMPLETED")
+
+	client.publish(t, "epd/image", []byte("not an image"))
+	state = client.waitForEvent(t, "REFRESH_FAILED")
+	if state["last_error"] == nil {
+		t.Errorf("Expected last_error in state after a failed refresh, got %v", state)
+	}
+
+	image := base64.StdEncoding.EncodeToString(testPNG(t, 100, 100))
+	client.publish(t, "epd/command", fmt.Sprintf(`{"command": "image", "image": %q, "fit": "contain", "dither": "atkinson"}`, image))
+	client.waitForEvent(t, "REFRESH_COMPLETED")
+
+	client.publish(t, "epd/command", `{"command": "clear"}`)
+	client.waitForEvent(t, "REFRESH_COMPLETED")
+
+	client.publish(t, "epd/command", `{"command": "sleep"}`)
+	client.waitForEvent(t, "SLEEP")
+
+	drv.mu.Lock()
+	if drv.displays != 4 || drv.clears != 1 || drv.sleeps != 1 {
+		t.Errorf("Expected 4 displays, 1 clear and 1 sleep, got %d, %d and %d", drv.displays, drv.clears, drv.sleeps)
+	}
+	drv.mu.Unlock()
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("RunMQTT failed: %v", err)
+	}
+	client.waitFor(t, "epd/availability", func(p []byte) bool { return string(p) == "offline" })
+}
+
+func TestMQTT_BrokerDown(t *testing.T) {
+	// An address nothing listens on until the broker comes up
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	s, drv := newEPDServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.RunMQTT(ctx, server.MQTTConfig{Broker: "tcp://" + addr, ClientID: "epd", Topic: "epd", RetryInterval: 100 * time.Millisecond})
+	}()
+
+	// The subscriber keeps retrying rather than giving up
+	select {
+	case err := <-done:
+		t.Fatalf("Expected RunMQTT to keep retrying, it returned %v", err)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	broker := newTestBrokerOn(t, addr)
+	client := newMQTTTestClient(t, broker.URL())
+	client.waitFor(t, "epd/availability", func(p []byte) bool { return string(p) == "online" })
+	client.publish(t, "epd/text", "Hello once the broker is up")
+	client.waitForEvent(t, "REFRESH_COMPLETED")
+	if n := displays(drv); n != 1 {
+		t.Errorf("Expected 1 display update, got %d", n)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("RunMQTT failed: %v", err)
+	}
+}
+
+func TestMQTT_BrokerDown_Cancel(t *testing.T) {
+	s, _ := newEPDServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := s.RunMQTT(ctx, server.MQTTConfig{Broker: "tcp://127.0.0.1:1", Topic: "epd", RetryInterval: 50 * time.Millisecond}); err != nil {
+		t.Errorf("Expected RunMQTT to stop retrying once cancelled, got %v", err)
+	}
+}
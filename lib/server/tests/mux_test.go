@@ -0,0 +1,127 @@
+package server_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/justmiles/epd/lib/display"
+	"github.com/justmiles/epd/lib/server"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// serveMux serves two displays, left (the default) and right, over gRPC.
+func serveMux(t *testing.T, opts ...server.Option) (mux *server.Mux, addr string, left, right *fakeDriver) {
+	t.Helper()
+	leftServer, left := newEPDServer(t, append(opts, server.WithName("left"))...)
+	rightServer, right := newEPDServer(t, append(opts, server.WithName("right"))...)
+	mux, err := server.NewMux(leftServer, rightServer)
+	if err != nil {
+		t.Fatalf("NewMux failed: %v", err)
+	}
+	t.Cleanup(mux.Shutdown)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(mux.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(mux.StreamInterceptor()),
+	)
+	pb.RegisterEPDServiceServer(grpcServer, mux)
+	healthpb.RegisterHealthServer(grpcServer, mux.HealthServer())
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	return mux, lis.Addr().String(), left, right
+}
+
+func TestMux_Routes(t *testing.T) {
+	_, addr, left, right := serveMux(t)
+
+	if err := dialRemote(t, addr+"/right").Clear(); err != nil {
+		t.Fatalf("Clear on right failed: %v", err)
+	}
+	if left.clears != 0 || right.clears != 1 {
+		t.Errorf("Expected only right to clear, got left=%d right=%d", left.clears, right.clears)
+	}
+
+	// Without a name, requests go to the default display.
+	if err := dialRemote(t, addr).Clear(); err != nil {
+		t.Fatalf("Clear on default failed: %v", err)
+	}
+	if left.clears != 1 || right.clears != 1 {
+		t.Errorf("Expected left to clear, got left=%d right=%d", left.clears, right.clears)
+	}
+
+	err := dialRemote(t, addr+"/missing").Clear()
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for an unknown display, got %v", err)
+	}
+}
+
+func TestMux_ListDisplays(t *testing.T) {
+	_, addr, _, _ := serveMux(t)
+
+	displays, err := dialRemote(t, addr).ListDisplays()
+	if err != nil {
+		t.Fatalf("ListDisplays failed: %v", err)
+	}
+	if len(displays) != 2 || displays[0].Display != "left" || displays[1].Display != "right" {
+		t.Fatalf("Expected left and right, got %v", displays)
+	}
+
+	if h, err := dialRemote(t, addr+"/right").Health(); err != nil || h != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Expected right to be SERVING, got %v, %v", h, err)
+	}
+}
+
+func TestMux_Auth(t *testing.T) {
+	_, addr, _, right := serveMux(t, server.WithAuthToken("secret"))
+
+	if err := dialRemote(t, addr+"/right").Clear(); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without a token, got %v", err)
+	}
+	if err := dialRemote(t, addr+"/right", display.WithToken("secret")).Clear(); err != nil {
+		t.Errorf("Clear with a token failed: %v", err)
+	}
+	if right.clears != 1 {
+		t.Errorf("Expected right to clear once, got %d", right.clears)
+	}
+}
+
+func TestMux_HTTP(t *testing.T) {
+	mux, _, left, right := serveMux(t)
+	ts := httptest.NewServer(mux.HTTPHandler())
+	t.Cleanup(ts.Close)
+
+	for _, path := range []string{"/displays/right/v1/clear", "/v1/clear"} {
+		resp, err := http.Post(ts.URL+path, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("POST %s returned %d", path, resp.StatusCode)
+		}
+	}
+	if left.clears != 1 || right.clears != 1 {
+		t.Errorf("Expected each display to clear once, got left=%d right=%d", left.clears, right.clears)
+	}
+}
+
+func TestNewMux_DuplicateName(t *testing.T) {
+	a, _ := newEPDServer(t, server.WithName("panel"))
+	b, _ := newEPDServer(t, server.WithName("panel"))
+	t.Cleanup(a.Shutdown)
+	t.Cleanup(b.Shutdown)
+	if _, err := server.NewMux(a, b); err == nil {
+		t.Error("Expected duplicate display names to be rejected")
+	}
+}
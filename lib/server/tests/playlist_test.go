@@ -0,0 +1,254 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/server"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// playlistFixtures writes an image and a markdown file for playlist items.
+func playlistFixtures(t *testing.T) (imagePath, markdownPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	imagePath = filepath.Join(dir, "photo.png")
+	markdownPath = filepath.Join(dir, "notes.md")
+	if err := os.WriteFile(imagePath, testPNG(t, 400, 240), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(markdownPath, []byte("# Notes\n\n- one"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return imagePath, markdownPath
+}
+
+// waitFor polls cond until it holds or 15 seconds pass, which leaves room for
+// slow image processing under the race detector.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(15 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestPlaylist_Cycles(t *testing.T) {
+	imagePath, markdownPath := playlistFixtures(t)
+	s, drv := newEPDServer(t)
+	t.Cleanup(s.Shutdown)
+	client := dialRemote(t, serveGRPC(t, s))
+
+	dwell := durationpb.New(200 * time.Millisecond)
+	first, err := client.AddPlaylistItem(&pb.PlaylistItem{Content: &pb.PlaylistItem_Image{Image: imagePath}, Dwell: dwell})
+	if err != nil {
+		t.Fatalf("AddPlaylistItem failed: %v", err)
+	}
+	second, err := client.AddPlaylistItem(&pb.PlaylistItem{Content: &pb.PlaylistItem_MarkdownFile{MarkdownFile: markdownPath}, Dwell: dwell})
+	if err != nil {
+		t.Fatalf("AddPlaylistItem failed: %v", err)
+	}
+
+	// The first item starts the playlist, and the rest follow in order.
+	seen := map[string]bool{}
+	waitFor(t, "the playlist to show both items", func() bool {
+		playlist, err := client.Playlist()
+		if err != nil {
+			t.Fatalf("Playlist failed: %v", err)
+		}
+		seen[playlist.CurrentId] = true
+		return seen[first.Id] && seen[second.Id]
+	})
+	waitFor(t, "both items to be displayed", func() bool { return displays(drv) >= 2 })
+}
+
+func TestPlaylist_SkipAndPause(t *testing.T) {
+	imagePath, markdownPath := playlistFixtures(t)
+	s, drv := newEPDServer(t)
+	t.Cleanup(s.Shutdown)
+	client := dialRemote(t, serveGRPC(t, s))
+
+	if _, err := client.SkipPlaylistItem(); err == nil {
+		t.Error("Expected skipping an empty playlist to fail")
+	}
+
+	first, _ := client.AddPlaylistItem(&pb.PlaylistItem{Content: &pb.PlaylistItem_Image{Image: imagePath}, Dwell: durationpb.New(time.Hour)})
+	waitFor(t, "the first item to be displayed", func() bool { return displays(drv) == 1 })
+	second, _ := client.AddPlaylistItem(&pb.PlaylistItem{Content: &pb.PlaylistItem_MarkdownFile{MarkdownFile: markdownPath}, Dwell: durationpb.New(time.Hour)})
+
+	playlist, err := client.PausePlaylist(true)
+	if err != nil {
+		t.Fatalf("PausePlaylist failed: %v", err)
+	}
+	if !playlist.Paused || playlist.CurrentId != first.Id || playlist.NextChange != nil {
+		t.Fatalf("Expected to be paused on %s, got %v", first.Id, playlist)
+	}
+
+	// Skipping moves on but stays paused.
+	playlist, err = client.SkipPlaylistItem()
+	if err != nil {
+		t.Fatalf("SkipPlaylistItem failed: %v", err)
+	}
+	if !playlist.Paused || playlist.CurrentId != second.Id {
+		t.Fatalf("Expected to be paused on %s, got %v", second.Id, playlist)
+	}
+	waitFor(t, "the skipped item to be displayed", func() bool { return displays(drv) == 2 })
+
+	playlist, err = client.PausePlaylist(false)
+	if err != nil {
+		t.Fatalf("PausePlaylist failed: %v", err)
+	}
+	if playlist.Paused || playlist.NextChange == nil {
+		t.Errorf("Expected the playlist to resume, got %v", playlist)
+	}
+
+	// Removing the current item moves on to the next.
+	if err := client.RemovePlaylistItem(second.Id); err != nil {
+		t.Fatalf("RemovePlaylistItem failed: %v", err)
+	}
+	if playlist, _ := client.Playlist(); len(playlist.Items) != 1 || playlist.CurrentId != first.Id {
+		t.Errorf("Expected only %s to remain and be shown, got %v", first.Id, playlist)
+	}
+	if err := client.RemovePlaylistItem(second.Id); err == nil {
+		t.Error("Expected removing an unknown item to fail")
+	}
+}
+
+func TestPlaylist_Persists(t *testing.T) {
+	imagePath, _ := playlistFixtures(t)
+	dir := t.TempDir()
+	s, _ := newEPDServer(t, server.WithStateDir(dir))
+	client := dialRemote(t, serveGRPC(t, s))
+
+	added, err := client.AddPlaylistItem(&pb.PlaylistItem{Content: &pb.PlaylistItem_Image{Image: imagePath}})
+	if err != nil {
+		t.Fatalf("AddPlaylistItem failed: %v", err)
+	}
+	if _, err := client.ShufflePlaylist(true); err != nil {
+		t.Fatalf("ShufflePlaylist failed: %v", err)
+	}
+	s.Shutdown()
+
+	restarted, drv := newEPDServer(t, server.WithStateDir(dir))
+	t.Cleanup(restarted.Shutdown)
+	client = dialRemote(t, serveGRPC(t, restarted))
+
+	playlist, err := client.Playlist()
+	if err != nil {
+		t.Fatalf("Playlist failed: %v", err)
+	}
+	if len(playlist.Items) != 1 || playlist.Items[0].Id != added.Id || !playlist.Shuffle {
+		t.Fatalf("Expected the shuffled playlist to survive a restart, got %v", playlist)
+	}
+	if playlist.CurrentId != added.Id {
+		t.Errorf("Expected the restarted daemon to resume the playlist, got %v", playlist)
+	}
+	waitFor(t, "the restarted daemon to show the item", func() bool { return displays(drv) == 1 })
+}
+
+func TestPlaylist_QuietHours(t *testing.T) {
+	imagePath, markdownPath := playlistFixtures(t)
+	dir := t.TempDir()
+	s, drv := newEPDServer(t, server.WithStateDir(dir))
+	client := dialRemote(t, serveGRPC(t, s))
+
+	now := time.Now()
+	if err := client.SetQuietHours(now.Add(-time.Hour).Format("15:04"), now.Add(time.Hour).Format("15:04")); err != nil {
+		t.Fatalf("SetQuietHours failed: %v", err)
+	}
+
+	// Adding the first item still shows it, but the playlist doesn't move on
+	dwell := durationpb.New(200 * time.Millisecond)
+	first, err := client.AddPlaylistItem(&pb.PlaylistItem{Content: &pb.PlaylistItem_Image{Image: imagePath}, Dwell: dwell})
+	if err != nil {
+		t.Fatalf("AddPlaylistItem failed: %v", err)
+	}
+	if _, err := client.AddPlaylistItem(&pb.PlaylistItem{Content: &pb.PlaylistItem_MarkdownFile{MarkdownFile: markdownPath}, Dwell: dwell}); err != nil {
+		t.Fatalf("AddPlaylistItem failed: %v", err)
+	}
+	waitFor(t, "the first item to be displayed", func() bool { return displays(drv) >= 1 })
+
+	time.Sleep(time.Second)
+	if n := displays(drv); n != 1 {
+		t.Errorf("Expected no playlist updates during quiet hours, got %d", n-1)
+	}
+	if playlist, err := client.Playlist(); err != nil || playlist.CurrentId != first.Id {
+		t.Errorf("Expected the playlist to stay on %s, got %v, %v", first.Id, playlist.GetCurrentId(), err)
+	}
+
+	// Nor does it show anything when the daemon restarts
+	s.Shutdown()
+	restarted, drv := newEPDServer(t, server.WithStateDir(dir))
+	t.Cleanup(restarted.Shutdown)
+	time.Sleep(time.Second)
+	if n := displays(drv); n != 0 {
+		t.Errorf("Expected no playlist updates after a restart during quiet hours, got %d", n)
+	}
+}
+
+func TestPlaylist_SaveFails(t *testing.T) {
+	imagePath, markdownPath := playlistFixtures(t)
+	dir := t.TempDir()
+	s, drv := newEPDServer(t, server.WithStateDir(dir))
+	client := dialRemote(t, serveGRPC(t, s))
+
+	kept, err := client.AddPlaylistItem(&pb.PlaylistItem{Content: &pb.PlaylistItem_Image{Image: imagePath}, Dwell: durationpb.New(time.Hour)})
+	if err != nil {
+		t.Fatalf("AddPlaylistItem failed: %v", err)
+	}
+	waitFor(t, "the item to be displayed", func() bool { return displays(drv) == 1 })
+
+	// A directory in the way of the temporary file makes every save fail
+	blocker := filepath.Join(dir, "playlist.json.tmp")
+	if err := os.Mkdir(blocker, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.AddPlaylistItem(&pb.PlaylistItem{Content: &pb.PlaylistItem_MarkdownFile{MarkdownFile: markdownPath}}); err == nil {
+		t.Error("Expected AddPlaylistItem to fail when the playlist can't be saved")
+	}
+	if err := client.RemovePlaylistItem(kept.Id); err == nil {
+		t.Error("Expected RemovePlaylistItem to fail when the playlist can't be saved")
+	}
+	if _, err := client.PausePlaylist(true); err == nil {
+		t.Error("Expected PausePlaylist to fail when the playlist can't be saved")
+	}
+	if _, err := client.ShufflePlaylist(true); err == nil {
+		t.Error("Expected ShufflePlaylist to fail when the playlist can't be saved")
+	}
+
+	// Nothing changed
+	playlist, err := client.Playlist()
+	if err != nil {
+		t.Fatalf("Playlist failed: %v", err)
+	}
+	if len(playlist.Items) != 1 || playlist.Items[0].Id != kept.Id || playlist.CurrentId != kept.Id || playlist.Paused || playlist.Shuffle {
+		t.Errorf("Expected the playlist unchanged, got %v", playlist)
+	}
+
+	// The next save doesn't persist the changes that failed
+	if err := os.Remove(blocker); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.PausePlaylist(true); err != nil {
+		t.Fatalf("PausePlaylist failed: %v", err)
+	}
+	s.Shutdown()
+
+	restarted, _ := newEPDServer(t, server.WithStateDir(dir))
+	t.Cleanup(restarted.Shutdown)
+	client = dialRemote(t, serveGRPC(t, restarted))
+	playlist, err = client.Playlist()
+	if err != nil {
+		t.Fatalf("Playlist failed: %v", err)
+	}
+	if len(playlist.Items) != 1 || playlist.Items[0].Id != kept.Id || !playlist.Paused || playlist.Shuffle {
+		t.Errorf("Expected only the saved changes after a restart, got %v", playlist)
+	}
+}
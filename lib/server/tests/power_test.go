@@ -0,0 +1,78 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/server"
+)
+
+func getStatus(t *testing.T, url string) map[string]any {
+	t.Helper()
+	resp, err := http.Get(url + "/v1/status")
+	if err != nil {
+		t.Fatalf("GET /v1/status failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var status map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Fatalf("Invalid status JSON: %v", err)
+	}
+	return status
+}
+
+func counts(drv *fakeDriver) (inits, sleeps int) {
+	drv.mu.Lock()
+	defer drv.mu.Unlock()
+	return drv.inits, drv.sleeps
+}
+
+func TestIdleSleepAndWake(t *testing.T) {
+	srv, drv := newTestServer(t, server.WithIdleTimeout(50*time.Millisecond))
+
+	if status := getStatus(t, srv.URL); status["sleeping"] != false {
+		t.Fatalf("Expected the panel to be awake after startup, got %v", status)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for getStatus(t, srv.URL)["sleeping"] != true {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the idle panel to sleep")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if inits, sleeps := counts(drv); inits != 1 || sleeps != 1 {
+		t.Fatalf("Expected 1 init and 1 sleep, got %d and %d", inits, sleeps)
+	}
+
+	// The next refresh wakes the panel first.
+	post(t, srv.URL+"/v1/text", "text/plain", []byte("Hello"))
+	if inits, _ := counts(drv); inits != 2 {
+		t.Errorf("Expected the panel to be woken before refreshing, got %d inits", inits)
+	}
+}
+
+func TestSleepWhenAsleep(t *testing.T) {
+	srv, drv := newTestServer(t)
+
+	post(t, srv.URL+"/v1/sleep", "", nil)
+	post(t, srv.URL+"/v1/sleep", "", nil)
+
+	if _, sleeps := counts(drv); sleeps != 1 {
+		t.Errorf("Expected a sleeping panel not to be put to sleep again, got %d sleeps", sleeps)
+	}
+	if status := getStatus(t, srv.URL); status["sleeping"] != true {
+		t.Errorf("Expected the panel to report sleeping, got %v", status)
+	}
+
+	post(t, srv.URL+"/v1/clear", "", nil)
+	if inits, _ := counts(drv); inits != 2 {
+		t.Errorf("Expected clear to wake the panel, got %d inits", inits)
+	}
+	if status := getStatus(t, srv.URL); status["sleeping"] != false {
+		t.Errorf("Expected the panel to report awake, got %v", status)
+	}
+}
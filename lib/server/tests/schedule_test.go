@@ -0,0 +1,195 @@
+package server_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/server"
+	pb "github.com/justmiles/epd/proto/epdpb"
+)
+
+func displays(drv *fakeDriver) int {
+	drv.mu.Lock()
+	defer drv.mu.Unlock()
+	return drv.displays
+}
+
+func TestSchedule_AddListRemove(t *testing.T) {
+	dir := t.TempDir()
+	s, _ := newEPDServer(t, server.WithStateDir(dir))
+	client := dialRemote(t, serveGRPC(t, s))
+
+	added, err := client.AddSchedule(&pb.Schedule{
+		Cron:    "0 7 * * *",
+		Content: &pb.Schedule_Dashboard{Dashboard: &pb.DashboardContent{HeaderText: "Good morning"}},
+	})
+	if err != nil {
+		t.Fatalf("AddSchedule failed: %v", err)
+	}
+	if added.Id == "" {
+		t.Fatal("Expected the daemon to assign an ID")
+	}
+	if next := added.NextRun.AsTime().Local(); next.Hour() != 7 || next.Minute() != 0 {
+		t.Errorf("Expected the next run at 07:00, got %s", next)
+	}
+	if err := client.SetQuietHours("22:00", "06:30"); err != nil {
+		t.Fatalf("SetQuietHours failed: %v", err)
+	}
+
+	// A new daemon with the same state directory picks up the schedule.
+	s.Shutdown()
+	restarted, _ := newEPDServer(t, server.WithStateDir(dir))
+	client = dialRemote(t, serveGRPC(t, restarted))
+
+	list, err := client.ListSchedules()
+	if err != nil {
+		t.Fatalf("ListSchedules failed: %v", err)
+	}
+	if len(list.Schedules) != 1 || list.Schedules[0].Id != added.Id {
+		t.Fatalf("Expected schedule %s to survive a restart, got %v", added.Id, list.Schedules)
+	}
+	if got := list.Schedules[0].GetDashboard().GetHeaderText(); got != "Good morning" {
+		t.Errorf("Expected the dashboard header to persist, got %q", got)
+	}
+	if q := list.QuietHours; q.Start != "22:00" || q.End != "06:30" {
+		t.Errorf("Expected quiet hours 22:00-06:30 to persist, got %v", q)
+	}
+
+	if err := client.RemoveSchedule(added.Id); err != nil {
+		t.Fatalf("RemoveSchedule failed: %v", err)
+	}
+	if err := client.RemoveSchedule(added.Id); err == nil {
+		t.Error("Expected removing an unknown schedule to fail")
+	}
+	if list, _ := client.ListSchedules(); len(list.Schedules) != 0 {
+		t.Errorf("Expected no schedules after removal, got %v", list.Schedules)
+	}
+}
+
+func TestSchedule_SaveFails(t *testing.T) {
+	dir := t.TempDir()
+	s, drv := newEPDServer(t, server.WithStateDir(dir))
+	t.Cleanup(s.Shutdown)
+	client := dialRemote(t, serveGRPC(t, s))
+
+	kept, err := client.AddSchedule(&pb.Schedule{Cron: "0 7 * * *", Content: &pb.Schedule_Text{Text: "Good morning"}})
+	if err != nil {
+		t.Fatalf("AddSchedule failed: %v", err)
+	}
+	// Quiet hours that don't stop the unsaved schedule below from running
+	start, end := time.Now().Add(2*time.Hour).Format("15:04"), time.Now().Add(3*time.Hour).Format("15:04")
+	if err := client.SetQuietHours(start, end); err != nil {
+		t.Fatalf("SetQuietHours failed: %v", err)
+	}
+
+	// A directory in the way of the temporary file makes every save fail
+	if err := os.Mkdir(filepath.Join(dir, "schedules.json.tmp"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.AddSchedule(&pb.Schedule{Cron: "@every 1s", Content: &pb.Schedule_Text{Text: "Tick"}}); err == nil {
+		t.Error("Expected AddSchedule to fail when the schedule can't be saved")
+	}
+	if err := client.RemoveSchedule(kept.Id); err == nil {
+		t.Error("Expected RemoveSchedule to fail when the schedule can't be saved")
+	}
+	if err := client.SetQuietHours("", ""); err == nil {
+		t.Error("Expected SetQuietHours to fail when the schedule can't be saved")
+	}
+
+	// Nothing changed, and the schedule that couldn't be saved never runs
+	list, err := client.ListSchedules()
+	if err != nil {
+		t.Fatalf("ListSchedules failed: %v", err)
+	}
+	if len(list.Schedules) != 1 || list.Schedules[0].Id != kept.Id {
+		t.Errorf("Expected only schedule %s, got %v", kept.Id, list.Schedules)
+	}
+	if q := list.QuietHours; q.Start != start || q.End != end {
+		t.Errorf("Expected quiet hours %s-%s to remain, got %v", start, end, q)
+	}
+	time.Sleep(1500 * time.Millisecond)
+	if n := displays(drv); n != 0 {
+		t.Errorf("Expected the unsaved schedule not to run, got %d updates", n)
+	}
+}
+
+func TestSchedule_Invalid(t *testing.T) {
+	s, _ := newEPDServer(t)
+	client := dialRemote(t, serveGRPC(t, s))
+
+	if _, err := client.AddSchedule(&pb.Schedule{Cron: "every morning", Content: &pb.Schedule_Text{Text: "Hi"}}); err == nil {
+		t.Error("Expected an invalid cron expression to be rejected")
+	}
+	if _, err := client.AddSchedule(&pb.Schedule{Cron: "@hourly"}); err == nil {
+		t.Error("Expected a schedule without content to be rejected")
+	}
+	if err := client.SetQuietHours("25:00", "07:00"); err == nil {
+		t.Error("Expected an invalid quiet hours time to be rejected")
+	}
+	if err := client.SetQuietHours("22:00", ""); err == nil {
+		t.Error("Expected quiet hours without an end to be rejected")
+	}
+}
+
+func TestSchedule_Runs(t *testing.T) {
+	s, drv := newEPDServer(t)
+	t.Cleanup(s.Shutdown)
+	client := dialRemote(t, serveGRPC(t, s))
+
+	if _, err := client.AddSchedule(&pb.Schedule{Cron: "@every 1s", Content: &pb.Schedule_Text{Text: "Tick"}}); err != nil {
+		t.Fatalf("AddSchedule failed: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for displays(drv) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the schedule to run")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestSchedule_QuietHours(t *testing.T) {
+	s, drv := newEPDServer(t)
+	t.Cleanup(s.Shutdown)
+	client := dialRemote(t, serveGRPC(t, s))
+
+	now := time.Now()
+	if err := client.SetQuietHours(now.Add(-time.Hour).Format("15:04"), now.Add(time.Hour).Format("15:04")); err != nil {
+		t.Fatalf("SetQuietHours failed: %v", err)
+	}
+	if _, err := client.AddSchedule(&pb.Schedule{Cron: "@every 1s", Content: &pb.Schedule_Text{Text: "Tick"}}); err != nil {
+		t.Fatalf("AddSchedule failed: %v", err)
+	}
+
+	time.Sleep(2500 * time.Millisecond)
+	if n := displays(drv); n != 0 {
+		t.Errorf("Expected no updates during quiet hours, got %d", n)
+	}
+}
+
+func TestSchedule_QuietHoursLocation(t *testing.T) {
+	// Half a day ahead of local time, so quiet hours around now there are
+	// far from now here
+	_, offset := time.Now().Zone()
+	loc := time.FixedZone("test", offset+12*60*60)
+	s, drv := newEPDServer(t, server.WithLocation(loc))
+	t.Cleanup(s.Shutdown)
+	client := dialRemote(t, serveGRPC(t, s))
+
+	now := time.Now().In(loc)
+	if err := client.SetQuietHours(now.Add(-time.Hour).Format("15:04"), now.Add(time.Hour).Format("15:04")); err != nil {
+		t.Fatalf("SetQuietHours failed: %v", err)
+	}
+	if _, err := client.AddSchedule(&pb.Schedule{Cron: "@every 1s", Content: &pb.Schedule_Text{Text: "Tick"}}); err != nil {
+		t.Fatalf("AddSchedule failed: %v", err)
+	}
+
+	time.Sleep(2500 * time.Millisecond)
+	if n := displays(drv); n != 0 {
+		t.Errorf("Expected no updates during quiet hours in the server's location, got %d", n)
+	}
+}
@@ -0,0 +1,116 @@
+package server_test
+
+import (
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/justmiles/epd/lib/server"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"google.golang.org/grpc"
+)
+
+// socketPath returns a path for a Unix socket short enough for the platform's
+// limit, which t.TempDir paths can exceed.
+func socketPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "epd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "epd.sock")
+}
+
+func TestListenUnix(t *testing.T) {
+	path := socketPath(t)
+
+	// A socket left behind by a crashed daemon is replaced.
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	lis, err := server.ListenUnix(path, 0600, "")
+	if err != nil {
+		t.Fatalf("ListenUnix failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("Expected socket mode 0600, got %o", perm)
+	}
+
+	s, drv := newEPDServer(t)
+	grpcServer := grpc.NewServer()
+	pb.RegisterEPDServiceServer(grpcServer, s)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	client := dialRemote(t, "unix://"+path)
+	if err := client.DisplayText("Hello over a socket"); err != nil {
+		t.Fatalf("DisplayText failed: %v", err)
+	}
+	if n := displays(drv); n != 1 {
+		t.Errorf("Expected 1 display update, got %d", n)
+	}
+}
+
+func TestListenUnix_NotASocket(t *testing.T) {
+	path := socketPath(t)
+	if err := os.WriteFile(path, []byte("important"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := server.ListenUnix(path, 0660, ""); err == nil {
+		t.Fatal("Expected ListenUnix to refuse to replace a regular file")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "important" {
+		t.Error("Expected the regular file to be left alone")
+	}
+}
+
+func TestListenUnix_NeverOpen(t *testing.T) {
+	// Even with a permissive umask, the socket is never more open than its
+	// mode while ListenUnix sets it up
+	old := syscall.Umask(0)
+	t.Cleanup(func() { syscall.Umask(old) })
+
+	path := socketPath(t)
+	for range 50 {
+		done := make(chan struct{})
+		loosest := make(chan fs.FileMode)
+		go func() {
+			var perm fs.FileMode
+			for {
+				if info, err := os.Stat(path); err == nil {
+					perm |= info.Mode().Perm()
+				}
+				select {
+				case <-done:
+					loosest <- perm
+					return
+				default:
+				}
+			}
+		}()
+
+		lis, err := server.ListenUnix(path, 0600, "")
+		close(done)
+		if err != nil {
+			t.Fatalf("ListenUnix failed: %v", err)
+		}
+		if perm := <-loosest; perm&^0600 != 0 {
+			t.Fatalf("Expected the socket never to be more open than 0600, saw %o", perm)
+		}
+		lis.Close()
+	}
+}
@@ -0,0 +1,160 @@
+package server_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/justmiles/epd/lib/display"
+	pb "github.com/justmiles/epd/proto/epdpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// dialClient returns a raw gRPC client for addr, for sending requests
+// RemoteDisplay never would.
+func dialClient(t *testing.T, addr string) pb.EPDServiceClient {
+	t.Helper()
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewEPDServiceClient(conn)
+}
+
+// upload sends chunks to UploadImage and returns the server's status.
+func upload(t *testing.T, client pb.EPDServiceClient, chunks ...*pb.ImageChunk) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.UploadImage(ctx)
+	if err != nil {
+		t.Fatalf("UploadImage failed: %v", err)
+	}
+	for _, chunk := range chunks {
+		if err := stream.Send(chunk); err != nil {
+			// The server's status is only available from CloseAndRecv
+			break
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// split splits data into n chunks, the first declaring size and checksum.
+func split(data []byte, n int, size int64, checksum string) []*pb.ImageChunk {
+	var chunks []*pb.ImageChunk
+	step := (len(data) + n - 1) / n
+	for offset := 0; offset < len(data); offset += step {
+		chunks = append(chunks, &pb.ImageChunk{Data: data[offset:min(offset+step, len(data))]})
+	}
+	chunks[0].TotalSize, chunks[0].Sha256 = size, checksum
+	return chunks
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestUploadImage(t *testing.T) {
+	s, drv := newEPDServer(t)
+	client := dialClient(t, serveGRPC(t, s))
+
+	img := testPNG(t, 800, 480)
+	if err := upload(t, client, split(img, 3, int64(len(img)), sha256Hex(img))...); err != nil {
+		t.Fatalf("UploadImage failed: %v", err)
+	}
+	if n := displays(drv); n != 1 {
+		t.Errorf("Expected 1 display update, got %d", n)
+	}
+}
+
+func TestUploadImage_Invalid(t *testing.T) {
+	img := testPNG(t, 800, 480)
+	size, checksum := int64(len(img)), sha256Hex(img)
+
+	tests := []struct {
+		name   string
+		chunks []*pb.ImageChunk
+		code   codes.Code
+		msg    string
+	}{
+		{"empty stream", nil, codes.InvalidArgument, "no data"},
+		{"no header", split(img, 3, 0, ""), codes.InvalidArgument, "must set total_size and sha256"},
+		{"checksum mismatch", split(img, 3, size, sha256Hex([]byte("other"))), codes.DataLoss, "checksum mismatch"},
+		{"short of declared size", split(img, 3, size+1, checksum), codes.InvalidArgument, "received"},
+		{"over declared size", split(img, 3, size-1, checksum), codes.InvalidArgument, "exceeds declared size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, drv := newEPDServer(t)
+			client := dialClient(t, serveGRPC(t, s))
+
+			err := upload(t, client, tt.chunks...)
+			if status.Code(err) != tt.code || !strings.Contains(err.Error(), tt.msg) {
+				t.Errorf("Expected %s containing %q, got %v", tt.code, tt.msg, err)
+			}
+			if n := displays(drv); n != 0 {
+				t.Errorf("Expected no display update, got %d", n)
+			}
+		})
+	}
+}
+
+func TestDisplayImage_StreamThreshold(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		methods []string
+	)
+	record := func(method string) {
+		mu.Lock()
+		methods = append(methods, method[strings.LastIndex(method, "/")+1:])
+		mu.Unlock()
+	}
+	s, drv := newEPDServer(t)
+	addr := serveGRPC(t, s,
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			record(info.FullMethod)
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			record(info.FullMethod)
+			return handler(srv, ss)
+		}),
+	)
+
+	img := testPNG(t, 800, 480)
+	tests := []struct {
+		threshold int
+		want      string
+	}{
+		{display.DefaultStreamThreshold, "DisplayImage"},
+		{len(img), "DisplayImage"},
+		{len(img) - 1, "UploadImage"},
+	}
+	for i, tt := range tests {
+		client := dialRemote(t, addr, display.WithStreamThreshold(tt.threshold))
+		if err := client.DisplayImage(img); err != nil {
+			t.Fatalf("DisplayImage failed: %v", err)
+		}
+
+		mu.Lock()
+		got := methods[len(methods)-1]
+		mu.Unlock()
+		if got != tt.want {
+			t.Errorf("Threshold %d: expected %s for a %d byte image, got %s", tt.threshold, tt.want, len(img), got)
+		}
+		if n := displays(drv); n != i+1 {
+			t.Errorf("Threshold %d: expected %d display updates, got %d", tt.threshold, i+1, n)
+		}
+	}
+}
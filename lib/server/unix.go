@@ -0,0 +1,62 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"sync"
+	"syscall"
+)
+
+// umaskMu serializes changes to the process umask.
+var umaskMu sync.Mutex
+
+// ListenUnix listens on a Unix socket at path for local clients, who are then
+// controlled by the socket's file permissions: mode, and group when set. A
+// stale socket left by a previous run is replaced.
+func ListenUnix(path string, mode fs.FileMode, group string) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode().Type() != fs.ModeSocket {
+			return nil, fmt.Errorf("refusing to replace %s: not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to check socket: %w", err)
+	}
+
+	// Create the socket accessible only to the daemon, so no one can connect
+	// before its permissions are set
+	umaskMu.Lock()
+	old := syscall.Umask(0177)
+	lis, err := net.Listen("unix", path)
+	syscall.Umask(old)
+	umaskMu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+
+	if err := os.Chmod(path, mode); err != nil {
+		lis.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+	if group != "" {
+		g, err := user.LookupGroup(group)
+		if err != nil {
+			lis.Close()
+			return nil, fmt.Errorf("failed to look up socket group: %w", err)
+		}
+		gid, _ := strconv.Atoi(g.Gid)
+		if err := os.Chown(path, -1, gid); err != nil {
+			lis.Close()
+			return nil, fmt.Errorf("failed to set socket group: %w", err)
+		}
+	}
+
+	return lis, nil
+}
@@ -0,0 +1,164 @@
+// Browser UI for the EPD daemon. Talks to the HTTP API under /v1/.
+(function () {
+  "use strict";
+
+  const $ = (id) => document.getElementById(id);
+
+  let imageFile = null;
+  let markdownTimer = null;
+
+  // api calls the HTTP API, prompting for the daemon token when required.
+  async function api(method, path, body, contentType) {
+    for (;;) {
+      const headers = {};
+      const token = localStorage.getItem("epd-token");
+      if (token) headers["Authorization"] = "Bearer " + token;
+      if (contentType) headers["Content-Type"] = contentType;
+
+      // Relative to the page, so the UI also works under /displays/<name>/
+      const resp = await fetch(path.replace(/^\//, ""), { method, headers, body });
+      if (resp.status === 401) {
+        const entered = prompt("This display requires a token:");
+        if (entered === null) throw new Error("unauthorized");
+        localStorage.setItem("epd-token", entered);
+        continue;
+      }
+      if (!resp.ok) {
+        let msg = resp.statusText;
+        try {
+          msg = (await resp.json()).error || msg;
+        } catch (e) {}
+        throw new Error(msg);
+      }
+      return resp;
+    }
+  }
+
+  function setStatus(text, isError) {
+    $("status").textContent = text;
+    $("status").classList.toggle("error", !!isError);
+  }
+
+  // showBlob replaces an <img> source with a blob, releasing the previous one.
+  function showBlob(img, blob) {
+    if (img.src) URL.revokeObjectURL(img.src);
+    img.src = URL.createObjectURL(blob);
+    img.hidden = false;
+  }
+
+  async function refreshStatus() {
+    try {
+      const s = await (await api("GET", "/v1/status")).json();
+      let text = `${s.device} ${s.width}×${s.height}`;
+      if (s.queue_depth > 0) text += ` · ${s.queue_depth} queued`;
+      if (s.last_error) {
+        setStatus(`${text} · last refresh failed: ${s.last_error}`, true);
+      } else {
+        setStatus(text);
+      }
+    } catch (e) {
+      setStatus(e.message, true);
+    }
+  }
+
+  async function refreshFrame() {
+    try {
+      const resp = await api("GET", "/v1/screenshot.png");
+      showBlob($("frame"), await resp.blob());
+      $("frame-empty").hidden = true;
+      const updated = resp.headers.get("Last-Modified");
+      $("frame-updated").textContent = updated ? "Updated " + new Date(updated).toLocaleString() : "";
+    } catch (e) {
+      $("frame").hidden = true;
+      $("frame-empty").hidden = false;
+    }
+    refreshStatus();
+  }
+
+  // run performs a display operation, then refreshes the current frame.
+  async function run(button, label, fn) {
+    button.disabled = true;
+    setStatus(label + "…");
+    try {
+      await fn();
+      await refreshFrame();
+    } catch (e) {
+      setStatus(e.message, true);
+    } finally {
+      button.disabled = false;
+    }
+  }
+
+  function imageQuery() {
+    return `?fit=${encodeURIComponent($("fit").value)}&dither=${encodeURIComponent($("dither").value)}`;
+  }
+
+  async function previewImage() {
+    if (!imageFile) return;
+    try {
+      const resp = await api("POST", "/v1/preview/image" + imageQuery(), imageFile, imageFile.type);
+      showBlob($("image-preview"), await resp.blob());
+      $("send-image").disabled = false;
+    } catch (e) {
+      setStatus(e.message, true);
+    }
+  }
+
+  function chooseImage(file) {
+    if (!file) return;
+    imageFile = file;
+    $("drop").querySelector("span").textContent = file.name;
+    previewImage();
+  }
+
+  async function previewMarkdown() {
+    const text = $("markdown-text").value;
+    $("send-markdown").disabled = text.trim() === "";
+    if (text.trim() === "") {
+      $("markdown-preview").hidden = true;
+      return;
+    }
+    try {
+      const resp = await api("POST", "/v1/preview/markdown", text, "text/markdown");
+      showBlob($("markdown-preview"), await resp.blob());
+    } catch (e) {
+      setStatus(e.message, true);
+    }
+  }
+
+  // Current frame and controls
+  $("refresh-frame").addEventListener("click", refreshFrame);
+  $("clear").addEventListener("click", (e) => run(e.target, "Clearing", () => api("POST", "/v1/clear")));
+  $("sleep").addEventListener("click", (e) => run(e.target, "Sleeping", () => api("POST", "/v1/sleep")));
+
+  // Image upload with drag and drop
+  const drop = $("drop");
+  drop.addEventListener("dragover", (e) => {
+    e.preventDefault();
+    drop.classList.add("over");
+  });
+  drop.addEventListener("dragleave", () => drop.classList.remove("over"));
+  drop.addEventListener("drop", (e) => {
+    e.preventDefault();
+    drop.classList.remove("over");
+    chooseImage(e.dataTransfer.files[0]);
+  });
+  $("image-file").addEventListener("change", (e) => chooseImage(e.target.files[0]));
+  $("fit").addEventListener("change", previewImage);
+  $("dither").addEventListener("change", previewImage);
+  $("send-image").addEventListener("click", (e) =>
+    run(e.target, "Sending image", () => api("POST", "/v1/image" + imageQuery(), imageFile, imageFile.type))
+  );
+
+  // Markdown editor with live preview
+  $("markdown-text").addEventListener("input", () => {
+    clearTimeout(markdownTimer);
+    markdownTimer = setTimeout(previewMarkdown, 500);
+  });
+  $("send-markdown").addEventListener("click", (e) =>
+    run(e.target, "Sending markdown", () => api("POST", "/v1/markdown", $("markdown-text").value, "text/markdown"))
+  );
+
+  refreshFrame();
+  setInterval(refreshFrame, 60000);
+})();
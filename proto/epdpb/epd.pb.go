@@ -9,6 +9,8 @@ package epdpb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,9 +23,72 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Event_Type int32
+
+const (
+	Event_TYPE_UNSPECIFIED  Event_Type = 0
+	Event_REFRESH_STARTED   Event_Type = 1 // the panel started updating
+	Event_REFRESH_COMPLETED Event_Type = 2 // the panel finished updating
+	Event_REFRESH_FAILED    Event_Type = 3 // the update failed, see error
+	Event_SLEEP             Event_Type = 4 // the panel entered sleep mode
+	Event_WAKE              Event_Type = 5 // the panel was initialized (woken)
+	Event_QUEUE_CHANGED     Event_Type = 6 // an operation was queued or finished, see queue_depth
+)
+
+// Enum value maps for Event_Type.
+var (
+	Event_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "REFRESH_STARTED",
+		2: "REFRESH_COMPLETED",
+		3: "REFRESH_FAILED",
+		4: "SLEEP",
+		5: "WAKE",
+		6: "QUEUE_CHANGED",
+	}
+	Event_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED":  0,
+		"REFRESH_STARTED":   1,
+		"REFRESH_COMPLETED": 2,
+		"REFRESH_FAILED":    3,
+		"SLEEP":             4,
+		"WAKE":              5,
+		"QUEUE_CHANGED":     6,
+	}
+)
+
+func (x Event_Type) Enum() *Event_Type {
+	p := new(Event_Type)
+	*p = x
+	return p
+}
+
+func (x Event_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Event_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_epd_proto_enumTypes[0].Descriptor()
+}
+
+func (Event_Type) Type() protoreflect.EnumType {
+	return &file_proto_epd_proto_enumTypes[0]
+}
+
+func (x Event_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Event_Type.Descriptor instead.
+func (Event_Type) EnumDescriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{20, 0}
+}
+
 type DisplayImageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ImageData     []byte                 `protobuf:"bytes,1,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"` // PNG encoded image data
+	Fit           string                 `protobuf:"bytes,2,opt,name=fit,proto3" json:"fit,omitempty"`                              // how to scale the image: "stretch" (default), "contain" or "cover"
+	Dither        string                 `protobuf:"bytes,3,opt,name=dither,proto3" json:"dither,omitempty"`                        // how to reduce to black and white: "none" (default), "floyd-steinberg" or "atkinson"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -65,6 +130,96 @@ func (x *DisplayImageRequest) GetImageData() []byte {
 	return nil
 }
 
+func (x *DisplayImageRequest) GetFit() string {
+	if x != nil {
+		return x.Fit
+	}
+	return ""
+}
+
+func (x *DisplayImageRequest) GetDither() string {
+	if x != nil {
+		return x.Dither
+	}
+	return ""
+}
+
+type ImageChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`                             // next slice of the PNG encoded image data
+	TotalSize     int64                  `protobuf:"varint,2,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"` // size of the complete image in bytes; required on the first chunk
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`                         // hex encoded SHA-256 of the complete image; required on the first chunk
+	Fit           string                 `protobuf:"bytes,4,opt,name=fit,proto3" json:"fit,omitempty"`                               // as in DisplayImageRequest; read from the first chunk
+	Dither        string                 `protobuf:"bytes,5,opt,name=dither,proto3" json:"dither,omitempty"`                         // as in DisplayImageRequest; read from the first chunk
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageChunk) Reset() {
+	*x = ImageChunk{}
+	mi := &file_proto_epd_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageChunk) ProtoMessage() {}
+
+func (x *ImageChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageChunk.ProtoReflect.Descriptor instead.
+func (*ImageChunk) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ImageChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImageChunk) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *ImageChunk) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *ImageChunk) GetFit() string {
+	if x != nil {
+		return x.Fit
+	}
+	return ""
+}
+
+func (x *ImageChunk) GetDither() string {
+	if x != nil {
+		return x.Dither
+	}
+	return ""
+}
+
 type DisplayImageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -74,7 +229,7 @@ type DisplayImageResponse struct {
 
 func (x *DisplayImageResponse) Reset() {
 	*x = DisplayImageResponse{}
-	mi := &file_proto_epd_proto_msgTypes[1]
+	mi := &file_proto_epd_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -86,7 +241,7 @@ func (x *DisplayImageResponse) String() string {
 func (*DisplayImageResponse) ProtoMessage() {}
 
 func (x *DisplayImageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_epd_proto_msgTypes[1]
+	mi := &file_proto_epd_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -99,7 +254,7 @@ func (x *DisplayImageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisplayImageResponse.ProtoReflect.Descriptor instead.
 func (*DisplayImageResponse) Descriptor() ([]byte, []int) {
-	return file_proto_epd_proto_rawDescGZIP(), []int{1}
+	return file_proto_epd_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *DisplayImageResponse) GetMessage() string {
@@ -118,7 +273,7 @@ type DisplayTextRequest struct {
 
 func (x *DisplayTextRequest) Reset() {
 	*x = DisplayTextRequest{}
-	mi := &file_proto_epd_proto_msgTypes[2]
+	mi := &file_proto_epd_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -130,7 +285,7 @@ func (x *DisplayTextRequest) String() string {
 func (*DisplayTextRequest) ProtoMessage() {}
 
 func (x *DisplayTextRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_epd_proto_msgTypes[2]
+	mi := &file_proto_epd_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -143,7 +298,7 @@ func (x *DisplayTextRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisplayTextRequest.ProtoReflect.Descriptor instead.
 func (*DisplayTextRequest) Descriptor() ([]byte, []int) {
-	return file_proto_epd_proto_rawDescGZIP(), []int{2}
+	return file_proto_epd_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *DisplayTextRequest) GetText() string {
@@ -162,7 +317,7 @@ type DisplayTextResponse struct {
 
 func (x *DisplayTextResponse) Reset() {
 	*x = DisplayTextResponse{}
-	mi := &file_proto_epd_proto_msgTypes[3]
+	mi := &file_proto_epd_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -174,7 +329,7 @@ func (x *DisplayTextResponse) String() string {
 func (*DisplayTextResponse) ProtoMessage() {}
 
 func (x *DisplayTextResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_epd_proto_msgTypes[3]
+	mi := &file_proto_epd_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -187,7 +342,7 @@ func (x *DisplayTextResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisplayTextResponse.ProtoReflect.Descriptor instead.
 func (*DisplayTextResponse) Descriptor() ([]byte, []int) {
-	return file_proto_epd_proto_rawDescGZIP(), []int{3}
+	return file_proto_epd_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DisplayTextResponse) GetMessage() string {
@@ -197,6 +352,190 @@ func (x *DisplayTextResponse) GetMessage() string {
 	return ""
 }
 
+type DisplayMarkdownRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Markdown      string                 `protobuf:"bytes,1,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisplayMarkdownRequest) Reset() {
+	*x = DisplayMarkdownRequest{}
+	mi := &file_proto_epd_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisplayMarkdownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisplayMarkdownRequest) ProtoMessage() {}
+
+func (x *DisplayMarkdownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisplayMarkdownRequest.ProtoReflect.Descriptor instead.
+func (*DisplayMarkdownRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DisplayMarkdownRequest) GetMarkdown() string {
+	if x != nil {
+		return x.Markdown
+	}
+	return ""
+}
+
+type DisplayMarkdownResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisplayMarkdownResponse) Reset() {
+	*x = DisplayMarkdownResponse{}
+	mi := &file_proto_epd_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisplayMarkdownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisplayMarkdownResponse) ProtoMessage() {}
+
+func (x *DisplayMarkdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisplayMarkdownResponse.ProtoReflect.Descriptor instead.
+func (*DisplayMarkdownResponse) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DisplayMarkdownResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RenderDashboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HeaderText    string                 `protobuf:"bytes,1,opt,name=header_text,json=headerText,proto3" json:"header_text,omitempty"` // text for the dashboard header
+	BodyText      string                 `protobuf:"bytes,2,opt,name=body_text,json=bodyText,proto3" json:"body_text,omitempty"`       // markdown for the dashboard body
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderDashboardRequest) Reset() {
+	*x = RenderDashboardRequest{}
+	mi := &file_proto_epd_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderDashboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderDashboardRequest) ProtoMessage() {}
+
+func (x *RenderDashboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderDashboardRequest.ProtoReflect.Descriptor instead.
+func (*RenderDashboardRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RenderDashboardRequest) GetHeaderText() string {
+	if x != nil {
+		return x.HeaderText
+	}
+	return ""
+}
+
+func (x *RenderDashboardRequest) GetBodyText() string {
+	if x != nil {
+		return x.BodyText
+	}
+	return ""
+}
+
+type RenderDashboardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderDashboardResponse) Reset() {
+	*x = RenderDashboardResponse{}
+	mi := &file_proto_epd_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderDashboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderDashboardResponse) ProtoMessage() {}
+
+func (x *RenderDashboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderDashboardResponse.ProtoReflect.Descriptor instead.
+func (*RenderDashboardResponse) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RenderDashboardResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type ClearRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -205,7 +544,7 @@ type ClearRequest struct {
 
 func (x *ClearRequest) Reset() {
 	*x = ClearRequest{}
-	mi := &file_proto_epd_proto_msgTypes[4]
+	mi := &file_proto_epd_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -217,7 +556,7 @@ func (x *ClearRequest) String() string {
 func (*ClearRequest) ProtoMessage() {}
 
 func (x *ClearRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_epd_proto_msgTypes[4]
+	mi := &file_proto_epd_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -230,7 +569,7 @@ func (x *ClearRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearRequest.ProtoReflect.Descriptor instead.
 func (*ClearRequest) Descriptor() ([]byte, []int) {
-	return file_proto_epd_proto_rawDescGZIP(), []int{4}
+	return file_proto_epd_proto_rawDescGZIP(), []int{9}
 }
 
 type ClearResponse struct {
@@ -242,7 +581,7 @@ type ClearResponse struct {
 
 func (x *ClearResponse) Reset() {
 	*x = ClearResponse{}
-	mi := &file_proto_epd_proto_msgTypes[5]
+	mi := &file_proto_epd_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -254,7 +593,7 @@ func (x *ClearResponse) String() string {
 func (*ClearResponse) ProtoMessage() {}
 
 func (x *ClearResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_epd_proto_msgTypes[5]
+	mi := &file_proto_epd_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -267,7 +606,7 @@ func (x *ClearResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClearResponse.ProtoReflect.Descriptor instead.
 func (*ClearResponse) Descriptor() ([]byte, []int) {
-	return file_proto_epd_proto_rawDescGZIP(), []int{5}
+	return file_proto_epd_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ClearResponse) GetMessage() string {
@@ -285,7 +624,7 @@ type SleepRequest struct {
 
 func (x *SleepRequest) Reset() {
 	*x = SleepRequest{}
-	mi := &file_proto_epd_proto_msgTypes[6]
+	mi := &file_proto_epd_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -297,7 +636,7 @@ func (x *SleepRequest) String() string {
 func (*SleepRequest) ProtoMessage() {}
 
 func (x *SleepRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_epd_proto_msgTypes[6]
+	mi := &file_proto_epd_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -310,7 +649,7 @@ func (x *SleepRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SleepRequest.ProtoReflect.Descriptor instead.
 func (*SleepRequest) Descriptor() ([]byte, []int) {
-	return file_proto_epd_proto_rawDescGZIP(), []int{6}
+	return file_proto_epd_proto_rawDescGZIP(), []int{11}
 }
 
 type SleepResponse struct {
@@ -322,7 +661,7 @@ type SleepResponse struct {
 
 func (x *SleepResponse) Reset() {
 	*x = SleepResponse{}
-	mi := &file_proto_epd_proto_msgTypes[7]
+	mi := &file_proto_epd_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -334,7 +673,7 @@ func (x *SleepResponse) String() string {
 func (*SleepResponse) ProtoMessage() {}
 
 func (x *SleepResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_epd_proto_msgTypes[7]
+	mi := &file_proto_epd_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -347,7 +686,7 @@ func (x *SleepResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SleepResponse.ProtoReflect.Descriptor instead.
 func (*SleepResponse) Descriptor() ([]byte, []int) {
-	return file_proto_epd_proto_rawDescGZIP(), []int{7}
+	return file_proto_epd_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SleepResponse) GetMessage() string {
@@ -357,32 +696,1556 @@ func (x *SleepResponse) GetMessage() string {
 	return ""
 }
 
-var File_proto_epd_proto protoreflect.FileDescriptor
+type GetCurrentFrameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentFrameRequest) Reset() {
+	*x = GetCurrentFrameRequest{}
+	mi := &file_proto_epd_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentFrameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentFrameRequest) ProtoMessage() {}
+
+func (x *GetCurrentFrameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentFrameRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentFrameRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{13}
+}
+
+type GetCurrentFrameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImageData     []byte                 `protobuf:"bytes,1,opt,name=image_data,json=imageData,proto3" json:"image_data,omitempty"` // PNG encoded image data
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // when the frame was last rendered
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentFrameResponse) Reset() {
+	*x = GetCurrentFrameResponse{}
+	mi := &file_proto_epd_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentFrameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentFrameResponse) ProtoMessage() {}
+
+func (x *GetCurrentFrameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentFrameResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentFrameResponse) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetCurrentFrameResponse) GetImageData() []byte {
+	if x != nil {
+		return x.ImageData
+	}
+	return nil
+}
+
+func (x *GetCurrentFrameResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_proto_epd_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{15}
+}
+
+type GetStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        string                 `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`                              // EPD device type, e.g. "epd7in5v2"
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`                               // panel width in pixels
+	Height        int32                  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`                             // panel height in pixels
+	QueueDepth    int32                  `protobuf:"varint,4,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`   // operations queued or in progress
+	LastRefresh   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_refresh,json=lastRefresh,proto3" json:"last_refresh,omitempty"` // when the last successful refresh completed
+	LastError     string                 `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`       // error from the most recent refresh, if it failed
+	Sleeping      bool                   `protobuf:"varint,7,opt,name=sleeping,proto3" json:"sleeping,omitempty"`                         // whether the panel is in sleep mode
+	Display       string                 `protobuf:"bytes,8,opt,name=display,proto3" json:"display,omitempty"`                            // name of the display, on daemons serving several
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_proto_epd_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetStatusResponse) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetQueueDepth() int32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetLastRefresh() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRefresh
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetSleeping() bool {
+	if x != nil {
+		return x.Sleeping
+	}
+	return false
+}
+
+func (x *GetStatusResponse) GetDisplay() string {
+	if x != nil {
+		return x.Display
+	}
+	return ""
+}
+
+type ListDisplaysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDisplaysRequest) Reset() {
+	*x = ListDisplaysRequest{}
+	mi := &file_proto_epd_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDisplaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDisplaysRequest) ProtoMessage() {}
+
+func (x *ListDisplaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDisplaysRequest.ProtoReflect.Descriptor instead.
+func (*ListDisplaysRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{17}
+}
+
+type ListDisplaysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Displays      []*GetStatusResponse   `protobuf:"bytes,1,rep,name=displays,proto3" json:"displays,omitempty"` // the default display first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDisplaysResponse) Reset() {
+	*x = ListDisplaysResponse{}
+	mi := &file_proto_epd_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDisplaysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDisplaysResponse) ProtoMessage() {}
+
+func (x *ListDisplaysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDisplaysResponse.ProtoReflect.Descriptor instead.
+func (*ListDisplaysResponse) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListDisplaysResponse) GetDisplays() []*GetStatusResponse {
+	if x != nil {
+		return x.Displays
+	}
+	return nil
+}
+
+type WatchEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	mi := &file_proto_epd_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{19}
+}
+
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          Event_Type             `protobuf:"varint,1,opt,name=type,proto3,enum=epd.Event_Type" json:"type,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`                                        // when the event occurred
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`             // when the operation started, if applicable
+	Operation     string                 `protobuf:"bytes,4,opt,name=operation,proto3" json:"operation,omitempty"`                              // RPC that caused the event, e.g. "DisplayImage"
+	ClientId      string                 `protobuf:"bytes,5,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`                // identity reported by the requesting client
+	ClientAddress string                 `protobuf:"bytes,6,opt,name=client_address,json=clientAddress,proto3" json:"client_address,omitempty"` // network address of the requesting client
+	QueueDepth    int32                  `protobuf:"varint,7,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`         // operations queued or in progress
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`                                      // failure reason for REFRESH_FAILED
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_proto_epd_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Event) GetType() Event_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Event_TYPE_UNSPECIFIED
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Event) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Event) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *Event) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *Event) GetClientAddress() string {
+	if x != nil {
+		return x.ClientAddress
+	}
+	return ""
+}
+
+func (x *Event) GetQueueDepth() int32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *Event) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Schedule is content the daemon displays whenever its cron expression fires.
+type Schedule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`     // assigned by the daemon
+	Cron  string                 `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"` // five-field cron expression or descriptor, e.g. "*/15 7-22 * * *" or "@hourly"
+	// Types that are valid to be assigned to Content:
+	//
+	//	*Schedule_Dashboard
+	//	*Schedule_MarkdownFile
+	//	*Schedule_ImageUrl
+	//	*Schedule_Text
+	Content       isSchedule_Content     `protobuf_oneof:"content"`
+	NextRun       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"` // set by the daemon when listing
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	mi := &file_proto_epd_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schedule) ProtoMessage() {}
+
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Schedule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Schedule) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *Schedule) GetContent() isSchedule_Content {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *Schedule) GetDashboard() *DashboardContent {
+	if x != nil {
+		if x, ok := x.Content.(*Schedule_Dashboard); ok {
+			return x.Dashboard
+		}
+	}
+	return nil
+}
+
+func (x *Schedule) GetMarkdownFile() string {
+	if x != nil {
+		if x, ok := x.Content.(*Schedule_MarkdownFile); ok {
+			return x.MarkdownFile
+		}
+	}
+	return ""
+}
+
+func (x *Schedule) GetImageUrl() string {
+	if x != nil {
+		if x, ok := x.Content.(*Schedule_ImageUrl); ok {
+			return x.ImageUrl
+		}
+	}
+	return ""
+}
+
+func (x *Schedule) GetText() string {
+	if x != nil {
+		if x, ok := x.Content.(*Schedule_Text); ok {
+			return x.Text
+		}
+	}
+	return ""
+}
+
+func (x *Schedule) GetNextRun() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRun
+	}
+	return nil
+}
+
+type isSchedule_Content interface {
+	isSchedule_Content()
+}
+
+type Schedule_Dashboard struct {
+	Dashboard *DashboardContent `protobuf:"bytes,3,opt,name=dashboard,proto3,oneof"` // render the dashboard
+}
+
+type Schedule_MarkdownFile struct {
+	MarkdownFile string `protobuf:"bytes,4,opt,name=markdown_file,json=markdownFile,proto3,oneof"` // path on the daemon to a markdown file, read on every run
+}
+
+type Schedule_ImageUrl struct {
+	ImageUrl string `protobuf:"bytes,5,opt,name=image_url,json=imageUrl,proto3,oneof"` // URL or path on the daemon of an image, fetched on every run
+}
+
+type Schedule_Text struct {
+	Text string `protobuf:"bytes,6,opt,name=text,proto3,oneof"` // display text
+}
+
+func (*Schedule_Dashboard) isSchedule_Content() {}
+
+func (*Schedule_MarkdownFile) isSchedule_Content() {}
+
+func (*Schedule_ImageUrl) isSchedule_Content() {}
+
+func (*Schedule_Text) isSchedule_Content() {}
+
+type DashboardContent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HeaderText    string                 `protobuf:"bytes,1,opt,name=header_text,json=headerText,proto3" json:"header_text,omitempty"` // header text, or a path on the daemon to a file holding it
+	BodyText      string                 `protobuf:"bytes,2,opt,name=body_text,json=bodyText,proto3" json:"body_text,omitempty"`       // body markdown, or a path on the daemon to a file holding it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DashboardContent) Reset() {
+	*x = DashboardContent{}
+	mi := &file_proto_epd_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DashboardContent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DashboardContent) ProtoMessage() {}
+
+func (x *DashboardContent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DashboardContent.ProtoReflect.Descriptor instead.
+func (*DashboardContent) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DashboardContent) GetHeaderText() string {
+	if x != nil {
+		return x.HeaderText
+	}
+	return ""
+}
+
+func (x *DashboardContent) GetBodyText() string {
+	if x != nil {
+		return x.BodyText
+	}
+	return ""
+}
+
+// QuietHours is a daily window, in the daemon's local time, in which scheduled
+// entries don't run. It may wrap past midnight; empty start and end disable it.
+type QuietHours struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"` // "HH:MM"
+	End           string                 `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`     // "HH:MM"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuietHours) Reset() {
+	*x = QuietHours{}
+	mi := &file_proto_epd_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuietHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuietHours) ProtoMessage() {}
+
+func (x *QuietHours) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuietHours.ProtoReflect.Descriptor instead.
+func (*QuietHours) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *QuietHours) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *QuietHours) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+type AddScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *Schedule              `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddScheduleRequest) Reset() {
+	*x = AddScheduleRequest{}
+	mi := &file_proto_epd_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddScheduleRequest) ProtoMessage() {}
+
+func (x *AddScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddScheduleRequest.ProtoReflect.Descriptor instead.
+func (*AddScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AddScheduleRequest) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type RemoveScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveScheduleRequest) Reset() {
+	*x = RemoveScheduleRequest{}
+	mi := &file_proto_epd_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveScheduleRequest) ProtoMessage() {}
+
+func (x *RemoveScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveScheduleRequest.ProtoReflect.Descriptor instead.
+func (*RemoveScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RemoveScheduleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RemoveScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveScheduleResponse) Reset() {
+	*x = RemoveScheduleResponse{}
+	mi := &file_proto_epd_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveScheduleResponse) ProtoMessage() {}
+
+func (x *RemoveScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveScheduleResponse.ProtoReflect.Descriptor instead.
+func (*RemoveScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RemoveScheduleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListSchedulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	mi := &file_proto_epd_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{27}
+}
+
+type ListSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*Schedule            `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	QuietHours    *QuietHours            `protobuf:"bytes,2,opt,name=quiet_hours,json=quietHours,proto3" json:"quiet_hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesResponse) Reset() {
+	*x = ListSchedulesResponse{}
+	mi := &file_proto_epd_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesResponse) ProtoMessage() {}
+
+func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListSchedulesResponse) GetSchedules() []*Schedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+func (x *ListSchedulesResponse) GetQuietHours() *QuietHours {
+	if x != nil {
+		return x.QuietHours
+	}
+	return nil
+}
+
+// PlaylistItem is content the daemon shows for its dwell time before moving on.
+type PlaylistItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // assigned by the daemon
+	// Types that are valid to be assigned to Content:
+	//
+	//	*PlaylistItem_Image
+	//	*PlaylistItem_MarkdownFile
+	//	*PlaylistItem_Dashboard
+	Content       isPlaylistItem_Content `protobuf_oneof:"content"`
+	Dwell         *durationpb.Duration   `protobuf:"bytes,5,opt,name=dwell,proto3" json:"dwell,omitempty"` // how long to show the item; defaults to one minute
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaylistItem) Reset() {
+	*x = PlaylistItem{}
+	mi := &file_proto_epd_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaylistItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaylistItem) ProtoMessage() {}
+
+func (x *PlaylistItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaylistItem.ProtoReflect.Descriptor instead.
+func (*PlaylistItem) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PlaylistItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PlaylistItem) GetContent() isPlaylistItem_Content {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *PlaylistItem) GetImage() string {
+	if x != nil {
+		if x, ok := x.Content.(*PlaylistItem_Image); ok {
+			return x.Image
+		}
+	}
+	return ""
+}
+
+func (x *PlaylistItem) GetMarkdownFile() string {
+	if x != nil {
+		if x, ok := x.Content.(*PlaylistItem_MarkdownFile); ok {
+			return x.MarkdownFile
+		}
+	}
+	return ""
+}
+
+func (x *PlaylistItem) GetDashboard() *DashboardContent {
+	if x != nil {
+		if x, ok := x.Content.(*PlaylistItem_Dashboard); ok {
+			return x.Dashboard
+		}
+	}
+	return nil
+}
+
+func (x *PlaylistItem) GetDwell() *durationpb.Duration {
+	if x != nil {
+		return x.Dwell
+	}
+	return nil
+}
+
+type isPlaylistItem_Content interface {
+	isPlaylistItem_Content()
+}
+
+type PlaylistItem_Image struct {
+	Image string `protobuf:"bytes,2,opt,name=image,proto3,oneof"` // URL or path on the daemon of an image, fetched every time it is shown
+}
+
+type PlaylistItem_MarkdownFile struct {
+	MarkdownFile string `protobuf:"bytes,3,opt,name=markdown_file,json=markdownFile,proto3,oneof"` // path on the daemon to a markdown file, read every time it is shown
+}
+
+type PlaylistItem_Dashboard struct {
+	Dashboard *DashboardContent `protobuf:"bytes,4,opt,name=dashboard,proto3,oneof"` // render the dashboard
+}
+
+func (*PlaylistItem_Image) isPlaylistItem_Content() {}
+
+func (*PlaylistItem_MarkdownFile) isPlaylistItem_Content() {}
+
+func (*PlaylistItem_Dashboard) isPlaylistItem_Content() {}
+
+// Playlist is the ordered list of items the daemon cycles through.
+type Playlist struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*PlaylistItem        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Shuffle       bool                   `protobuf:"varint,2,opt,name=shuffle,proto3" json:"shuffle,omitempty"`                        // play items in a random order, reshuffled every cycle
+	Paused        bool                   `protobuf:"varint,3,opt,name=paused,proto3" json:"paused,omitempty"`                          // stay on the current item
+	CurrentId     string                 `protobuf:"bytes,4,opt,name=current_id,json=currentId,proto3" json:"current_id,omitempty"`    // item currently shown, if any
+	NextChange    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=next_change,json=nextChange,proto3" json:"next_change,omitempty"` // when the next item will be shown, unless paused
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Playlist) Reset() {
+	*x = Playlist{}
+	mi := &file_proto_epd_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Playlist) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Playlist) ProtoMessage() {}
+
+func (x *Playlist) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Playlist.ProtoReflect.Descriptor instead.
+func (*Playlist) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Playlist) GetItems() []*PlaylistItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Playlist) GetShuffle() bool {
+	if x != nil {
+		return x.Shuffle
+	}
+	return false
+}
+
+func (x *Playlist) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *Playlist) GetCurrentId() string {
+	if x != nil {
+		return x.CurrentId
+	}
+	return ""
+}
+
+func (x *Playlist) GetNextChange() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextChange
+	}
+	return nil
+}
+
+type AddPlaylistItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *PlaylistItem          `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddPlaylistItemRequest) Reset() {
+	*x = AddPlaylistItemRequest{}
+	mi := &file_proto_epd_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPlaylistItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPlaylistItemRequest) ProtoMessage() {}
+
+func (x *AddPlaylistItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPlaylistItemRequest.ProtoReflect.Descriptor instead.
+func (*AddPlaylistItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AddPlaylistItemRequest) GetItem() *PlaylistItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type RemovePlaylistItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePlaylistItemRequest) Reset() {
+	*x = RemovePlaylistItemRequest{}
+	mi := &file_proto_epd_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePlaylistItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePlaylistItemRequest) ProtoMessage() {}
+
+func (x *RemovePlaylistItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePlaylistItemRequest.ProtoReflect.Descriptor instead.
+func (*RemovePlaylistItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RemovePlaylistItemRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RemovePlaylistItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePlaylistItemResponse) Reset() {
+	*x = RemovePlaylistItemResponse{}
+	mi := &file_proto_epd_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePlaylistItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePlaylistItemResponse) ProtoMessage() {}
+
+func (x *RemovePlaylistItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePlaylistItemResponse.ProtoReflect.Descriptor instead.
+func (*RemovePlaylistItemResponse) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RemovePlaylistItemResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetPlaylistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlaylistRequest) Reset() {
+	*x = GetPlaylistRequest{}
+	mi := &file_proto_epd_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlaylistRequest) ProtoMessage() {}
+
+func (x *GetPlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlaylistRequest.ProtoReflect.Descriptor instead.
+func (*GetPlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{34}
+}
+
+type SkipPlaylistItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SkipPlaylistItemRequest) Reset() {
+	*x = SkipPlaylistItemRequest{}
+	mi := &file_proto_epd_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SkipPlaylistItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkipPlaylistItemRequest) ProtoMessage() {}
+
+func (x *SkipPlaylistItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkipPlaylistItemRequest.ProtoReflect.Descriptor instead.
+func (*SkipPlaylistItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{35}
+}
+
+type PausePlaylistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paused        bool                   `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"` // true to pause, false to resume
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PausePlaylistRequest) Reset() {
+	*x = PausePlaylistRequest{}
+	mi := &file_proto_epd_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PausePlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PausePlaylistRequest) ProtoMessage() {}
+
+func (x *PausePlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PausePlaylistRequest.ProtoReflect.Descriptor instead.
+func (*PausePlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PausePlaylistRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+type ShufflePlaylistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shuffle       bool                   `protobuf:"varint,1,opt,name=shuffle,proto3" json:"shuffle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShufflePlaylistRequest) Reset() {
+	*x = ShufflePlaylistRequest{}
+	mi := &file_proto_epd_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShufflePlaylistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShufflePlaylistRequest) ProtoMessage() {}
+
+func (x *ShufflePlaylistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_epd_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShufflePlaylistRequest.ProtoReflect.Descriptor instead.
+func (*ShufflePlaylistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_epd_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ShufflePlaylistRequest) GetShuffle() bool {
+	if x != nil {
+		return x.Shuffle
+	}
+	return false
+}
+
+var File_proto_epd_proto protoreflect.FileDescriptor
 
 const file_proto_epd_proto_rawDesc = "" +
 	"\n" +
-	"\x0fproto/epd.proto\x12\x03epd\"4\n" +
+	"\x0fproto/epd.proto\x12\x03epd\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"^\n" +
 	"\x13DisplayImageRequest\x12\x1d\n" +
 	"\n" +
-	"image_data\x18\x01 \x01(\fR\timageData\"0\n" +
+	"image_data\x18\x01 \x01(\fR\timageData\x12\x10\n" +
+	"\x03fit\x18\x02 \x01(\tR\x03fit\x12\x16\n" +
+	"\x06dither\x18\x03 \x01(\tR\x06dither\"\x81\x01\n" +
+	"\n" +
+	"ImageChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x02 \x01(\x03R\ttotalSize\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\x12\x10\n" +
+	"\x03fit\x18\x04 \x01(\tR\x03fit\x12\x16\n" +
+	"\x06dither\x18\x05 \x01(\tR\x06dither\"0\n" +
 	"\x14DisplayImageResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\"(\n" +
 	"\x12DisplayTextRequest\x12\x12\n" +
 	"\x04text\x18\x01 \x01(\tR\x04text\"/\n" +
 	"\x13DisplayTextResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"4\n" +
+	"\x16DisplayMarkdownRequest\x12\x1a\n" +
+	"\bmarkdown\x18\x01 \x01(\tR\bmarkdown\"3\n" +
+	"\x17DisplayMarkdownResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"V\n" +
+	"\x16RenderDashboardRequest\x12\x1f\n" +
+	"\vheader_text\x18\x01 \x01(\tR\n" +
+	"headerText\x12\x1b\n" +
+	"\tbody_text\x18\x02 \x01(\tR\bbodyText\"3\n" +
+	"\x17RenderDashboardResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\"\x0e\n" +
 	"\fClearRequest\")\n" +
 	"\rClearResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\"\x0e\n" +
 	"\fSleepRequest\")\n" +
 	"\rSleepResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2\xf3\x01\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\x18\n" +
+	"\x16GetCurrentFrameRequest\"s\n" +
+	"\x17GetCurrentFrameResponse\x12\x1d\n" +
+	"\n" +
+	"image_data\x18\x01 \x01(\fR\timageData\x129\n" +
+	"\n" +
+	"updated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x12\n" +
+	"\x10GetStatusRequest\"\x8e\x02\n" +
+	"\x11GetStatusResponse\x12\x16\n" +
+	"\x06device\x18\x01 \x01(\tR\x06device\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\x12\x1f\n" +
+	"\vqueue_depth\x18\x04 \x01(\x05R\n" +
+	"queueDepth\x12=\n" +
+	"\flast_refresh\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vlastRefresh\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x06 \x01(\tR\tlastError\x12\x1a\n" +
+	"\bsleeping\x18\a \x01(\bR\bsleeping\x12\x18\n" +
+	"\adisplay\x18\b \x01(\tR\adisplay\"\x15\n" +
+	"\x13ListDisplaysRequest\"J\n" +
+	"\x14ListDisplaysResponse\x122\n" +
+	"\bdisplays\x18\x01 \x03(\v2\x16.epd.GetStatusResponseR\bdisplays\"\x14\n" +
+	"\x12WatchEventsRequest\"\xb7\x03\n" +
+	"\x05Event\x12#\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x0f.epd.Event.TypeR\x04type\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x129\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12\x1c\n" +
+	"\toperation\x18\x04 \x01(\tR\toperation\x12\x1b\n" +
+	"\tclient_id\x18\x05 \x01(\tR\bclientId\x12%\n" +
+	"\x0eclient_address\x18\x06 \x01(\tR\rclientAddress\x12\x1f\n" +
+	"\vqueue_depth\x18\a \x01(\x05R\n" +
+	"queueDepth\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\"\x84\x01\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fREFRESH_STARTED\x10\x01\x12\x15\n" +
+	"\x11REFRESH_COMPLETED\x10\x02\x12\x12\n" +
+	"\x0eREFRESH_FAILED\x10\x03\x12\t\n" +
+	"\x05SLEEP\x10\x04\x12\b\n" +
+	"\x04WAKE\x10\x05\x12\x11\n" +
+	"\rQUEUE_CHANGED\x10\x06\"\x83\x02\n" +
+	"\bSchedule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04cron\x18\x02 \x01(\tR\x04cron\x125\n" +
+	"\tdashboard\x18\x03 \x01(\v2\x15.epd.DashboardContentH\x00R\tdashboard\x12%\n" +
+	"\rmarkdown_file\x18\x04 \x01(\tH\x00R\fmarkdownFile\x12\x1d\n" +
+	"\timage_url\x18\x05 \x01(\tH\x00R\bimageUrl\x12\x14\n" +
+	"\x04text\x18\x06 \x01(\tH\x00R\x04text\x125\n" +
+	"\bnext_run\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\anextRunB\t\n" +
+	"\acontent\"P\n" +
+	"\x10DashboardContent\x12\x1f\n" +
+	"\vheader_text\x18\x01 \x01(\tR\n" +
+	"headerText\x12\x1b\n" +
+	"\tbody_text\x18\x02 \x01(\tR\bbodyText\"4\n" +
+	"\n" +
+	"QuietHours\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\"?\n" +
+	"\x12AddScheduleRequest\x12)\n" +
+	"\bschedule\x18\x01 \x01(\v2\r.epd.ScheduleR\bschedule\"'\n" +
+	"\x15RemoveScheduleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
+	"\x16RemoveScheduleResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\x16\n" +
+	"\x14ListSchedulesRequest\"v\n" +
+	"\x15ListSchedulesResponse\x12+\n" +
+	"\tschedules\x18\x01 \x03(\v2\r.epd.ScheduleR\tschedules\x120\n" +
+	"\vquiet_hours\x18\x02 \x01(\v2\x0f.epd.QuietHoursR\n" +
+	"quietHours\"\xd0\x01\n" +
+	"\fPlaylistItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x05image\x18\x02 \x01(\tH\x00R\x05image\x12%\n" +
+	"\rmarkdown_file\x18\x03 \x01(\tH\x00R\fmarkdownFile\x125\n" +
+	"\tdashboard\x18\x04 \x01(\v2\x15.epd.DashboardContentH\x00R\tdashboard\x12/\n" +
+	"\x05dwell\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x05dwellB\t\n" +
+	"\acontent\"\xc1\x01\n" +
+	"\bPlaylist\x12'\n" +
+	"\x05items\x18\x01 \x03(\v2\x11.epd.PlaylistItemR\x05items\x12\x18\n" +
+	"\ashuffle\x18\x02 \x01(\bR\ashuffle\x12\x16\n" +
+	"\x06paused\x18\x03 \x01(\bR\x06paused\x12\x1d\n" +
+	"\n" +
+	"current_id\x18\x04 \x01(\tR\tcurrentId\x12;\n" +
+	"\vnext_change\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"nextChange\"?\n" +
+	"\x16AddPlaylistItemRequest\x12%\n" +
+	"\x04item\x18\x01 \x01(\v2\x11.epd.PlaylistItemR\x04item\"+\n" +
+	"\x19RemovePlaylistItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"6\n" +
+	"\x1aRemovePlaylistItemResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\x14\n" +
+	"\x12GetPlaylistRequest\"\x19\n" +
+	"\x17SkipPlaylistItemRequest\".\n" +
+	"\x14PausePlaylistRequest\x12\x16\n" +
+	"\x06paused\x18\x01 \x01(\bR\x06paused\"2\n" +
+	"\x16ShufflePlaylistRequest\x12\x18\n" +
+	"\ashuffle\x18\x01 \x01(\bR\ashuffle2\xda\n" +
+	"\n" +
 	"\n" +
 	"EPDService\x12C\n" +
-	"\fDisplayImage\x12\x18.epd.DisplayImageRequest\x1a\x19.epd.DisplayImageResponse\x12@\n" +
-	"\vDisplayText\x12\x17.epd.DisplayTextRequest\x1a\x18.epd.DisplayTextResponse\x12.\n" +
+	"\fDisplayImage\x12\x18.epd.DisplayImageRequest\x1a\x19.epd.DisplayImageResponse\x12;\n" +
+	"\vUploadImage\x12\x0f.epd.ImageChunk\x1a\x19.epd.DisplayImageResponse(\x01\x12@\n" +
+	"\vDisplayText\x12\x17.epd.DisplayTextRequest\x1a\x18.epd.DisplayTextResponse\x12L\n" +
+	"\x0fDisplayMarkdown\x12\x1b.epd.DisplayMarkdownRequest\x1a\x1c.epd.DisplayMarkdownResponse\x12L\n" +
+	"\x0fRenderDashboard\x12\x1b.epd.RenderDashboardRequest\x1a\x1c.epd.RenderDashboardResponse\x12.\n" +
 	"\x05Clear\x12\x11.epd.ClearRequest\x1a\x12.epd.ClearResponse\x12.\n" +
-	"\x05Sleep\x12\x11.epd.SleepRequest\x1a\x12.epd.SleepResponseB&Z$github.com/justmiles/epd/proto/epdpbb\x06proto3"
+	"\x05Sleep\x12\x11.epd.SleepRequest\x1a\x12.epd.SleepResponse\x12L\n" +
+	"\x0fGetCurrentFrame\x12\x1b.epd.GetCurrentFrameRequest\x1a\x1c.epd.GetCurrentFrameResponse\x12:\n" +
+	"\tGetStatus\x12\x15.epd.GetStatusRequest\x1a\x16.epd.GetStatusResponse\x124\n" +
+	"\vWatchEvents\x12\x17.epd.WatchEventsRequest\x1a\n" +
+	".epd.Event0\x01\x12C\n" +
+	"\fListDisplays\x12\x18.epd.ListDisplaysRequest\x1a\x19.epd.ListDisplaysResponse\x125\n" +
+	"\vAddSchedule\x12\x17.epd.AddScheduleRequest\x1a\r.epd.Schedule\x12I\n" +
+	"\x0eRemoveSchedule\x12\x1a.epd.RemoveScheduleRequest\x1a\x1b.epd.RemoveScheduleResponse\x12F\n" +
+	"\rListSchedules\x12\x19.epd.ListSchedulesRequest\x1a\x1a.epd.ListSchedulesResponse\x121\n" +
+	"\rSetQuietHours\x12\x0f.epd.QuietHours\x1a\x0f.epd.QuietHours\x12A\n" +
+	"\x0fAddPlaylistItem\x12\x1b.epd.AddPlaylistItemRequest\x1a\x11.epd.PlaylistItem\x12U\n" +
+	"\x12RemovePlaylistItem\x12\x1e.epd.RemovePlaylistItemRequest\x1a\x1f.epd.RemovePlaylistItemResponse\x125\n" +
+	"\vGetPlaylist\x12\x17.epd.GetPlaylistRequest\x1a\r.epd.Playlist\x12?\n" +
+	"\x10SkipPlaylistItem\x12\x1c.epd.SkipPlaylistItemRequest\x1a\r.epd.Playlist\x129\n" +
+	"\rPausePlaylist\x12\x19.epd.PausePlaylistRequest\x1a\r.epd.Playlist\x12=\n" +
+	"\x0fShufflePlaylist\x12\x1b.epd.ShufflePlaylistRequest\x1a\r.epd.PlaylistB&Z$github.com/justmiles/epd/proto/epdpbb\x06proto3"
 
 var (
 	file_proto_epd_proto_rawDescOnce sync.Once
@@ -396,31 +2259,115 @@ func file_proto_epd_proto_rawDescGZIP() []byte {
 	return file_proto_epd_proto_rawDescData
 }
 
-var file_proto_epd_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_epd_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_epd_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_proto_epd_proto_goTypes = []any{
-	(*DisplayImageRequest)(nil),  // 0: epd.DisplayImageRequest
-	(*DisplayImageResponse)(nil), // 1: epd.DisplayImageResponse
-	(*DisplayTextRequest)(nil),   // 2: epd.DisplayTextRequest
-	(*DisplayTextResponse)(nil),  // 3: epd.DisplayTextResponse
-	(*ClearRequest)(nil),         // 4: epd.ClearRequest
-	(*ClearResponse)(nil),        // 5: epd.ClearResponse
-	(*SleepRequest)(nil),         // 6: epd.SleepRequest
-	(*SleepResponse)(nil),        // 7: epd.SleepResponse
+	(Event_Type)(0),                    // 0: epd.Event.Type
+	(*DisplayImageRequest)(nil),        // 1: epd.DisplayImageRequest
+	(*ImageChunk)(nil),                 // 2: epd.ImageChunk
+	(*DisplayImageResponse)(nil),       // 3: epd.DisplayImageResponse
+	(*DisplayTextRequest)(nil),         // 4: epd.DisplayTextRequest
+	(*DisplayTextResponse)(nil),        // 5: epd.DisplayTextResponse
+	(*DisplayMarkdownRequest)(nil),     // 6: epd.DisplayMarkdownRequest
+	(*DisplayMarkdownResponse)(nil),    // 7: epd.DisplayMarkdownResponse
+	(*RenderDashboardRequest)(nil),     // 8: epd.RenderDashboardRequest
+	(*RenderDashboardResponse)(nil),    // 9: epd.RenderDashboardResponse
+	(*ClearRequest)(nil),               // 10: epd.ClearRequest
+	(*ClearResponse)(nil),              // 11: epd.ClearResponse
+	(*SleepRequest)(nil),               // 12: epd.SleepRequest
+	(*SleepResponse)(nil),              // 13: epd.SleepResponse
+	(*GetCurrentFrameRequest)(nil),     // 14: epd.GetCurrentFrameRequest
+	(*GetCurrentFrameResponse)(nil),    // 15: epd.GetCurrentFrameResponse
+	(*GetStatusRequest)(nil),           // 16: epd.GetStatusRequest
+	(*GetStatusResponse)(nil),          // 17: epd.GetStatusResponse
+	(*ListDisplaysRequest)(nil),        // 18: epd.ListDisplaysRequest
+	(*ListDisplaysResponse)(nil),       // 19: epd.ListDisplaysResponse
+	(*WatchEventsRequest)(nil),         // 20: epd.WatchEventsRequest
+	(*Event)(nil),                      // 21: epd.Event
+	(*Schedule)(nil),                   // 22: epd.Schedule
+	(*DashboardContent)(nil),           // 23: epd.DashboardContent
+	(*QuietHours)(nil),                 // 24: epd.QuietHours
+	(*AddScheduleRequest)(nil),         // 25: epd.AddScheduleRequest
+	(*RemoveScheduleRequest)(nil),      // 26: epd.RemoveScheduleRequest
+	(*RemoveScheduleResponse)(nil),     // 27: epd.RemoveScheduleResponse
+	(*ListSchedulesRequest)(nil),       // 28: epd.ListSchedulesRequest
+	(*ListSchedulesResponse)(nil),      // 29: epd.ListSchedulesResponse
+	(*PlaylistItem)(nil),               // 30: epd.PlaylistItem
+	(*Playlist)(nil),                   // 31: epd.Playlist
+	(*AddPlaylistItemRequest)(nil),     // 32: epd.AddPlaylistItemRequest
+	(*RemovePlaylistItemRequest)(nil),  // 33: epd.RemovePlaylistItemRequest
+	(*RemovePlaylistItemResponse)(nil), // 34: epd.RemovePlaylistItemResponse
+	(*GetPlaylistRequest)(nil),         // 35: epd.GetPlaylistRequest
+	(*SkipPlaylistItemRequest)(nil),    // 36: epd.SkipPlaylistItemRequest
+	(*PausePlaylistRequest)(nil),       // 37: epd.PausePlaylistRequest
+	(*ShufflePlaylistRequest)(nil),     // 38: epd.ShufflePlaylistRequest
+	(*timestamppb.Timestamp)(nil),      // 39: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),        // 40: google.protobuf.Duration
 }
 var file_proto_epd_proto_depIdxs = []int32{
-	0, // 0: epd.EPDService.DisplayImage:input_type -> epd.DisplayImageRequest
-	2, // 1: epd.EPDService.DisplayText:input_type -> epd.DisplayTextRequest
-	4, // 2: epd.EPDService.Clear:input_type -> epd.ClearRequest
-	6, // 3: epd.EPDService.Sleep:input_type -> epd.SleepRequest
-	1, // 4: epd.EPDService.DisplayImage:output_type -> epd.DisplayImageResponse
-	3, // 5: epd.EPDService.DisplayText:output_type -> epd.DisplayTextResponse
-	5, // 6: epd.EPDService.Clear:output_type -> epd.ClearResponse
-	7, // 7: epd.EPDService.Sleep:output_type -> epd.SleepResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	39, // 0: epd.GetCurrentFrameResponse.updated_at:type_name -> google.protobuf.Timestamp
+	39, // 1: epd.GetStatusResponse.last_refresh:type_name -> google.protobuf.Timestamp
+	17, // 2: epd.ListDisplaysResponse.displays:type_name -> epd.GetStatusResponse
+	0,  // 3: epd.Event.type:type_name -> epd.Event.Type
+	39, // 4: epd.Event.time:type_name -> google.protobuf.Timestamp
+	39, // 5: epd.Event.started_at:type_name -> google.protobuf.Timestamp
+	23, // 6: epd.Schedule.dashboard:type_name -> epd.DashboardContent
+	39, // 7: epd.Schedule.next_run:type_name -> google.protobuf.Timestamp
+	22, // 8: epd.AddScheduleRequest.schedule:type_name -> epd.Schedule
+	22, // 9: epd.ListSchedulesResponse.schedules:type_name -> epd.Schedule
+	24, // 10: epd.ListSchedulesResponse.quiet_hours:type_name -> epd.QuietHours
+	23, // 11: epd.PlaylistItem.dashboard:type_name -> epd.DashboardContent
+	40, // 12: epd.PlaylistItem.dwell:type_name -> google.protobuf.Duration
+	30, // 13: epd.Playlist.items:type_name -> epd.PlaylistItem
+	39, // 14: epd.Playlist.next_change:type_name -> google.protobuf.Timestamp
+	30, // 15: epd.AddPlaylistItemRequest.item:type_name -> epd.PlaylistItem
+	1,  // 16: epd.EPDService.DisplayImage:input_type -> epd.DisplayImageRequest
+	2,  // 17: epd.EPDService.UploadImage:input_type -> epd.ImageChunk
+	4,  // 18: epd.EPDService.DisplayText:input_type -> epd.DisplayTextRequest
+	6,  // 19: epd.EPDService.DisplayMarkdown:input_type -> epd.DisplayMarkdownRequest
+	8,  // 20: epd.EPDService.RenderDashboard:input_type -> epd.RenderDashboardRequest
+	10, // 21: epd.EPDService.Clear:input_type -> epd.ClearRequest
+	12, // 22: epd.EPDService.Sleep:input_type -> epd.SleepRequest
+	14, // 23: epd.EPDService.GetCurrentFrame:input_type -> epd.GetCurrentFrameRequest
+	16, // 24: epd.EPDService.GetStatus:input_type -> epd.GetStatusRequest
+	20, // 25: epd.EPDService.WatchEvents:input_type -> epd.WatchEventsRequest
+	18, // 26: epd.EPDService.ListDisplays:input_type -> epd.ListDisplaysRequest
+	25, // 27: epd.EPDService.AddSchedule:input_type -> epd.AddScheduleRequest
+	26, // 28: epd.EPDService.RemoveSchedule:input_type -> epd.RemoveScheduleRequest
+	28, // 29: epd.EPDService.ListSchedules:input_type -> epd.ListSchedulesRequest
+	24, // 30: epd.EPDService.SetQuietHours:input_type -> epd.QuietHours
+	32, // 31: epd.EPDService.AddPlaylistItem:input_type -> epd.AddPlaylistItemRequest
+	33, // 32: epd.EPDService.RemovePlaylistItem:input_type -> epd.RemovePlaylistItemRequest
+	35, // 33: epd.EPDService.GetPlaylist:input_type -> epd.GetPlaylistRequest
+	36, // 34: epd.EPDService.SkipPlaylistItem:input_type -> epd.SkipPlaylistItemRequest
+	37, // 35: epd.EPDService.PausePlaylist:input_type -> epd.PausePlaylistRequest
+	38, // 36: epd.EPDService.ShufflePlaylist:input_type -> epd.ShufflePlaylistRequest
+	3,  // 37: epd.EPDService.DisplayImage:output_type -> epd.DisplayImageResponse
+	3,  // 38: epd.EPDService.UploadImage:output_type -> epd.DisplayImageResponse
+	5,  // 39: epd.EPDService.DisplayText:output_type -> epd.DisplayTextResponse
+	7,  // 40: epd.EPDService.DisplayMarkdown:output_type -> epd.DisplayMarkdownResponse
+	9,  // 41: epd.EPDService.RenderDashboard:output_type -> epd.RenderDashboardResponse
+	11, // 42: epd.EPDService.Clear:output_type -> epd.ClearResponse
+	13, // 43: epd.EPDService.Sleep:output_type -> epd.SleepResponse
+	15, // 44: epd.EPDService.GetCurrentFrame:output_type -> epd.GetCurrentFrameResponse
+	17, // 45: epd.EPDService.GetStatus:output_type -> epd.GetStatusResponse
+	21, // 46: epd.EPDService.WatchEvents:output_type -> epd.Event
+	19, // 47: epd.EPDService.ListDisplays:output_type -> epd.ListDisplaysResponse
+	22, // 48: epd.EPDService.AddSchedule:output_type -> epd.Schedule
+	27, // 49: epd.EPDService.RemoveSchedule:output_type -> epd.RemoveScheduleResponse
+	29, // 50: epd.EPDService.ListSchedules:output_type -> epd.ListSchedulesResponse
+	24, // 51: epd.EPDService.SetQuietHours:output_type -> epd.QuietHours
+	30, // 52: epd.EPDService.AddPlaylistItem:output_type -> epd.PlaylistItem
+	34, // 53: epd.EPDService.RemovePlaylistItem:output_type -> epd.RemovePlaylistItemResponse
+	31, // 54: epd.EPDService.GetPlaylist:output_type -> epd.Playlist
+	31, // 55: epd.EPDService.SkipPlaylistItem:output_type -> epd.Playlist
+	31, // 56: epd.EPDService.PausePlaylist:output_type -> epd.Playlist
+	31, // 57: epd.EPDService.ShufflePlaylist:output_type -> epd.Playlist
+	37, // [37:58] is the sub-list for method output_type
+	16, // [16:37] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_proto_epd_proto_init() }
@@ -428,18 +2375,30 @@ func file_proto_epd_proto_init() {
 	if File_proto_epd_proto != nil {
 		return
 	}
+	file_proto_epd_proto_msgTypes[21].OneofWrappers = []any{
+		(*Schedule_Dashboard)(nil),
+		(*Schedule_MarkdownFile)(nil),
+		(*Schedule_ImageUrl)(nil),
+		(*Schedule_Text)(nil),
+	}
+	file_proto_epd_proto_msgTypes[29].OneofWrappers = []any{
+		(*PlaylistItem_Image)(nil),
+		(*PlaylistItem_MarkdownFile)(nil),
+		(*PlaylistItem_Dashboard)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_epd_proto_rawDesc), len(file_proto_epd_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      1,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_epd_proto_goTypes,
 		DependencyIndexes: file_proto_epd_proto_depIdxs,
+		EnumInfos:         file_proto_epd_proto_enumTypes,
 		MessageInfos:      file_proto_epd_proto_msgTypes,
 	}.Build()
 	File_proto_epd_proto = out.File
@@ -19,24 +19,81 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	EPDService_DisplayImage_FullMethodName = "/epd.EPDService/DisplayImage"
-	EPDService_DisplayText_FullMethodName  = "/epd.EPDService/DisplayText"
-	EPDService_Clear_FullMethodName        = "/epd.EPDService/Clear"
-	EPDService_Sleep_FullMethodName        = "/epd.EPDService/Sleep"
+	EPDService_DisplayImage_FullMethodName       = "/epd.EPDService/DisplayImage"
+	EPDService_UploadImage_FullMethodName        = "/epd.EPDService/UploadImage"
+	EPDService_DisplayText_FullMethodName        = "/epd.EPDService/DisplayText"
+	EPDService_DisplayMarkdown_FullMethodName    = "/epd.EPDService/DisplayMarkdown"
+	EPDService_RenderDashboard_FullMethodName    = "/epd.EPDService/RenderDashboard"
+	EPDService_Clear_FullMethodName              = "/epd.EPDService/Clear"
+	EPDService_Sleep_FullMethodName              = "/epd.EPDService/Sleep"
+	EPDService_GetCurrentFrame_FullMethodName    = "/epd.EPDService/GetCurrentFrame"
+	EPDService_GetStatus_FullMethodName          = "/epd.EPDService/GetStatus"
+	EPDService_WatchEvents_FullMethodName        = "/epd.EPDService/WatchEvents"
+	EPDService_ListDisplays_FullMethodName       = "/epd.EPDService/ListDisplays"
+	EPDService_AddSchedule_FullMethodName        = "/epd.EPDService/AddSchedule"
+	EPDService_RemoveSchedule_FullMethodName     = "/epd.EPDService/RemoveSchedule"
+	EPDService_ListSchedules_FullMethodName      = "/epd.EPDService/ListSchedules"
+	EPDService_SetQuietHours_FullMethodName      = "/epd.EPDService/SetQuietHours"
+	EPDService_AddPlaylistItem_FullMethodName    = "/epd.EPDService/AddPlaylistItem"
+	EPDService_RemovePlaylistItem_FullMethodName = "/epd.EPDService/RemovePlaylistItem"
+	EPDService_GetPlaylist_FullMethodName        = "/epd.EPDService/GetPlaylist"
+	EPDService_SkipPlaylistItem_FullMethodName   = "/epd.EPDService/SkipPlaylistItem"
+	EPDService_PausePlaylist_FullMethodName      = "/epd.EPDService/PausePlaylist"
+	EPDService_ShufflePlaylist_FullMethodName    = "/epd.EPDService/ShufflePlaylist"
 )
 
 // EPDServiceClient is the client API for EPDService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// EPDService drives one or more displays. Requests address a display by name
+// with the "epd-display" metadata key; without it they go to the default
+// display.
 type EPDServiceClient interface {
 	// DisplayImage accepts PNG image data and displays it on the EPD
 	DisplayImage(ctx context.Context, in *DisplayImageRequest, opts ...grpc.CallOption) (*DisplayImageResponse, error)
+	// UploadImage streams PNG image data in chunks and displays it on the EPD.
+	// Use it for images too large to fit in a single gRPC message.
+	UploadImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImageChunk, DisplayImageResponse], error)
 	// DisplayText renders text and displays it on the EPD
 	DisplayText(ctx context.Context, in *DisplayTextRequest, opts ...grpc.CallOption) (*DisplayTextResponse, error)
+	// DisplayMarkdown renders markdown on the daemon and displays it on the EPD
+	DisplayMarkdown(ctx context.Context, in *DisplayMarkdownRequest, opts ...grpc.CallOption) (*DisplayMarkdownResponse, error)
+	// RenderDashboard renders the dashboard on the daemon, using its own fonts
+	// and weather configuration, and displays it on the EPD
+	RenderDashboard(ctx context.Context, in *RenderDashboardRequest, opts ...grpc.CallOption) (*RenderDashboardResponse, error)
 	// Clear clears the EPD to white
 	Clear(ctx context.Context, in *ClearRequest, opts ...grpc.CallOption) (*ClearResponse, error)
 	// Sleep puts the EPD into sleep mode
 	Sleep(ctx context.Context, in *SleepRequest, opts ...grpc.CallOption) (*SleepResponse, error)
+	// GetCurrentFrame returns the image currently shown on the EPD as PNG
+	GetCurrentFrame(ctx context.Context, in *GetCurrentFrameRequest, opts ...grpc.CallOption) (*GetCurrentFrameResponse, error)
+	// GetStatus reports the state of the EPD and the daemon's queue
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
+	// WatchEvents streams display activity events until the client disconnects
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// ListDisplays reports the state of every display the daemon serves
+	ListDisplays(ctx context.Context, in *ListDisplaysRequest, opts ...grpc.CallOption) (*ListDisplaysResponse, error)
+	// AddSchedule adds content the daemon displays on a cron schedule
+	AddSchedule(ctx context.Context, in *AddScheduleRequest, opts ...grpc.CallOption) (*Schedule, error)
+	// RemoveSchedule removes a scheduled entry by ID
+	RemoveSchedule(ctx context.Context, in *RemoveScheduleRequest, opts ...grpc.CallOption) (*RemoveScheduleResponse, error)
+	// ListSchedules returns every scheduled entry and the quiet hours
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	// SetQuietHours sets the daily window in which scheduled entries don't run
+	SetQuietHours(ctx context.Context, in *QuietHours, opts ...grpc.CallOption) (*QuietHours, error)
+	// AddPlaylistItem appends an item to the playlist the daemon cycles through
+	AddPlaylistItem(ctx context.Context, in *AddPlaylistItemRequest, opts ...grpc.CallOption) (*PlaylistItem, error)
+	// RemovePlaylistItem removes a playlist item by ID
+	RemovePlaylistItem(ctx context.Context, in *RemovePlaylistItemRequest, opts ...grpc.CallOption) (*RemovePlaylistItemResponse, error)
+	// GetPlaylist returns the playlist and what it is currently showing
+	GetPlaylist(ctx context.Context, in *GetPlaylistRequest, opts ...grpc.CallOption) (*Playlist, error)
+	// SkipPlaylistItem shows the next playlist item immediately
+	SkipPlaylistItem(ctx context.Context, in *SkipPlaylistItemRequest, opts ...grpc.CallOption) (*Playlist, error)
+	// PausePlaylist pauses or resumes cycling through the playlist
+	PausePlaylist(ctx context.Context, in *PausePlaylistRequest, opts ...grpc.CallOption) (*Playlist, error)
+	// ShufflePlaylist switches between playing items in order and shuffled
+	ShufflePlaylist(ctx context.Context, in *ShufflePlaylistRequest, opts ...grpc.CallOption) (*Playlist, error)
 }
 
 type ePDServiceClient struct {
@@ -57,6 +114,19 @@ func (c *ePDServiceClient) DisplayImage(ctx context.Context, in *DisplayImageReq
 	return out, nil
 }
 
+func (c *ePDServiceClient) UploadImage(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImageChunk, DisplayImageResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EPDService_ServiceDesc.Streams[0], EPDService_UploadImage_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImageChunk, DisplayImageResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EPDService_UploadImageClient = grpc.ClientStreamingClient[ImageChunk, DisplayImageResponse]
+
 func (c *ePDServiceClient) DisplayText(ctx context.Context, in *DisplayTextRequest, opts ...grpc.CallOption) (*DisplayTextResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DisplayTextResponse)
@@ -67,6 +137,26 @@ func (c *ePDServiceClient) DisplayText(ctx context.Context, in *DisplayTextReque
 	return out, nil
 }
 
+func (c *ePDServiceClient) DisplayMarkdown(ctx context.Context, in *DisplayMarkdownRequest, opts ...grpc.CallOption) (*DisplayMarkdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisplayMarkdownResponse)
+	err := c.cc.Invoke(ctx, EPDService_DisplayMarkdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ePDServiceClient) RenderDashboard(ctx context.Context, in *RenderDashboardRequest, opts ...grpc.CallOption) (*RenderDashboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderDashboardResponse)
+	err := c.cc.Invoke(ctx, EPDService_RenderDashboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ePDServiceClient) Clear(ctx context.Context, in *ClearRequest, opts ...grpc.CallOption) (*ClearResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ClearResponse)
@@ -87,18 +177,207 @@ func (c *ePDServiceClient) Sleep(ctx context.Context, in *SleepRequest, opts ...
 	return out, nil
 }
 
+func (c *ePDServiceClient) GetCurrentFrame(ctx context.Context, in *GetCurrentFrameRequest, opts ...grpc.CallOption) (*GetCurrentFrameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCurrentFrameResponse)
+	err := c.cc.Invoke(ctx, EPDService_GetCurrentFrame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ePDServiceClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, EPDService_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ePDServiceClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EPDService_ServiceDesc.Streams[1], EPDService_WatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EPDService_WatchEventsClient = grpc.ServerStreamingClient[Event]
+
+func (c *ePDServiceClient) ListDisplays(ctx context.Context, in *ListDisplaysRequest, opts ...grpc.CallOption) (*ListDisplaysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDisplaysResponse)
+	err := c.cc.Invoke(ctx, EPDService_ListDisplays_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ePDServiceClient) AddSchedule(ctx context.Context, in *AddScheduleRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, EPDService_AddSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ePDServiceClient) RemoveSchedule(ctx context.Context, in *RemoveScheduleRequest, opts ...grpc.CallOption) (*RemoveScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveScheduleResponse)
+	err := c.cc.Invoke(ctx, EPDService_RemoveSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ePDServiceClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, EPDService_ListSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ePDServiceClient) SetQuietHours(ctx context.Context, in *QuietHours, opts ...grpc.CallOption) (*QuietHours, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuietHours)
+	err := c.cc.Invoke(ctx, EPDService_SetQuietHours_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ePDServiceClient) AddPlaylistItem(ctx context.Context, in *AddPlaylistItemRequest, opts ...grpc.CallOption) (*PlaylistItem, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlaylistItem)
+	err := c.cc.Invoke(ctx, EPDService_AddPlaylistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ePDServiceClient) RemovePlaylistItem(ctx context.Context, in *RemovePlaylistItemRequest, opts ...grpc.CallOption) (*RemovePlaylistItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemovePlaylistItemResponse)
+	err := c.cc.Invoke(ctx, EPDService_RemovePlaylistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ePDServiceClient) GetPlaylist(ctx context.Context, in *GetPlaylistRequest, opts ...grpc.CallOption) (*Playlist, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Playlist)
+	err := c.cc.Invoke(ctx, EPDService_GetPlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ePDServiceClient) SkipPlaylistItem(ctx context.Context, in *SkipPlaylistItemRequest, opts ...grpc.CallOption) (*Playlist, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Playlist)
+	err := c.cc.Invoke(ctx, EPDService_SkipPlaylistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ePDServiceClient) PausePlaylist(ctx context.Context, in *PausePlaylistRequest, opts ...grpc.CallOption) (*Playlist, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Playlist)
+	err := c.cc.Invoke(ctx, EPDService_PausePlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ePDServiceClient) ShufflePlaylist(ctx context.Context, in *ShufflePlaylistRequest, opts ...grpc.CallOption) (*Playlist, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Playlist)
+	err := c.cc.Invoke(ctx, EPDService_ShufflePlaylist_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EPDServiceServer is the server API for EPDService service.
 // All implementations must embed UnimplementedEPDServiceServer
 // for forward compatibility.
+//
+// EPDService drives one or more displays. Requests address a display by name
+// with the "epd-display" metadata key; without it they go to the default
+// display.
 type EPDServiceServer interface {
 	// DisplayImage accepts PNG image data and displays it on the EPD
 	DisplayImage(context.Context, *DisplayImageRequest) (*DisplayImageResponse, error)
+	// UploadImage streams PNG image data in chunks and displays it on the EPD.
+	// Use it for images too large to fit in a single gRPC message.
+	UploadImage(grpc.ClientStreamingServer[ImageChunk, DisplayImageResponse]) error
 	// DisplayText renders text and displays it on the EPD
 	DisplayText(context.Context, *DisplayTextRequest) (*DisplayTextResponse, error)
+	// DisplayMarkdown renders markdown on the daemon and displays it on the EPD
+	DisplayMarkdown(context.Context, *DisplayMarkdownRequest) (*DisplayMarkdownResponse, error)
+	// RenderDashboard renders the dashboard on the daemon, using its own fonts
+	// and weather configuration, and displays it on the EPD
+	RenderDashboard(context.Context, *RenderDashboardRequest) (*RenderDashboardResponse, error)
 	// Clear clears the EPD to white
 	Clear(context.Context, *ClearRequest) (*ClearResponse, error)
 	// Sleep puts the EPD into sleep mode
 	Sleep(context.Context, *SleepRequest) (*SleepResponse, error)
+	// GetCurrentFrame returns the image currently shown on the EPD as PNG
+	GetCurrentFrame(context.Context, *GetCurrentFrameRequest) (*GetCurrentFrameResponse, error)
+	// GetStatus reports the state of the EPD and the daemon's queue
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
+	// WatchEvents streams display activity events until the client disconnects
+	WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error
+	// ListDisplays reports the state of every display the daemon serves
+	ListDisplays(context.Context, *ListDisplaysRequest) (*ListDisplaysResponse, error)
+	// AddSchedule adds content the daemon displays on a cron schedule
+	AddSchedule(context.Context, *AddScheduleRequest) (*Schedule, error)
+	// RemoveSchedule removes a scheduled entry by ID
+	RemoveSchedule(context.Context, *RemoveScheduleRequest) (*RemoveScheduleResponse, error)
+	// ListSchedules returns every scheduled entry and the quiet hours
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	// SetQuietHours sets the daily window in which scheduled entries don't run
+	SetQuietHours(context.Context, *QuietHours) (*QuietHours, error)
+	// AddPlaylistItem appends an item to the playlist the daemon cycles through
+	AddPlaylistItem(context.Context, *AddPlaylistItemRequest) (*PlaylistItem, error)
+	// RemovePlaylistItem removes a playlist item by ID
+	RemovePlaylistItem(context.Context, *RemovePlaylistItemRequest) (*RemovePlaylistItemResponse, error)
+	// GetPlaylist returns the playlist and what it is currently showing
+	GetPlaylist(context.Context, *GetPlaylistRequest) (*Playlist, error)
+	// SkipPlaylistItem shows the next playlist item immediately
+	SkipPlaylistItem(context.Context, *SkipPlaylistItemRequest) (*Playlist, error)
+	// PausePlaylist pauses or resumes cycling through the playlist
+	PausePlaylist(context.Context, *PausePlaylistRequest) (*Playlist, error)
+	// ShufflePlaylist switches between playing items in order and shuffled
+	ShufflePlaylist(context.Context, *ShufflePlaylistRequest) (*Playlist, error)
 	mustEmbedUnimplementedEPDServiceServer()
 }
 
@@ -112,15 +391,66 @@ type UnimplementedEPDServiceServer struct{}
 func (UnimplementedEPDServiceServer) DisplayImage(context.Context, *DisplayImageRequest) (*DisplayImageResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DisplayImage not implemented")
 }
+func (UnimplementedEPDServiceServer) UploadImage(grpc.ClientStreamingServer[ImageChunk, DisplayImageResponse]) error {
+	return status.Error(codes.Unimplemented, "method UploadImage not implemented")
+}
 func (UnimplementedEPDServiceServer) DisplayText(context.Context, *DisplayTextRequest) (*DisplayTextResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DisplayText not implemented")
 }
+func (UnimplementedEPDServiceServer) DisplayMarkdown(context.Context, *DisplayMarkdownRequest) (*DisplayMarkdownResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DisplayMarkdown not implemented")
+}
+func (UnimplementedEPDServiceServer) RenderDashboard(context.Context, *RenderDashboardRequest) (*RenderDashboardResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenderDashboard not implemented")
+}
 func (UnimplementedEPDServiceServer) Clear(context.Context, *ClearRequest) (*ClearResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Clear not implemented")
 }
 func (UnimplementedEPDServiceServer) Sleep(context.Context, *SleepRequest) (*SleepResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Sleep not implemented")
 }
+func (UnimplementedEPDServiceServer) GetCurrentFrame(context.Context, *GetCurrentFrameRequest) (*GetCurrentFrameResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCurrentFrame not implemented")
+}
+func (UnimplementedEPDServiceServer) GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedEPDServiceServer) WatchEvents(*WatchEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Error(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedEPDServiceServer) ListDisplays(context.Context, *ListDisplaysRequest) (*ListDisplaysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDisplays not implemented")
+}
+func (UnimplementedEPDServiceServer) AddSchedule(context.Context, *AddScheduleRequest) (*Schedule, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddSchedule not implemented")
+}
+func (UnimplementedEPDServiceServer) RemoveSchedule(context.Context, *RemoveScheduleRequest) (*RemoveScheduleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveSchedule not implemented")
+}
+func (UnimplementedEPDServiceServer) ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (UnimplementedEPDServiceServer) SetQuietHours(context.Context, *QuietHours) (*QuietHours, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetQuietHours not implemented")
+}
+func (UnimplementedEPDServiceServer) AddPlaylistItem(context.Context, *AddPlaylistItemRequest) (*PlaylistItem, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddPlaylistItem not implemented")
+}
+func (UnimplementedEPDServiceServer) RemovePlaylistItem(context.Context, *RemovePlaylistItemRequest) (*RemovePlaylistItemResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemovePlaylistItem not implemented")
+}
+func (UnimplementedEPDServiceServer) GetPlaylist(context.Context, *GetPlaylistRequest) (*Playlist, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPlaylist not implemented")
+}
+func (UnimplementedEPDServiceServer) SkipPlaylistItem(context.Context, *SkipPlaylistItemRequest) (*Playlist, error) {
+	return nil, status.Error(codes.Unimplemented, "method SkipPlaylistItem not implemented")
+}
+func (UnimplementedEPDServiceServer) PausePlaylist(context.Context, *PausePlaylistRequest) (*Playlist, error) {
+	return nil, status.Error(codes.Unimplemented, "method PausePlaylist not implemented")
+}
+func (UnimplementedEPDServiceServer) ShufflePlaylist(context.Context, *ShufflePlaylistRequest) (*Playlist, error) {
+	return nil, status.Error(codes.Unimplemented, "method ShufflePlaylist not implemented")
+}
 func (UnimplementedEPDServiceServer) mustEmbedUnimplementedEPDServiceServer() {}
 func (UnimplementedEPDServiceServer) testEmbeddedByValue()                    {}
 
@@ -160,6 +490,13 @@ func _EPDService_DisplayImage_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EPDService_UploadImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EPDServiceServer).UploadImage(&grpc.GenericServerStream[ImageChunk, DisplayImageResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EPDService_UploadImageServer = grpc.ClientStreamingServer[ImageChunk, DisplayImageResponse]
+
 func _EPDService_DisplayText_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DisplayTextRequest)
 	if err := dec(in); err != nil {
@@ -178,6 +515,42 @@ func _EPDService_DisplayText_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EPDService_DisplayMarkdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisplayMarkdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).DisplayMarkdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_DisplayMarkdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).DisplayMarkdown(ctx, req.(*DisplayMarkdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EPDService_RenderDashboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderDashboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).RenderDashboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_RenderDashboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).RenderDashboard(ctx, req.(*RenderDashboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _EPDService_Clear_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ClearRequest)
 	if err := dec(in); err != nil {
@@ -214,6 +587,251 @@ func _EPDService_Sleep_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EPDService_GetCurrentFrame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentFrameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).GetCurrentFrame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_GetCurrentFrame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).GetCurrentFrame(ctx, req.(*GetCurrentFrameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EPDService_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EPDService_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EPDServiceServer).WatchEvents(m, &grpc.GenericServerStream[WatchEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EPDService_WatchEventsServer = grpc.ServerStreamingServer[Event]
+
+func _EPDService_ListDisplays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDisplaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).ListDisplays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_ListDisplays_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).ListDisplays(ctx, req.(*ListDisplaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EPDService_AddSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).AddSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_AddSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).AddSchedule(ctx, req.(*AddScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EPDService_RemoveSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).RemoveSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_RemoveSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).RemoveSchedule(ctx, req.(*RemoveScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EPDService_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_ListSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EPDService_SetQuietHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuietHours)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).SetQuietHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_SetQuietHours_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).SetQuietHours(ctx, req.(*QuietHours))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EPDService_AddPlaylistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPlaylistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).AddPlaylistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_AddPlaylistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).AddPlaylistItem(ctx, req.(*AddPlaylistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EPDService_RemovePlaylistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePlaylistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).RemovePlaylistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_RemovePlaylistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).RemovePlaylistItem(ctx, req.(*RemovePlaylistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EPDService_GetPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).GetPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_GetPlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).GetPlaylist(ctx, req.(*GetPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EPDService_SkipPlaylistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SkipPlaylistItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).SkipPlaylistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_SkipPlaylistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).SkipPlaylistItem(ctx, req.(*SkipPlaylistItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EPDService_PausePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PausePlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).PausePlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_PausePlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).PausePlaylist(ctx, req.(*PausePlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EPDService_ShufflePlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShufflePlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EPDServiceServer).ShufflePlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EPDService_ShufflePlaylist_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EPDServiceServer).ShufflePlaylist(ctx, req.(*ShufflePlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EPDService_ServiceDesc is the grpc.ServiceDesc for EPDService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +847,14 @@ var EPDService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DisplayText",
 			Handler:    _EPDService_DisplayText_Handler,
 		},
+		{
+			MethodName: "DisplayMarkdown",
+			Handler:    _EPDService_DisplayMarkdown_Handler,
+		},
+		{
+			MethodName: "RenderDashboard",
+			Handler:    _EPDService_RenderDashboard_Handler,
+		},
 		{
 			MethodName: "Clear",
 			Handler:    _EPDService_Clear_Handler,
@@ -237,7 +863,70 @@ var EPDService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Sleep",
 			Handler:    _EPDService_Sleep_Handler,
 		},
+		{
+			MethodName: "GetCurrentFrame",
+			Handler:    _EPDService_GetCurrentFrame_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _EPDService_GetStatus_Handler,
+		},
+		{
+			MethodName: "ListDisplays",
+			Handler:    _EPDService_ListDisplays_Handler,
+		},
+		{
+			MethodName: "AddSchedule",
+			Handler:    _EPDService_AddSchedule_Handler,
+		},
+		{
+			MethodName: "RemoveSchedule",
+			Handler:    _EPDService_RemoveSchedule_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _EPDService_ListSchedules_Handler,
+		},
+		{
+			MethodName: "SetQuietHours",
+			Handler:    _EPDService_SetQuietHours_Handler,
+		},
+		{
+			MethodName: "AddPlaylistItem",
+			Handler:    _EPDService_AddPlaylistItem_Handler,
+		},
+		{
+			MethodName: "RemovePlaylistItem",
+			Handler:    _EPDService_RemovePlaylistItem_Handler,
+		},
+		{
+			MethodName: "GetPlaylist",
+			Handler:    _EPDService_GetPlaylist_Handler,
+		},
+		{
+			MethodName: "SkipPlaylistItem",
+			Handler:    _EPDService_SkipPlaylistItem_Handler,
+		},
+		{
+			MethodName: "PausePlaylist",
+			Handler:    _EPDService_PausePlaylist_Handler,
+		},
+		{
+			MethodName: "ShufflePlaylist",
+			Handler:    _EPDService_ShufflePlaylist_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadImage",
+			Handler:       _EPDService_UploadImage_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _EPDService_WatchEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/epd.proto",
 }